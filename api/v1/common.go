@@ -16,7 +16,10 @@ limitations under the License.
 
 package v1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+)
 
 // VolumeGroupDeletionPolicy describes a policy for end-of-life maintenance of
 // volume group contents
@@ -32,13 +35,178 @@ const (
 	VolumeGroupContentRetain VolumeGroupDeletionPolicy = "Retain"
 )
 
-// Describes an error encountered on the group
-type VolumeGroupError struct {
-	// time is the timestamp when the error was encountered.
+// VolumeGroupSnapshotDeletionPolicy describes a policy for end-of-life maintenance of
+// volume group snapshot contents
+type VolumeGroupSnapshotDeletionPolicy string
+
+const (
+	// VolumeGroupSnapshotContentDelete means the group snapshot will be deleted from the
+	// underlying storage system on release from its volume group snapshot.
+	VolumeGroupSnapshotContentDelete VolumeGroupSnapshotDeletionPolicy = "Delete"
+
+	// VolumeGroupSnapshotContentRetain means the group snapshot will be left in its current
+	// state on release from its volume group snapshot.
+	VolumeGroupSnapshotContentRetain VolumeGroupSnapshotDeletionPolicy = "Retain"
+)
+
+// MembershipDriftPolicy describes how a membership drift between a volume group
+// content and the underlying storage system is handled
+type MembershipDriftPolicy string
+
+const (
+	// MembershipDriftReport means a membership drift is only reported.
+	MembershipDriftReport MembershipDriftPolicy = "Report"
+
+	// MembershipDriftRemediate means a membership drift is reported and the
+	// membership on the underlying storage system is set back to the volume group content.
+	MembershipDriftRemediate MembershipDriftPolicy = "Remediate"
+)
+
+// VolumeGroupRestorePhase is the restore phase of a member of a restored volume group
+type VolumeGroupRestorePhase string
+
+const (
+	// RestorePending means the persistent volume claim of the member is not bound yet.
+	RestorePending VolumeGroupRestorePhase = "Pending"
+
+	// RestoreBound means the persistent volume claim of the member is bound.
+	RestoreBound VolumeGroupRestorePhase = "Bound"
+
+	// RestoreWaitingForFirstConsumer means the persistent volume claim of the member is pending
+	// until a pod uses it, because its storage class binds volumes on first consumer.
+	RestoreWaitingForFirstConsumer VolumeGroupRestorePhase = "WaitingForFirstConsumer"
+
+	// RestoreFailed means the member could not be restored.
+	RestoreFailed VolumeGroupRestorePhase = "Failed"
+)
+
+// VolumeGroupMemberState is the membership state of a volume in a volume group
+type VolumeGroupMemberState string
+
+const (
+	// MemberPending means the volume should join the group and is not a member on the underlying storage system yet.
+	MemberPending VolumeGroupMemberState = "Pending"
+	// MemberJoined means the volume is a member of the group on the underlying storage system.
+	MemberJoined VolumeGroupMemberState = "Joined"
+	// MemberLeaving means the volume should leave the group and is still a member on the underlying storage system.
+	MemberLeaving VolumeGroupMemberState = "Leaving"
+	// MemberFailed means the volume should join the group and the membership modification on the
+	// underlying storage system failed, it is retried on the next reconcile.
+	MemberFailed VolumeGroupMemberState = "Failed"
+)
+
+// Reasons of member states.
+const (
+	// ReasonModifyFailed means the membership modification on the underlying storage system failed
+	// and the member was rolled back to its previous observed state.
+	ReasonModifyFailed = "ModifyFailed"
+)
+
+// VolumeGroupMemberReference identifies a member of a volume group with the fields the operator needs,
+// instead of embedding the whole persistent volume claim or persistent volume object.
+type VolumeGroupMemberReference struct {
+	// The name of the persistent volume claim, or of the persistent volume in a VolumeGroupContent.
+	Name string `json:"name"`
+
+	// +optional
+	// The namespace of the persistent volume claim, it is empty for a persistent volume.
+	Namespace string `json:"namespace,omitempty"`
+
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+
 	// +optional
-	Time *metav1.Time `json:"time,omitempty"`
+	// The CSI volume handle of the member volume.
+	VolumeHandle string `json:"volumeHandle,omitempty"`
 
-	// message details the encountered error
 	// +optional
-	Message *string `json:"message,omitempty"`
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+
+	// +optional
+	// The storage class of the member, it is used to restore the member from a snapshot.
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// +optional
+	// The claim the persistent volume was bound to when it joined the VolumeGroupContent.
+	ClaimRef *VolumeGroupMemberClaimReference `json:"claimRef,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=Pending;Joined;Leaving;Failed
+	State VolumeGroupMemberState `json:"state,omitempty"`
+
+	// +optional
+	// The reason of the last state change of the member, it is set when the member failed to join or leave the group.
+	Reason string `json:"reason,omitempty"`
 }
+
+// VolumeGroupMemberClaimReference identifies the persistent volume claim of a persistent volume member
+type VolumeGroupMemberClaimReference struct {
+	Name string `json:"name"`
+
+	Namespace string `json:"namespace"`
+
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+}
+
+// Condition types of the volume group and volume group snapshot objects
+const (
+	// ConditionReady is True when the object is reconciled successfully, it is False
+	// when any other condition reports a failure.
+	ConditionReady = "Ready"
+
+	// ConditionBound is True when the VolumeGroup is bound to its VolumeGroupContent.
+	ConditionBound = "Bound"
+
+	// ConditionBackendGroupCreated is True when the volume group exists on the
+	// underlying storage system.
+	ConditionBackendGroupCreated = "BackendGroupCreated"
+
+	// ConditionMembershipSynced is True when the volume group members on the
+	// underlying storage system match the claims selected by the VolumeGroup.
+	ConditionMembershipSynced = "MembershipSynced"
+
+	// ConditionDriverReachable is False when the last call to the CSI driver
+	// could not reach it.
+	ConditionDriverReachable = "DriverReachable"
+
+	// ConditionDeleting is True when the object is being deleted.
+	ConditionDeleting = "Deleting"
+
+	// ConditionParametersValid is True when the VolumeGroupClass parameters are valid.
+	ConditionParametersValid = "ParametersValid"
+
+	// ConditionSettingsSupported is True when the driver of the VolumeGroupClass supports
+	// the operations its settings need.
+	ConditionSettingsSupported = "SettingsSupported"
+
+	// ConditionSnapshotCreated is True when the group snapshot exists on the
+	// underlying storage system.
+	ConditionSnapshotCreated = "SnapshotCreated"
+
+	// ConditionRestored is True when all the members of a VolumeGroup restored from
+	// a VolumeGroupSnapshot are bound.
+	ConditionRestored = "Restored"
+
+	// ConditionMembershipDrift is True when the volume group members on the underlying
+	// storage system do not match the persistent volumes of the VolumeGroupContent.
+	ConditionMembershipDrift = "MembershipDrift"
+
+	// ConditionPVsBound is True when all the persistent volumes of the VolumeGroupContent
+	// exist and are still bound to the claims they were added with.
+	ConditionPVsBound = "PVsBound"
+)
+
+// Reasons of conditions that do not report a failure. A failure uses the
+// reason of the event that reports it.
+const (
+	ReasonSucceeded         = "Succeeded"
+	ReasonPending           = "Pending"
+	ReasonInProgress        = "InProgress"
+	ReasonPVDeleted         = "PVDeleted"
+	ReasonPVRebound         = "PVRebound"
+	ReasonPVReleased        = "PVReleased"
+	ReasonDeletionRequested = "DeletionRequested"
+	ReasonDriftDetected     = "DriftDetected"
+	ReasonDriftRemediated   = "DriftRemediated"
+)
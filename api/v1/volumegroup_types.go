@@ -29,6 +29,11 @@ type VolumeGroupSpec struct {
 
 	// Source has the information about where the group is created from.
 	Source VolumeGroupSource `json:"source"`
+
+	// +optional
+	// The time membership changes are coalesced for before they are sent to the storage in one
+	// membership modification. It overrides the --membership-batch-window option of the operator, 0 disables it.
+	MembershipBatchWindow *metav1.Duration `json:"membershipBatchWindow,omitempty"`
 }
 
 // VolumeGroupSource contains several options.
@@ -45,6 +50,17 @@ type VolumeGroupSource struct {
 	// In Phase 1, when the label is added to PVC, the PVC will be added to the matching group.
 	// In Phase 2, this labelSelector will be used to find all PVCs with matching label and add them to the group when the group is being created.
 	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// +optional
+	// A label query over namespaces whose persistent volume claims can be added to the volume group.
+	// Persistent volume claims in the volume group namespace are always matched by the selector,
+	// when namespaceSelector is not set no other namespace is matched.
+	// It is honored only when the VolumeGroupClass allows it with allowNamespaceSelector.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// +optional
+	// A VolumeGroupSnapshot in the volume group namespace to restore the volume group from.
+	// A persistent volume claim is provisioned for every snapshotted member with the selector labels,
+	// so the selector must have matchLabels.
+	DataSource *corev1.TypedLocalObjectReference `json:"dataSource,omitempty"`
 }
 
 // VolumeGroupStatus defines the observed state of VolumeGroup
@@ -55,23 +71,95 @@ type VolumeGroupStatus struct {
 	// +optional
 	GroupCreationTime *metav1.Time `json:"groupCreationTime,omitempty"`
 
-	// A list of persistent volume claims
+	// Deprecated: PVCList is replaced by Members. It is only read to migrate
+	// existing objects and it is cleared once they are migrated.
 	// +optional
 	PVCList []corev1.PersistentVolumeClaim `json:"pvcList,omitempty"`
 
+	// The persistent volume claims that are members of the volume group. It is empty when the
+	// members are stored in VolumeGroupMember objects.
+	// +optional
+	Members []VolumeGroupMemberReference `json:"members,omitempty"`
+
+	// The number of members of the volume group, it is set when the members are stored in
+	// VolumeGroupMember objects.
+	// +optional
+	MemberCounts *VolumeGroupMemberCounts `json:"memberCounts,omitempty"`
+
+	// The time the pending membership changes of the volume group are sent to the storage,
+	// it is set while membership changes are coalesced.
+	// +optional
+	MembershipBatchFlushTime *metav1.Time `json:"membershipBatchFlushTime,omitempty"`
+
+	// The number of membership changes that wait for the membership batch to be flushed
+	// +optional
+	PendingMembershipChanges int32 `json:"pendingMembershipChanges,omitempty"`
+
+	// The restore progress of every member when the volume group is restored from a dataSource
+	// +optional
+	RestoredMembers []VolumeGroupRestoredMember `json:"restoredMembers,omitempty"`
+
+	// RetryCount is the number of consecutive reconciles that failed or are still waiting,
+	// it is reset by a successful reconcile.
+	// +optional
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// NextRetryTime is the time of the next reconcile after a failure. It is empty when
+	// the failure is not retried until the spec of the VolumeGroup changes.
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// ObservedGeneration is the generation of the VolumeGroup that was last reconciled.
 	// +optional
-	Ready *bool `json:"ready,omitempty"`
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest observations of the volume group state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// VolumeGroupMemberCounts is the number of members of a volume group in every state
+type VolumeGroupMemberCounts struct {
+	Total int32 `json:"total"`
+
+	// +optional
+	Pending int32 `json:"pending,omitempty"`
+
+	// +optional
+	Joined int32 `json:"joined,omitempty"`
+
+	// +optional
+	Leaving int32 `json:"leaving,omitempty"`
+
+	// +optional
+	Failed int32 `json:"failed,omitempty"`
+}
+
+// VolumeGroupRestoredMember is the restore progress of a single snapshotted member
+type VolumeGroupRestoredMember struct {
+	// The name of the persistent volume that was snapshotted.
+	// +optional
+	SourcePersistentVolumeName string `json:"sourcePersistentVolumeName,omitempty"`
+
+	// The CSI snapshot handle the member is restored from.
+	SnapshotHandle string `json:"snapshotHandle"`
+
+	// The name of the persistent volume claim provisioned from the snapshot.
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+
+	Phase VolumeGroupRestorePhase `json:"phase"`
 
-	// Last error encountered during group creation
 	// +optional
-	Error *VolumeGroupError `json:"error,omitempty"`
+	Message string `json:"message,omitempty"`
 }
 
 // VolumeGroup is a user's request for a group of volumes
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced,shortName=vg
-// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="VolumeGroupClass",type=string,JSONPath=`.spec.volumeGroupClassName`
 // +kubebuilder:printcolumn:name="VolumeGroupContent",type=string,JSONPath=`.status.boundVolumeGroupContentName`
 // +kubebuilder:printcolumn:name="CreationTime",type=date,JSONPath=`.status.groupCreationTime`
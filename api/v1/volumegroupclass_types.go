@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,6 +29,7 @@ import (
 // +kubebuilder:printcolumn:name="Driver",type=string,JSONPath=`.driver`
 // +kubebuilder:printcolumn:name="DeletionPolicy",type=string,JSONPath=`.volumeGroupDeletionPolicy`
 // +kubebuilder:printcolumn:name="SupportVolumeGroupSnapshot",type=boolean,JSONPath=`.supportVolumeGroupSnapshot`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type VolumeGroupClass struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -50,6 +52,80 @@ type VolumeGroupClass struct {
 	// +optional
 	// +kubebuilder:default:=false
 	SupportVolumeGroupSnapshot *bool `json:"supportVolumeGroupSnapshot,omitempty"`
+
+	// This field specifies whether volume groups of this class can use a namespaceSelector
+	// to match persistent volume claims outside of their own namespace.
+	// +optional
+	// +kubebuilder:default:=false
+	AllowNamespaceSelector *bool `json:"allowNamespaceSelector,omitempty"`
+
+	// This field specifies how a drift between the volume group content members and the
+	// members on the storage system is handled, Report only reports it and Remediate
+	// also sets the members on the storage system back to the volume group content members.
+	// +optional
+	// +kubebuilder:default:=Report
+	// +kubebuilder:validation:Enum=Report;Remediate
+	MembershipDriftPolicy *MembershipDriftPolicy `json:"membershipDriftPolicy,omitempty"`
+
+	// This field enables the discovery of the volume groups on the storage system,
+	// an unbound volume group content is created for every discovered volume group
+	// that is not referenced by a volume group content yet.
+	// +optional
+	VolumeGroupDiscovery *VolumeGroupDiscovery `json:"volumeGroupDiscovery,omitempty"`
+
+	// This field specifies whether orphaned volume groups, volume groups on the storage system
+	// that were created by the operator but whose volume group content was deleted before it recorded
+	// their handle, are deleted after the garbage collection grace period.
+	// Only the volume groups recorded in the status of the class are deleted, so volume groups of another
+	// cluster that shares the storage system and volume groups released with a Retain policy are kept.
+	// +optional
+	// +kubebuilder:default:=false
+	DeleteOrphanedVolumeGroups *bool `json:"deleteOrphanedVolumeGroups,omitempty"`
+
+	// This field specifies whether the members of the volume groups of this class are stored in
+	// VolumeGroupMember objects, one per persistent volume claim, instead of the volume group status.
+	// It is meant for volume groups with thousands of members, their status keeps only the member counts.
+	// +optional
+	// +kubebuilder:default:=false
+	UseVolumeGroupMembers *bool `json:"useVolumeGroupMembers,omitempty"`
+
+	// Status represents the current information about a volume group class
+	// +optional
+	Status VolumeGroupClassStatus `json:"status,omitempty"`
+}
+
+// VolumeGroupDiscovery defines the volume group contents of the discovered volume groups
+type VolumeGroupDiscovery struct {
+	// Namespace of the volume group contents of the discovered volume groups.
+	Namespace string `json:"namespace"`
+}
+
+// VolumeGroupClassStatus defines the observed state of VolumeGroupClass
+type VolumeGroupClassStatus struct {
+	// ObservedGeneration is the generation of the VolumeGroupClass that was last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest observations of the volume group class state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// CreatedVolumeGroups are the volume groups the operator created on the storage system for this class
+	// whose handle is not recorded in their volume group content yet. The orphaned volume group collector
+	// deletes only these volume groups.
+	// +optional
+	CreatedVolumeGroups []CreatedVolumeGroup `json:"createdVolumeGroups,omitempty"`
+}
+
+// CreatedVolumeGroup is a volume group created on the storage system for a volume group content
+type CreatedVolumeGroup struct {
+	// VolumeGroupHandle is the id of the volume group returned by the driver.
+	VolumeGroupHandle string `json:"volumeGroupHandle"`
+
+	// VolumeGroupContentRef is the volume group content the volume group was created for.
+	VolumeGroupContentRef corev1.ObjectReference `json:"volumeGroupContentRef"`
 }
 
 //+kubebuilder:object:root=true
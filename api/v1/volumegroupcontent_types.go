@@ -69,16 +69,36 @@ type VolumeGroupContentStatus struct {
 	// +optional
 	GroupCreationTime *metav1.Time `json:"groupCreationTime,omitempty"`
 
-	// A list of persistent volumes
+	// Deprecated: PVList is replaced by Members. It is only read to migrate
+	// existing objects and it is cleared once they are migrated.
 	// +optional
 	PVList []corev1.PersistentVolume `json:"pvList,omitempty"`
 
+	// The persistent volumes that are members of the volume group. There is one member per persistent volume
+	// also when the volume group stores its members in VolumeGroupMember objects, so the size of a volume
+	// group is bounded by the object size limit of the API server, a few thousand persistent volumes.
 	// +optional
-	Ready *bool `json:"ready,omitempty"`
+	Members []VolumeGroupMemberReference `json:"members,omitempty"`
 
-	// Last error encountered during group creation
+	// RetryCount is the number of consecutive reconciles that failed or are still waiting,
+	// it is reset by a successful reconcile.
 	// +optional
-	Error *VolumeGroupError `json:"error,omitempty"`
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// NextRetryTime is the time of the next reconcile after a failure. It is empty when
+	// the failure is not retried until the spec of the VolumeGroupContent changes.
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// ObservedGeneration is the generation of the VolumeGroupContent that was last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest observations of the volume group content state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -88,7 +108,7 @@ type VolumeGroupContentStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Namespaced,shortName=vgc
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="DeletionPolicy",type=string,JSONPath=`.spec.volumeGroupDeletionPolicy`
 // +kubebuilder:printcolumn:name="Driver",type=string,JSONPath=`.spec.source.driver`
 // +kubebuilder:printcolumn:name="VolumeGroupClass",type=string,JSONPath=`.spec.volumeGroupClassName`
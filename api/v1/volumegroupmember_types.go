@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VolumeGroupMemberSpec identifies the persistent volume claim that is a member of a volume group
+type VolumeGroupMemberSpec struct {
+	// The name of the VolumeGroup in the same namespace that the persistent volume claim is a member of.
+	VolumeGroupName string `json:"volumeGroupName"`
+
+	// The persistent volume claim of the member.
+	PersistentVolumeClaim VolumeGroupMemberClaimReference `json:"persistentVolumeClaim"`
+}
+
+// VolumeGroupMemberStatus defines the observed state of VolumeGroupMember
+type VolumeGroupMemberStatus struct {
+	// +optional
+	// The CSI volume handle of the member volume.
+	VolumeHandle string `json:"volumeHandle,omitempty"`
+
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+
+	// +optional
+	// The storage class of the member, it is used to restore the member from a snapshot.
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=Pending;Joined;Leaving;Failed
+	State VolumeGroupMemberState `json:"state,omitempty"`
+
+	// +optional
+	// The reason of the last state change of the member.
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	// The last error of the member, it is cleared when the member joins the volume group.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// VolumeGroupMember is a persistent volume claim bound to a volume group. The members of a volume group
+// are stored in VolumeGroupMember objects instead of the volume group status when its class sets useVolumeGroupMembers.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=vgm
+// +kubebuilder:printcolumn:name="VolumeGroup",type=string,JSONPath=`.spec.volumeGroupName`
+// +kubebuilder:printcolumn:name="PVCNamespace",type=string,JSONPath=`.spec.persistentVolumeClaim.namespace`
+// +kubebuilder:printcolumn:name="PVC",type=string,JSONPath=`.spec.persistentVolumeClaim.name`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type VolumeGroupMember struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VolumeGroupMemberSpec `json:"spec"`
+
+	// +optional
+	Status VolumeGroupMemberStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VolumeGroupMemberList contains a list of VolumeGroupMember
+type VolumeGroupMemberList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeGroupMember `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VolumeGroupMember{}, &VolumeGroupMemberList{})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VolumeGroupSnapshotSpec describes the snapshot requested for a group of volumes
+type VolumeGroupSnapshotSpec struct {
+	// +optional
+	VolumeGroupSnapshotClassName *string `json:"volumeGroupSnapshotClassName,omitempty"`
+
+	// Source has the information about what the group snapshot is taken from.
+	Source VolumeGroupSnapshotSource `json:"source"`
+}
+
+// VolumeGroupSnapshotSource contains several options.
+// OneOf the options must be defined.
+type VolumeGroupSnapshotSource struct {
+	// +optional
+	// Dynamically provisioned VolumeGroupSnapshot
+	// The name of the VolumeGroup in the same namespace whose member volumes are snapshotted.
+	VolumeGroupName *string `json:"volumeGroupName,omitempty"`
+
+	// +optional
+	// Pre-provisioned VolumeGroupSnapshot
+	VolumeGroupSnapshotContentName *string `json:"volumeGroupSnapshotContentName,omitempty"`
+}
+
+// VolumeGroupSnapshotStatus defines the observed state of VolumeGroupSnapshot
+type VolumeGroupSnapshotStatus struct {
+	// +optional
+	BoundVolumeGroupSnapshotContentName *string `json:"boundVolumeGroupSnapshotContentName,omitempty"`
+
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// ObservedGeneration is the generation of the VolumeGroupSnapshot that was last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest observations of the volume group snapshot state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// VolumeGroupSnapshot is a user's request for a snapshot of a group of volumes
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=vgs
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="VolumeGroup",type=string,JSONPath=`.spec.source.volumeGroupName`
+// +kubebuilder:printcolumn:name="VolumeGroupSnapshotClass",type=string,JSONPath=`.spec.volumeGroupSnapshotClassName`
+// +kubebuilder:printcolumn:name="VolumeGroupSnapshotContent",type=string,JSONPath=`.status.boundVolumeGroupSnapshotContentName`
+// +kubebuilder:printcolumn:name="CreationTime",type=date,JSONPath=`.status.creationTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type VolumeGroupSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the volume group snapshot requested by a user
+	Spec VolumeGroupSnapshotSpec `json:"spec,omitempty"`
+	// Status represents the current information about a volume group snapshot
+	// +optional
+	Status VolumeGroupSnapshotStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VolumeGroupSnapshotList contains a list of VolumeGroupSnapshot
+type VolumeGroupSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeGroupSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VolumeGroupSnapshot{}, &VolumeGroupSnapshotList{})
+}
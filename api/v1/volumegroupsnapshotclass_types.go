@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+
+// VolumeGroupSnapshotClass is the Schema for the volumegroupsnapshotclasses API
+// +kubebuilder:resource:scope=Cluster,shortName=vgsclass
+// +kubebuilder:printcolumn:name="Driver",type=string,JSONPath=`.driver`
+// +kubebuilder:printcolumn:name="DeletionPolicy",type=string,JSONPath=`.deletionPolicy`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type VolumeGroupSnapshotClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Driver is the driver expected to handle this VolumeGroupSnapshotClass.
+	Driver string `json:"driver"`
+
+	// Parameters hold parameters for the driver.
+	// These values are opaque to the system and are passed directly
+	// to the driver.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// DeletionPolicy determines whether a VolumeGroupSnapshotContent created
+	// through this class and its snapshots on the storage are deleted when
+	// the bound VolumeGroupSnapshot is deleted.
+	// +optional
+	// +kubebuilder:default:=Delete
+	DeletionPolicy *VolumeGroupSnapshotDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VolumeGroupSnapshotClassList contains a list of VolumeGroupSnapshotClass
+type VolumeGroupSnapshotClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeGroupSnapshotClass `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VolumeGroupSnapshotClass{}, &VolumeGroupSnapshotClassList{})
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VolumeGroupSnapshotContentSpec defines the desired state of VolumeGroupSnapshotContent
+type VolumeGroupSnapshotContentSpec struct {
+	// +optional
+	VolumeGroupSnapshotClassName *string `json:"volumeGroupSnapshotClassName,omitempty"`
+
+	// +optional
+	// VolumeGroupSnapshotRef is part of a bi-directional binding between VolumeGroupSnapshot
+	// and VolumeGroupSnapshotContent.
+	VolumeGroupSnapshotRef *corev1.ObjectReference `json:"volumeGroupSnapshotRef,omitempty"`
+
+	Source VolumeGroupSnapshotContentSource `json:"source"`
+
+	// +optional
+	DeletionPolicy *VolumeGroupSnapshotDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// VolumeGroupSnapshotSecretRef is a reference to the secret object containing
+	// sensitive information to pass to the CSI driver to complete the CSI
+	// calls for VolumeGroupSnapshots.
+	// This field is optional, and may be empty if no secret is required. If the
+	// secret object contains more than one secret, all secrets are passed.
+	// +optional
+	VolumeGroupSnapshotSecretRef *corev1.SecretReference `json:"volumeGroupSnapshotSecretRef,omitempty"`
+}
+
+// VolumeGroupSnapshotContentSource
+type VolumeGroupSnapshotContentSource struct {
+	Driver string `json:"driver"`
+
+	// +optional
+	// The name of the VolumeGroupContent in the same namespace whose member volumes are snapshotted.
+	VolumeGroupContentName *string `json:"volumeGroupContentName,omitempty"`
+
+	// +optional
+	// VolumeGroupSnapshotHandle is the unique group snapshot id returned by the
+	// CSI volume plugin’s CreateVolumeGroupSnapshot to refer to the group snapshot on
+	// all subsequent calls.
+	VolumeGroupSnapshotHandle string `json:"volumeGroupSnapshotHandle,omitempty"`
+}
+
+// VolumeSnapshotHandle is the snapshot of a single member volume of a group snapshot
+type VolumeSnapshotHandle struct {
+	// +optional
+	// The name of the persistent volume that was snapshotted.
+	PersistentVolumeName string `json:"persistentVolumeName,omitempty"`
+
+	// +optional
+	// The name of the persistent volume claim that was bound to the snapshotted persistent volume.
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
+
+	// +optional
+	// The storage class of the snapshotted persistent volume.
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// The CSI volume handle of the snapshotted volume.
+	VolumeHandle string `json:"volumeHandle"`
+
+	// The CSI snapshot handle of the volume snapshot.
+	SnapshotHandle string `json:"snapshotHandle"`
+
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// +optional
+	RestoreSize *int64 `json:"restoreSize,omitempty"`
+
+	// +optional
+	ReadyToUse *bool `json:"readyToUse,omitempty"`
+}
+
+// VolumeGroupSnapshotContentStatus defines the observed state of VolumeGroupSnapshotContent
+type VolumeGroupSnapshotContentStatus struct {
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// +optional
+	ReadyToUse *bool `json:"readyToUse,omitempty"`
+
+	// A list of the snapshots of the member volumes
+	// +optional
+	VolumeSnapshotHandles []VolumeSnapshotHandle `json:"volumeSnapshotHandles,omitempty"`
+
+	// ObservedGeneration is the generation of the VolumeGroupSnapshotContent that was last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest observations of the volume group snapshot content state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// VolumeGroupSnapshotContent is the Schema for the volumegroupsnapshotcontents API
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vgsc
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="DeletionPolicy",type=string,JSONPath=`.spec.deletionPolicy`
+// +kubebuilder:printcolumn:name="Driver",type=string,JSONPath=`.spec.source.driver`
+// +kubebuilder:printcolumn:name="VolumeGroupSnapshotClass",type=string,JSONPath=`.spec.volumeGroupSnapshotClassName`
+// +kubebuilder:printcolumn:name="VolumeGroupSnapshot",type=string,JSONPath=`.spec.volumeGroupSnapshotRef.name`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type VolumeGroupSnapshotContent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the volume group snapshot content
+	Spec VolumeGroupSnapshotContentSpec `json:"spec,omitempty"`
+	// Status represents the current information about a volume group snapshot
+	// +optional
+	Status VolumeGroupSnapshotContentStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VolumeGroupSnapshotContentList contains a list of VolumeGroupSnapshotContent
+type VolumeGroupSnapshotContentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeGroupSnapshotContent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VolumeGroupSnapshotContent{}, &VolumeGroupSnapshotContentList{})
+}
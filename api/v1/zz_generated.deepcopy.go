@@ -26,6 +26,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CreatedVolumeGroup) DeepCopyInto(out *CreatedVolumeGroup) {
+	*out = *in
+	out.VolumeGroupContentRef = in.VolumeGroupContentRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CreatedVolumeGroup.
+func (in *CreatedVolumeGroup) DeepCopy() *CreatedVolumeGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(CreatedVolumeGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeGroup) DeepCopyInto(out *VolumeGroup) {
 	*out = *in
@@ -75,6 +91,32 @@ func (in *VolumeGroupClass) DeepCopyInto(out *VolumeGroupClass) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AllowNamespaceSelector != nil {
+		in, out := &in.AllowNamespaceSelector, &out.AllowNamespaceSelector
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MembershipDriftPolicy != nil {
+		in, out := &in.MembershipDriftPolicy, &out.MembershipDriftPolicy
+		*out = new(MembershipDriftPolicy)
+		**out = **in
+	}
+	if in.VolumeGroupDiscovery != nil {
+		in, out := &in.VolumeGroupDiscovery, &out.VolumeGroupDiscovery
+		*out = new(VolumeGroupDiscovery)
+		**out = **in
+	}
+	if in.DeleteOrphanedVolumeGroups != nil {
+		in, out := &in.DeleteOrphanedVolumeGroups, &out.DeleteOrphanedVolumeGroups
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseVolumeGroupMembers != nil {
+		in, out := &in.UseVolumeGroupMembers, &out.UseVolumeGroupMembers
+		*out = new(bool)
+		**out = **in
+	}
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupClass.
@@ -127,6 +169,33 @@ func (in *VolumeGroupClassList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupClassStatus) DeepCopyInto(out *VolumeGroupClassStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CreatedVolumeGroups != nil {
+		in, out := &in.CreatedVolumeGroups, &out.CreatedVolumeGroups
+		*out = make([]CreatedVolumeGroup, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupClassStatus.
+func (in *VolumeGroupClassStatus) DeepCopy() *VolumeGroupClassStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupClassStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeGroupContent) DeepCopyInto(out *VolumeGroupContent) {
 	*out = *in
@@ -267,15 +336,23 @@ func (in *VolumeGroupContentStatus) DeepCopyInto(out *VolumeGroupContentStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Ready != nil {
-		in, out := &in.Ready, &out.Ready
-		*out = new(bool)
-		**out = **in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]VolumeGroupMemberReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Error != nil {
-		in, out := &in.Error, &out.Error
-		*out = new(VolumeGroupError)
-		(*in).DeepCopyInto(*out)
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -290,25 +367,16 @@ func (in *VolumeGroupContentStatus) DeepCopy() *VolumeGroupContentStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VolumeGroupError) DeepCopyInto(out *VolumeGroupError) {
+func (in *VolumeGroupDiscovery) DeepCopyInto(out *VolumeGroupDiscovery) {
 	*out = *in
-	if in.Time != nil {
-		in, out := &in.Time, &out.Time
-		*out = (*in).DeepCopy()
-	}
-	if in.Message != nil {
-		in, out := &in.Message, &out.Message
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupError.
-func (in *VolumeGroupError) DeepCopy() *VolumeGroupError {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupDiscovery.
+func (in *VolumeGroupDiscovery) DeepCopy() *VolumeGroupDiscovery {
 	if in == nil {
 		return nil
 	}
-	out := new(VolumeGroupError)
+	out := new(VolumeGroupDiscovery)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -345,6 +413,529 @@ func (in *VolumeGroupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupMember) DeepCopyInto(out *VolumeGroupMember) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupMember.
+func (in *VolumeGroupMember) DeepCopy() *VolumeGroupMember {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeGroupMember) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupMemberClaimReference) DeepCopyInto(out *VolumeGroupMemberClaimReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupMemberClaimReference.
+func (in *VolumeGroupMemberClaimReference) DeepCopy() *VolumeGroupMemberClaimReference {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupMemberClaimReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupMemberCounts) DeepCopyInto(out *VolumeGroupMemberCounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupMemberCounts.
+func (in *VolumeGroupMemberCounts) DeepCopy() *VolumeGroupMemberCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupMemberCounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupMemberList) DeepCopyInto(out *VolumeGroupMemberList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeGroupMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupMemberList.
+func (in *VolumeGroupMemberList) DeepCopy() *VolumeGroupMemberList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupMemberList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeGroupMemberList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupMemberReference) DeepCopyInto(out *VolumeGroupMemberReference) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ClaimRef != nil {
+		in, out := &in.ClaimRef, &out.ClaimRef
+		*out = new(VolumeGroupMemberClaimReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupMemberReference.
+func (in *VolumeGroupMemberReference) DeepCopy() *VolumeGroupMemberReference {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupMemberReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupMemberSpec) DeepCopyInto(out *VolumeGroupMemberSpec) {
+	*out = *in
+	out.PersistentVolumeClaim = in.PersistentVolumeClaim
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupMemberSpec.
+func (in *VolumeGroupMemberSpec) DeepCopy() *VolumeGroupMemberSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupMemberSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupMemberStatus) DeepCopyInto(out *VolumeGroupMemberStatus) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupMemberStatus.
+func (in *VolumeGroupMemberStatus) DeepCopy() *VolumeGroupMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupRestoredMember) DeepCopyInto(out *VolumeGroupRestoredMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupRestoredMember.
+func (in *VolumeGroupRestoredMember) DeepCopy() *VolumeGroupRestoredMember {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupRestoredMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshot) DeepCopyInto(out *VolumeGroupSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshot.
+func (in *VolumeGroupSnapshot) DeepCopy() *VolumeGroupSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeGroupSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshotClass) DeepCopyInto(out *VolumeGroupSnapshotClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(VolumeGroupSnapshotDeletionPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshotClass.
+func (in *VolumeGroupSnapshotClass) DeepCopy() *VolumeGroupSnapshotClass {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshotClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeGroupSnapshotClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshotClassList) DeepCopyInto(out *VolumeGroupSnapshotClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeGroupSnapshotClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshotClassList.
+func (in *VolumeGroupSnapshotClassList) DeepCopy() *VolumeGroupSnapshotClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshotClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeGroupSnapshotClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshotContent) DeepCopyInto(out *VolumeGroupSnapshotContent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshotContent.
+func (in *VolumeGroupSnapshotContent) DeepCopy() *VolumeGroupSnapshotContent {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshotContent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeGroupSnapshotContent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshotContentList) DeepCopyInto(out *VolumeGroupSnapshotContentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeGroupSnapshotContent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshotContentList.
+func (in *VolumeGroupSnapshotContentList) DeepCopy() *VolumeGroupSnapshotContentList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshotContentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeGroupSnapshotContentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshotContentSource) DeepCopyInto(out *VolumeGroupSnapshotContentSource) {
+	*out = *in
+	if in.VolumeGroupContentName != nil {
+		in, out := &in.VolumeGroupContentName, &out.VolumeGroupContentName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshotContentSource.
+func (in *VolumeGroupSnapshotContentSource) DeepCopy() *VolumeGroupSnapshotContentSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshotContentSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshotContentSpec) DeepCopyInto(out *VolumeGroupSnapshotContentSpec) {
+	*out = *in
+	if in.VolumeGroupSnapshotClassName != nil {
+		in, out := &in.VolumeGroupSnapshotClassName, &out.VolumeGroupSnapshotClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.VolumeGroupSnapshotRef != nil {
+		in, out := &in.VolumeGroupSnapshotRef, &out.VolumeGroupSnapshotRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	in.Source.DeepCopyInto(&out.Source)
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(VolumeGroupSnapshotDeletionPolicy)
+		**out = **in
+	}
+	if in.VolumeGroupSnapshotSecretRef != nil {
+		in, out := &in.VolumeGroupSnapshotSecretRef, &out.VolumeGroupSnapshotSecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshotContentSpec.
+func (in *VolumeGroupSnapshotContentSpec) DeepCopy() *VolumeGroupSnapshotContentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshotContentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshotContentStatus) DeepCopyInto(out *VolumeGroupSnapshotContentStatus) {
+	*out = *in
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ReadyToUse != nil {
+		in, out := &in.ReadyToUse, &out.ReadyToUse
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VolumeSnapshotHandles != nil {
+		in, out := &in.VolumeSnapshotHandles, &out.VolumeSnapshotHandles
+		*out = make([]VolumeSnapshotHandle, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshotContentStatus.
+func (in *VolumeGroupSnapshotContentStatus) DeepCopy() *VolumeGroupSnapshotContentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshotContentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshotList) DeepCopyInto(out *VolumeGroupSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeGroupSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshotList.
+func (in *VolumeGroupSnapshotList) DeepCopy() *VolumeGroupSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeGroupSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshotSource) DeepCopyInto(out *VolumeGroupSnapshotSource) {
+	*out = *in
+	if in.VolumeGroupName != nil {
+		in, out := &in.VolumeGroupName, &out.VolumeGroupName
+		*out = new(string)
+		**out = **in
+	}
+	if in.VolumeGroupSnapshotContentName != nil {
+		in, out := &in.VolumeGroupSnapshotContentName, &out.VolumeGroupSnapshotContentName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshotSource.
+func (in *VolumeGroupSnapshotSource) DeepCopy() *VolumeGroupSnapshotSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshotSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshotSpec) DeepCopyInto(out *VolumeGroupSnapshotSpec) {
+	*out = *in
+	if in.VolumeGroupSnapshotClassName != nil {
+		in, out := &in.VolumeGroupSnapshotClassName, &out.VolumeGroupSnapshotClassName
+		*out = new(string)
+		**out = **in
+	}
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshotSpec.
+func (in *VolumeGroupSnapshotSpec) DeepCopy() *VolumeGroupSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshotStatus) DeepCopyInto(out *VolumeGroupSnapshotStatus) {
+	*out = *in
+	if in.BoundVolumeGroupSnapshotContentName != nil {
+		in, out := &in.BoundVolumeGroupSnapshotContentName, &out.BoundVolumeGroupSnapshotContentName
+		*out = new(string)
+		**out = **in
+	}
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshotStatus.
+func (in *VolumeGroupSnapshotStatus) DeepCopy() *VolumeGroupSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeGroupSource) DeepCopyInto(out *VolumeGroupSource) {
 	*out = *in
@@ -358,6 +949,16 @@ func (in *VolumeGroupSource) DeepCopyInto(out *VolumeGroupSource) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataSource != nil {
+		in, out := &in.DataSource, &out.DataSource
+		*out = new(corev1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSource.
@@ -379,6 +980,11 @@ func (in *VolumeGroupSpec) DeepCopyInto(out *VolumeGroupSpec) {
 		**out = **in
 	}
 	in.Source.DeepCopyInto(&out.Source)
+	if in.MembershipBatchWindow != nil {
+		in, out := &in.MembershipBatchWindow, &out.MembershipBatchWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSpec.
@@ -410,15 +1016,37 @@ func (in *VolumeGroupStatus) DeepCopyInto(out *VolumeGroupStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Ready != nil {
-		in, out := &in.Ready, &out.Ready
-		*out = new(bool)
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]VolumeGroupMemberReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MemberCounts != nil {
+		in, out := &in.MemberCounts, &out.MemberCounts
+		*out = new(VolumeGroupMemberCounts)
 		**out = **in
 	}
-	if in.Error != nil {
-		in, out := &in.Error, &out.Error
-		*out = new(VolumeGroupError)
-		(*in).DeepCopyInto(*out)
+	if in.MembershipBatchFlushTime != nil {
+		in, out := &in.MembershipBatchFlushTime, &out.MembershipBatchFlushTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestoredMembers != nil {
+		in, out := &in.RestoredMembers, &out.RestoredMembers
+		*out = make([]VolumeGroupRestoredMember, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -431,3 +1059,32 @@ func (in *VolumeGroupStatus) DeepCopy() *VolumeGroupStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotHandle) DeepCopyInto(out *VolumeSnapshotHandle) {
+	*out = *in
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestoreSize != nil {
+		in, out := &in.RestoreSize, &out.RestoreSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ReadyToUse != nil {
+		in, out := &in.ReadyToUse, &out.ReadyToUse
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotHandle.
+func (in *VolumeSnapshotHandle) DeepCopy() *VolumeSnapshotHandle {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotHandle)
+	in.DeepCopyInto(out)
+	return out
+}
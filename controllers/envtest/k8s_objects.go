@@ -39,10 +39,67 @@ var (
 			},
 		},
 	}
+	RestoredVG = &volumegroupv1.VolumeGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RestoredVGName,
+			Namespace: Namespace,
+		},
+		Spec: volumegroupv1.VolumeGroupSpec{
+			VolumeGroupClassName: &VGClassName,
+			Source: volumegroupv1.VolumeGroupSource{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: RestoredMatchLabels,
+				},
+				DataSource: &corev1.TypedLocalObjectReference{
+					APIGroup: &volumegroupv1.GroupVersion.Group,
+					Kind:     "VolumeGroupSnapshot",
+					Name:     VGSName,
+				},
+			},
+		},
+	}
 	VGClass = &volumegroupv1.VolumeGroupClass{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: VGClassName,
 		},
+		Driver:                     DriverName,
+		Parameters:                 StorageClassParameters,
+		SupportVolumeGroupSnapshot: &SupportVGSnapshot,
+	}
+	DiscoveryVGClass = &volumegroupv1.VolumeGroupClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: DiscoveryVGClassName,
+		},
+		Driver:     DriverName,
+		Parameters: StorageClassParameters,
+		VolumeGroupDiscovery: &volumegroupv1.VolumeGroupDiscovery{
+			Namespace: Namespace,
+		},
+	}
+	OrphanGCVGClass = &volumegroupv1.VolumeGroupClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: OrphanGCVGClassName,
+		},
+		Driver:                     DriverName,
+		Parameters:                 StorageClassParameters,
+		DeleteOrphanedVolumeGroups: &DeleteOrphanedVGs,
+	}
+	VGS = &volumegroupv1.VolumeGroupSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      VGSName,
+			Namespace: Namespace,
+		},
+		Spec: volumegroupv1.VolumeGroupSnapshotSpec{
+			VolumeGroupSnapshotClassName: &VGSClassName,
+			Source: volumegroupv1.VolumeGroupSnapshotSource{
+				VolumeGroupName: &VGName,
+			},
+		},
+	}
+	VGSClass = &volumegroupv1.VolumeGroupSnapshotClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: VGSClassName,
+		},
 		Driver:     DriverName,
 		Parameters: StorageClassParameters,
 	}
@@ -103,4 +160,50 @@ var (
 			},
 		},
 	}
+	OtherNamespaceObject = &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: OtherNamespace,
+		},
+	}
+	OtherPVC = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      OtherPVCName,
+			Namespace: OtherNamespace,
+			Labels:    FakeMatchLabels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &SCName,
+			VolumeName:       OtherPVName,
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: *resource.NewQuantity(100*1024*1024, resource.BinarySI),
+				},
+			},
+		},
+	}
+	OtherPV = &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: OtherPVName,
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: *resource.NewQuantity(100*1024*1024, resource.BinarySI),
+			},
+			StorageClassName:              SCName,
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{
+					Driver:       DriverName,
+					FSType:       "ext4",
+					VolumeHandle: "otherVolumeHandle",
+				},
+			},
+			ClaimRef: &corev1.ObjectReference{
+				Name:      OtherPVCName,
+				Namespace: OtherNamespace,
+			},
+		},
+	}
 )
@@ -27,12 +27,28 @@ const (
 var (
 	VGName                 = "fake-vg-name"
 	VGClassName            = "fake-vgclass-name"
+	DiscoveryVGClassName   = "fake-discovery-vgclass-name"
+	DiscoveredVGHandle     = "fake-discovered-vg-handle"
+	OrphanGCVGClassName    = "fake-orphan-gc-vgclass-name"
+	OrphanedVGHandle       = "volumegroup-fake-orphaned-vg-handle"
+	UnrecordedVGHandle     = "volumegroup-fake-unrecorded-vg-handle"
+	DeleteOrphanedVGs      = true
+	VGSName                = "fake-vgs-name"
+	RestoredVGName         = "fake-restored-vg-name"
+	VGSClassName           = "fake-vgsclass-name"
+	SupportVGSnapshot      = true
 	Namespace              = "default"
+	OtherNamespace         = "fake-other-namespace"
 	SecretName             = "fake-secret-name"
 	PVCName                = "fake-pvc-name"
 	PVName                 = "fake-pv-name"
+	OtherPVCName           = "fake-other-pvc-name"
+	OtherPVName            = "fake-other-pv-name"
 	SCName                 = "fake-storage-class-name"
 	DriverName             = "driver.name"
+	SecondDriverName       = "second.driver.name"
+	UnservedDriverName     = "unserved.driver.name"
+	StoppableDriverName    = "stoppable.driver.name"
 	StorageClassParameters = map[string]string{
 		"volumegroup.storage.ibm.io/secret-name":      SecretName,
 		"volumegroup.storage.ibm.io/secret-namespace": Namespace,
@@ -40,5 +56,8 @@ var (
 	FakeMatchLabels = map[string]string{
 		"fake-label": "fake-value",
 	}
+	RestoredMatchLabels = map[string]string{
+		"fake-restored-label": "fake-value",
+	}
 	PVCProtectionFinalizer = "kubernetes.io/pvc-protection"
 )
@@ -27,10 +27,10 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 
-	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -40,7 +40,12 @@ import (
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
 	"github.com/IBM/csi-volume-group-operator/controllers"
 	"github.com/IBM/csi-volume-group-operator/controllers/envtest/utils"
+	"github.com/IBM/csi-volume-group-operator/controllers/garbagecollector"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroupclass"
 	"github.com/IBM/csi-volume-group-operator/controllers/volumegroupcontent"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroupsnapshot"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroupsnapshotcontent"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
 	"github.com/IBM/csi-volume-group-operator/pkg/client/fake"
 	"github.com/IBM/csi-volume-group-operator/pkg/config"
 	"github.com/IBM/csi-volume-group-operator/tests/mock_grpc_server"
@@ -54,6 +59,9 @@ var (
 	cancel    context.CancelFunc
 	ctx       context.Context
 	server    *mock_grpc_server.MockServer
+	// stoppableServer serves StoppableDriverName only, so it can be stopped without affecting the other drivers.
+	stoppableServer *mock_grpc_server.MockServer
+	drivers         grpcClient.Drivers
 )
 
 func TestAPIs(t *testing.T) {
@@ -96,32 +104,40 @@ var _ = BeforeSuite(func() {
 	addr := server.Address()
 	csiConn, err := fake.New(addr, DriverName)
 	Expect(err).ToNot(HaveOccurred())
+	secondCSIConn, err := fake.New(addr, SecondDriverName)
+	Expect(err).ToNot(HaveOccurred())
+	stoppableServer, err = mock_grpc_server.CreateMockServer()
+	Expect(err).ToNot(HaveOccurred())
+	stoppableCSIConn, err := fake.New(stoppableServer.Address(), StoppableDriverName)
+	Expect(err).ToNot(HaveOccurred())
+	drivers = grpcClient.Drivers{
+		DriverName: grpcClient.NewDriver(DriverName, csiConn, grpcClient.NewRPCLimiter(DriverName, grpcClient.RateLimit{}, nil)),
+		SecondDriverName: grpcClient.NewDriver(SecondDriverName, secondCSIConn,
+			grpcClient.NewRPCLimiter(SecondDriverName, grpcClient.RateLimit{}, nil)),
+		StoppableDriverName: grpcClient.NewDriver(StoppableDriverName, stoppableCSIConn,
+			grpcClient.NewRPCLimiter(StoppableDriverName, grpcClient.RateLimit{}, nil)),
+	}
+	mock_grpc_server.SetDriverName(DriverName)
+	_, err = drivers[DriverName].Discover(context.TODO())
+	Expect(err).ToNot(HaveOccurred())
 	driverConfig := &config.DriverConfig{
-		DriverName:        DriverName,
-		DriverEndpoint:    addr,
-		RPCTimeout:        time.Minute,
-		MultipleVGsToPVC:  "false",
-		DisableDeletePvcs: "false",
-	}
-	mockVolumeGroup := fake.VolumeGroup{
-		CreateVolumeGroupMock: func(name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error) {
-			return &csi.CreateVolumeGroupResponse{}, nil
-		},
-		DeleteVolumeGroupMock: func(volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error) {
-			return &csi.DeleteVolumeGroupResponse{}, nil
-		},
-		ModifyVolumeGroupMembershipMock: func(volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error) {
-			return &csi.ModifyVolumeGroupMembershipResponse{}, nil
-		},
+		DriverName:                  DriverName,
+		DriverEndpoint:              addr,
+		RPCTimeout:                  time.Minute,
+		MultipleVGsToPVC:            "false",
+		DisableDeletePvcs:           "false",
+		DriftCheckInterval:          time.Second,
+		DiscoveryInterval:           time.Second,
+		CapabilityDiscoveryInterval: time.Second,
+		OrphanGCInterval:            time.Second,
+		RetryMaxDelay:               2 * time.Second,
 	}
-
 	err = (&controllers.VolumeGroupReconciler{
 		Client:       mgr.GetClient(),
 		Scheme:       mgr.GetScheme(),
 		DriverConfig: driverConfig,
 		Log:          ctrl.Log.WithName("controllers").WithName("VolumeGroup"),
-		GRPCClient:   csiConn,
-		VGClient:     mockVolumeGroup,
+		Drivers:      drivers,
 	}).SetupWithManager(mgr, driverConfig)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -130,8 +146,41 @@ var _ = BeforeSuite(func() {
 		Scheme:       mgr.GetScheme(),
 		DriverConfig: driverConfig,
 		Log:          ctrl.Log.WithName("VolumeGroupContentController"),
-		GRPCClient:   csiConn,
-		VGClient:     mockVolumeGroup,
+		Drivers:      drivers,
+	}).SetupWithManager(mgr, driverConfig)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&volumegroupclass.VolumeGroupClassReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		DriverConfig: driverConfig,
+		Log:          ctrl.Log.WithName("VolumeGroupClassController"),
+		Drivers:      drivers,
+	}).SetupWithManager(mgr, driverConfig)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&volumegroupsnapshot.VolumeGroupSnapshotReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		DriverConfig: driverConfig,
+		Log:          ctrl.Log.WithName("VolumeGroupSnapshotController"),
+	}).SetupWithManager(mgr)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&volumegroupsnapshotcontent.VolumeGroupSnapshotContentReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		DriverConfig: driverConfig,
+		Log:          ctrl.Log.WithName("VolumeGroupSnapshotContentController"),
+		Drivers:      drivers,
+	}).SetupWithManager(mgr, driverConfig)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&garbagecollector.OrphanedVolumeGroupCollector{
+		Client:       mgr.GetClient(),
+		DriverConfig: driverConfig,
+		Log:          ctrl.Log.WithName("OrphanedVolumeGroupCollector"),
+		Drivers:      drivers,
 	}).SetupWithManager(mgr, driverConfig)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -146,6 +195,7 @@ var _ = AfterSuite(func() {
 	cancel()
 	By("tearing down the test environment")
 	server.Stop()
+	stoppableServer.Stop()
 	err := testEnv.Stop()
 	Expect(err).NotTo(HaveOccurred())
 })
@@ -179,6 +229,13 @@ func createVolumeGroupObjects(deletionPolicy volumegroupv1.VolumeGroupDeletionPo
 	return err
 }
 
+func createVolumeGroupSnapshotObjects() error {
+	if err := utils.CreateResourceObject(VGSClass, k8sClient); err != nil {
+		return err
+	}
+	return utils.CreateResourceObject(VGS, k8sClient)
+}
+
 func createVolumeObjects() error {
 	if err := utils.CreateResourceObject(PV, k8sClient); err != nil {
 		return err
@@ -195,6 +252,25 @@ func createVolumeObjects() error {
 	return err
 }
 
+func createOtherNamespaceVolumeObjects() error {
+	if err := utils.CreateResourceObject(OtherNamespaceObject, k8sClient); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	if err := utils.CreateResourceObject(OtherPV, k8sClient); err != nil {
+		return err
+	}
+	if err := utils.CreateResourceObject(OtherPVC, k8sClient); err != nil {
+		return err
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := utils.GetNamespacedResourceObject(OtherPVCName, OtherNamespace, pvc, k8sClient); err != nil {
+		return err
+	}
+	pvc.Status.Phase = corev1.ClaimBound
+	err := k8sClient.Status().Update(context.TODO(), pvc)
+	return err
+}
+
 func cleanTestNamespace() error {
 	err := cleanVolumeGroupObjects()
 	if err != nil {
@@ -213,7 +289,19 @@ func cleanTestNamespace() error {
 }
 
 func cleanVolumeGroupObjects() error {
-	err := k8sClient.DeleteAllOf(context.Background(), &volumegroupv1.VolumeGroup{}, client.InNamespace(Namespace))
+	err := k8sClient.DeleteAllOf(context.Background(), &volumegroupv1.VolumeGroupSnapshot{}, client.InNamespace(Namespace))
+	if err != nil {
+		return err
+	}
+	err = k8sClient.DeleteAllOf(context.Background(), &volumegroupv1.VolumeGroupSnapshotClass{})
+	if err != nil {
+		return err
+	}
+	err = k8sClient.DeleteAllOf(context.Background(), &volumegroupv1.VolumeGroupMember{}, client.InNamespace(Namespace))
+	if err != nil {
+		return err
+	}
+	err = k8sClient.DeleteAllOf(context.Background(), &volumegroupv1.VolumeGroup{}, client.InNamespace(Namespace))
 	if err != nil {
 		return err
 	}
@@ -234,10 +322,18 @@ func cleanVolumeObjects() error {
 	if err := utils.RemoveResourceObjectFinalizers(PVName, Namespace, pv, k8sClient); err != nil {
 		return err
 	}
+	otherPVC := &corev1.PersistentVolumeClaim{}
+	if err := utils.RemoveResourceObjectFinalizers(OtherPVCName, OtherNamespace, otherPVC, k8sClient); err != nil {
+		return err
+	}
 	err := k8sClient.DeleteAllOf(context.Background(), &corev1.PersistentVolumeClaim{}, client.InNamespace(Namespace))
 	if err != nil {
 		return err
 	}
+	err = k8sClient.DeleteAllOf(context.Background(), &corev1.PersistentVolumeClaim{}, client.InNamespace(OtherNamespace))
+	if err != nil {
+		return err
+	}
 	err = k8sClient.DeleteAllOf(context.Background(), &corev1.PersistentVolume{})
 	return err
 }
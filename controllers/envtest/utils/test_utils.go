@@ -37,6 +37,17 @@ func GetVGCObjectFromVG(vgName, Namespace string, vgObject runtimeclient.Object,
 	return vgcObj, err
 }
 
+func GetVGSCObjectFromVGS(vgsName, Namespace string, vgsObject runtimeclient.Object,
+	client runtimeclient.Client) (*volumegroupv1.VolumeGroupSnapshotContent, error) {
+	if err := GetNamespacedResourceObject(vgsName, Namespace, vgsObject, client); err != nil {
+		return nil, err
+	}
+	vgscName := fmt.Sprintf("volumegroupsnapshot-%s", vgsObject.GetUID())
+	vgscObj := &volumegroupv1.VolumeGroupSnapshotContent{}
+	err := GetNamespacedResourceObject(vgscName, Namespace, vgscObj, client)
+	return vgscObj, err
+}
+
 func GetNamespacedResourceObject(name, namespace string, obj runtimeclient.Object, client runtimeclient.Client) error {
 	objNamespacedName := types.NamespacedName{
 		Name:      name,
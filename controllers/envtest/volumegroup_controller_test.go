@@ -18,13 +18,26 @@ package envtest
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
 	"github.com/IBM/csi-volume-group-operator/controllers/envtest/utils"
+	controllerUtils "github.com/IBM/csi-volume-group-operator/controllers/utils"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	"github.com/IBM/csi-volume-group-operator/tests/mock_grpc_server"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Test controllers", func() {
@@ -50,9 +63,54 @@ var _ = Describe("Test controllers", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			By("Validating VolumeGroupContent object created")
-			_, err = utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			vgcObj, err := utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
 			Expect(err).NotTo(HaveOccurred())
 
+			By("Validating status conditions")
+			Expect(meta.IsStatusConditionTrue(vgObj.Status.Conditions, volumegroupv1.ConditionBound)).To(BeTrue())
+			Expect(vgObj.Status.ObservedGeneration).To(Equal(vgObj.Generation))
+			Expect(meta.IsStatusConditionTrue(vgcObj.Status.Conditions, volumegroupv1.ConditionBackendGroupCreated)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(vgcObj.Status.Conditions, volumegroupv1.ConditionReady)).To(BeTrue())
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should resume a volumeGroup creation that timed out", func(done Done) {
+			By("Creating volumeGroup objects while the storage times out")
+			mock_grpc_server.SetCreateVolumeGroupError(status.Error(codes.DeadlineExceeded, "fake timeout"))
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeGroupObjects(volumegroupv1.VolumeGroupContentDelete)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating the VolumeGroupContent creation is in progress")
+			vgObj := &volumegroupv1.VolumeGroup{}
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgcObj, err := utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vgcObj.Annotations).To(HaveKey(controllerUtils.VGCreationInProgressKey))
+			condition := meta.FindStatusCondition(vgcObj.Status.Conditions, volumegroupv1.ConditionBackendGroupCreated)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(volumegroupv1.ReasonInProgress))
+			Expect(vgcObj.Status.RetryCount).To(BeNumerically(">", 0))
+			Expect(vgcObj.Status.NextRetryTime).NotTo(BeNil())
+
+			By("Validating the creation is resumed once the storage responds")
+			mock_grpc_server.SetCreateVolumeGroupError(nil)
+			time.Sleep(3 * time.Second)
+			vgcObj, err = utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vgcObj.Annotations).NotTo(HaveKey(controllerUtils.VGCreationInProgressKey))
+			Expect(vgcObj.Status.RetryCount).To(BeZero())
+
+			By("Validating the VolumeGroup is bound once its VolumeGroupContent is ready")
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionTrue(vgObj.Status.Conditions, volumegroupv1.ConditionBound)).To(BeTrue())
+			Expect(vgObj.Status.RetryCount).To(BeZero())
+			Expect(meta.IsStatusConditionTrue(vgcObj.Status.Conditions, volumegroupv1.ConditionBackendGroupCreated)).To(BeTrue())
+
 			close(done)
 		}, Timeout.Seconds())
 		It("should add and remove volume objects from volumeGroup objects when created before vg", func(done Done) {
@@ -72,15 +130,15 @@ var _ = Describe("Test controllers", func() {
 			By("Validating that PVC is in VG")
 			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(len(vgObj.Status.PVCList)).To(Equal(1))
-			Expect(vgObj.Status.PVCList[0].Name).To(Equal(PVCName))
-			Expect(vgObj.Status.PVCList[0].Namespace).To(Equal(Namespace))
+			Expect(len(vgObj.Status.Members)).To(Equal(1))
+			Expect(vgObj.Status.Members[0].Name).To(Equal(PVCName))
+			Expect(vgObj.Status.Members[0].Namespace).To(Equal(Namespace))
 
 			By("Validating that PV is in VGC")
 			vgcObj, err := utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(len(vgcObj.Status.PVList)).To(Equal(1))
-			Expect(vgcObj.Status.PVList[0].Name).To(Equal(PVName))
+			Expect(len(vgcObj.Status.Members)).To(Equal(1))
+			Expect(vgcObj.Status.Members[0].Name).To(Equal(PVName))
 
 			By("Removing labels from VG")
 			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
@@ -97,8 +155,52 @@ var _ = Describe("Test controllers", func() {
 			Expect(err).NotTo(HaveOccurred())
 			vgcObj, err = utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(len(vgObj.Status.PVCList)).To(Equal(0))
-			Expect(len(vgcObj.Status.PVList)).To(Equal(0))
+			Expect(len(vgObj.Status.Members)).To(Equal(0))
+			Expect(len(vgcObj.Status.Members)).To(Equal(0))
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should remove a deleted pvc from the volumeGroup before releasing it", func(done Done) {
+			By("Creating volumeGroup and volume objects")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeGroupObjects(volumegroupv1.VolumeGroupContentDelete)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			vgObj := &volumegroupv1.VolumeGroup{}
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(1))
+			vgcObj, err := utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgId := vgcObj.Spec.Source.VolumeGroupHandle
+			Expect(mock_grpc_server.GetVolumeGroupVolumeIds(vgId)).To(ContainElement(PV.Spec.CSI.VolumeHandle))
+
+			By("Deleting the PVC")
+			pvcObj := &corev1.PersistentVolumeClaim{}
+			err = utils.GetNamespacedResourceObject(PVCName, Namespace, pvcObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Delete(context.TODO(), pvcObj)
+			Expect(err).NotTo(HaveOccurred())
+			err = utils.RemoveFinalizerFromPVC(PVCName, Namespace, PVCProtectionFinalizer, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating the PVC left the volume group on the storage, the VG and the VGC")
+			Expect(mock_grpc_server.GetVolumeGroupVolumeIds(vgId)).NotTo(ContainElement(PV.Spec.CSI.VolumeHandle))
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(0))
+			vgcObj, err = utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgcObj.Status.Members)).To(Equal(0))
+
+			By("Validating the PVC is deleted")
+			err = utils.GetNamespacedResourceObject(PVCName, Namespace, pvcObj, k8sClient)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
 
 			close(done)
 		}, Timeout.Seconds())
@@ -118,15 +220,15 @@ var _ = Describe("Test controllers", func() {
 			By("Validating that PVC is in VG")
 			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(len(vgObj.Status.PVCList)).To(Equal(1))
-			Expect(vgObj.Status.PVCList[0].Name).To(Equal(PVCName))
-			Expect(vgObj.Status.PVCList[0].Namespace).To(Equal(Namespace))
+			Expect(len(vgObj.Status.Members)).To(Equal(1))
+			Expect(vgObj.Status.Members[0].Name).To(Equal(PVCName))
+			Expect(vgObj.Status.Members[0].Namespace).To(Equal(Namespace))
 
 			By("Validating that PV is in VGC")
 			vgcObj, err := utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(len(vgcObj.Status.PVList)).To(Equal(1))
-			Expect(vgcObj.Status.PVList[0].Name).To(Equal(PVName))
+			Expect(len(vgcObj.Status.Members)).To(Equal(1))
+			Expect(vgcObj.Status.Members[0].Name).To(Equal(PVName))
 
 			By("Removing labels from PVC")
 			err = utils.GetNamespacedResourceObject(PVCName, Namespace, pvc, k8sClient)
@@ -141,8 +243,450 @@ var _ = Describe("Test controllers", func() {
 			Expect(err).NotTo(HaveOccurred())
 			vgcObj, err = utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(len(vgObj.Status.PVCList)).To(Equal(0))
-			Expect(len(vgcObj.Status.PVList)).To(Equal(0))
+			Expect(len(vgObj.Status.Members)).To(Equal(0))
+			Expect(len(vgcObj.Status.Members)).To(Equal(0))
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should not add volume objects from another namespace to volumeGroup objects", func(done Done) {
+			By("Creating volume objects in the volumeGroup namespace and in another namespace")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			err = createOtherNamespaceVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = createVolumeGroupObjects(volumegroupv1.VolumeGroupContentDelete)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			vgObj := &volumegroupv1.VolumeGroup{}
+
+			By("Validating that only the PVC from the volumeGroup namespace is in VG")
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(1))
+			Expect(vgObj.Status.Members[0].Name).To(Equal(PVCName))
+			Expect(vgObj.Status.Members[0].Namespace).To(Equal(Namespace))
+
+			By("Validating that only the PV from the volumeGroup namespace is in VGC")
+			vgcObj, err := utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgcObj.Status.Members)).To(Equal(1))
+			Expect(vgcObj.Status.Members[0].Name).To(Equal(PVName))
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should add and remove volume objects of a namespace when its labels start and stop matching the namespaceSelector", func(done Done) {
+			By("Creating volume objects in the volumeGroup namespace and in another namespace")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			err = createOtherNamespaceVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Creating a volumeGroup with a namespaceSelector that matches no namespace yet")
+			err = utils.CreateResourceObject(VGClass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgclass := &volumegroupv1.VolumeGroupClass{}
+			err = utils.GetNamespacedResourceObject(VGClassName, Namespace, vgclass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			allowNamespaceSelector := true
+			deletionPolicy := volumegroupv1.VolumeGroupContentDelete
+			vgclass.AllowNamespaceSelector = &allowNamespaceSelector
+			vgclass.VolumeGroupDeletionPolicy = &deletionPolicy
+			err = k8sClient.Update(context.TODO(), vgclass)
+			Expect(err).NotTo(HaveOccurred())
+			namespaceLabels := map[string]string{"fake-namespace-label": "members"}
+			vg := VG.DeepCopy()
+			vg.Spec.Source.NamespaceSelector = &metav1.LabelSelector{MatchLabels: namespaceLabels}
+			err = utils.CreateResourceObject(vg, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			vgObj := &volumegroupv1.VolumeGroup{}
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(1))
+
+			By("Labeling the other namespace")
+			namespace := &corev1.Namespace{}
+			err = k8sClient.Get(context.TODO(), client.ObjectKey{Name: OtherNamespace}, namespace)
+			Expect(err).NotTo(HaveOccurred())
+			namespace.Labels = namespaceLabels
+			err = k8sClient.Update(context.TODO(), namespace)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating that the PVC of the other namespace is in VG")
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(2))
+			Expect(controllerUtils.IsPVCInMembers(OtherPVC, vgObj.Status.Members)).To(BeTrue())
+
+			By("Removing the labels of the other namespace")
+			err = k8sClient.Get(context.TODO(), client.ObjectKey{Name: OtherNamespace}, namespace)
+			Expect(err).NotTo(HaveOccurred())
+			namespace.Labels = nil
+			err = k8sClient.Update(context.TODO(), namespace)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating that the PVC of the other namespace left VG")
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(1))
+			Expect(vgObj.Status.Members[0].Name).To(Equal(PVCName))
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should delete a volumeGroup whose namespaceSelector is not allowed by its volumeGroupClass", func(done Done) {
+			By("Creating volumeGroup objects")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeGroupObjects(volumegroupv1.VolumeGroupContentDelete)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Setting a namespaceSelector that the volumeGroupClass does not allow")
+			vgObj := &volumegroupv1.VolumeGroup{}
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgObj.Spec.Source.NamespaceSelector = &metav1.LabelSelector{}
+			err = k8sClient.Update(context.TODO(), vgObj)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating that the volumeGroup is deleted")
+			err = k8sClient.Delete(context.TODO(), vgObj)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should roll back a pending member when the membership modification fails", func(done Done) {
+			By("Creating volumeGroup objects while the storage fails to modify the membership")
+			mock_grpc_server.SetModifyVolumeGroupError(status.Error(codes.Unavailable, "fake modify failure"))
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeGroupObjects(volumegroupv1.VolumeGroupContentDelete)
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating that the PVC failed to join VG and PV is not in VGC")
+			vgObj := &volumegroupv1.VolumeGroup{}
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(1))
+			Expect(vgObj.Status.Members[0].Name).To(Equal(PVCName))
+			Expect(vgObj.Status.Members[0].State).To(Equal(volumegroupv1.MemberFailed))
+			Expect(vgObj.Status.Members[0].Reason).To(Equal(volumegroupv1.ReasonModifyFailed))
+			vgcObj, err := utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgcObj.Status.Members)).To(Equal(0))
+
+			By("Validating that the PVC joins VG once the storage responds")
+			mock_grpc_server.SetModifyVolumeGroupError(nil)
+			time.Sleep(3 * time.Second)
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(1))
+			Expect(vgObj.Status.Members[0].State).To(Equal(volumegroupv1.MemberJoined))
+			Expect(vgObj.Status.Members[0].Reason).To(BeEmpty())
+			vgcObj, err = utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgcObj.Status.Members)).To(Equal(1))
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should keep a pending member when the membership modification times out", func(done Done) {
+			By("Creating volumeGroup objects while the storage times out modifying the membership")
+			mock_grpc_server.SetModifyVolumeGroupError(status.Error(codes.DeadlineExceeded, "fake timeout"))
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeGroupObjects(volumegroupv1.VolumeGroupContentDelete)
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating that the PVC is still pending and the membership modification is in progress")
+			vgObj := &volumegroupv1.VolumeGroup{}
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(1))
+			Expect(vgObj.Status.Members[0].State).To(Equal(volumegroupv1.MemberPending))
+			Expect(vgObj.Status.Members[0].Reason).To(BeEmpty())
+			membershipSynced := meta.FindStatusCondition(vgObj.Status.Conditions, volumegroupv1.ConditionMembershipSynced)
+			Expect(membershipSynced).NotTo(BeNil())
+			Expect(membershipSynced.Reason).To(Equal(volumegroupv1.ReasonInProgress))
+
+			By("Validating that the PVC joins VG once the modification is sent again")
+			mock_grpc_server.SetModifyVolumeGroupError(nil)
+			time.Sleep(3 * time.Second)
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(1))
+			Expect(vgObj.Status.Members[0].State).To(Equal(volumegroupv1.MemberJoined))
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should coalesce membership changes until the membership batch is flushed", func(done Done) {
+			By("Creating a volumeGroup with a membership batch window")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = utils.CreateResourceObject(VGClass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vg := VG.DeepCopy()
+			vg.Spec.MembershipBatchWindow = &metav1.Duration{Duration: 3 * time.Second}
+			err = utils.CreateResourceObject(vg, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating that the PVC waits for the membership batch")
+			vgObj := &volumegroupv1.VolumeGroup{}
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(0))
+			Expect(vgObj.Status.MembershipBatchFlushTime).NotTo(BeNil())
+			Expect(vgObj.Status.PendingMembershipChanges).To(Equal(int32(1)))
+
+			By("Validating that the PVC is in VG once the membership batch is flushed")
+			time.Sleep(3 * time.Second)
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(1))
+			Expect(vgObj.Status.Members[0].State).To(Equal(volumegroupv1.MemberJoined))
+			Expect(vgObj.Status.MembershipBatchFlushTime).To(BeNil())
+			Expect(vgObj.Status.PendingMembershipChanges).To(BeZero())
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should store the members in volumeGroupMembers when the volumeGroupClass uses them", func(done Done) {
+			By("Creating a volumeGroupClass that uses volumeGroupMembers")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			err = utils.CreateResourceObject(VGClass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgclass := &volumegroupv1.VolumeGroupClass{}
+			err = utils.GetNamespacedResourceObject(VGClassName, Namespace, vgclass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			useVolumeGroupMembers := true
+			vgclass.UseVolumeGroupMembers = &useVolumeGroupMembers
+			err = k8sClient.Update(context.TODO(), vgclass)
+			Expect(err).NotTo(HaveOccurred())
+			err = utils.CreateResourceObject(VG, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating that the PVC has a volumeGroupMember and VG keeps only the member counts")
+			vgmList := &volumegroupv1.VolumeGroupMemberList{}
+			err = k8sClient.List(context.TODO(), vgmList, client.InNamespace(Namespace))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgmList.Items)).To(Equal(1))
+			Expect(vgmList.Items[0].Spec.VolumeGroupName).To(Equal(VGName))
+			Expect(vgmList.Items[0].Spec.PersistentVolumeClaim.Name).To(Equal(PVCName))
+			Expect(vgmList.Items[0].Status.State).To(Equal(volumegroupv1.MemberJoined))
+
+			vgObj := &volumegroupv1.VolumeGroup{}
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgObj.Status.Members)).To(Equal(0))
+			Expect(vgObj.Status.MemberCounts).NotTo(BeNil())
+			Expect(vgObj.Status.MemberCounts.Total).To(Equal(int32(1)))
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should reconcile a volumeGroup whose volumeGroupClass has a second driver", func(done Done) {
+			By("Creating a volumeGroupClass of the second driver")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = utils.CreateResourceObject(VGClass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgclass := &volumegroupv1.VolumeGroupClass{}
+			err = utils.GetNamespacedResourceObject(VGClassName, Namespace, vgclass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgclass.Driver = SecondDriverName
+			err = k8sClient.Update(context.TODO(), vgclass)
+			Expect(err).NotTo(HaveOccurred())
+			err = utils.CreateResourceObject(VG, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating that the volumeGroup is bound by the second driver")
+			vgObj := &volumegroupv1.VolumeGroup{}
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionTrue(vgObj.Status.Conditions, volumegroupv1.ConditionBound)).To(BeTrue())
+			vgcObj, err := utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionTrue(vgcObj.Status.Conditions, volumegroupv1.ConditionReady)).To(BeTrue())
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should keep the retry state and skip the metrics of a volumeGroup whose driver is not served", func(done Done) {
+			By("Creating a volumeGroup of a driver that is not served by the operator")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = utils.CreateResourceObject(VGClass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgclass := &volumegroupv1.VolumeGroupClass{}
+			err = utils.GetNamespacedResourceObject(VGClassName, Namespace, vgclass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgclass.Driver = UnservedDriverName
+			err = k8sClient.Update(context.TODO(), vgclass)
+			Expect(err).NotTo(HaveOccurred())
+			err = utils.CreateResourceObject(VG, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Setting the retry state of the volumeGroup and reconciling it again")
+			vgObj := &volumegroupv1.VolumeGroup{}
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgObj.Status.RetryCount = 3
+			err = k8sClient.Status().Update(context.TODO(), vgObj)
+			Expect(err).NotTo(HaveOccurred())
+			vgObj.Spec.Source.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"fake-label": "fake-value"}}
+			err = k8sClient.Update(context.TODO(), vgObj)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating the retry state was not reset")
+			err = utils.GetNamespacedResourceObject(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vgObj.Status.RetryCount).To(Equal(int32(3)))
+
+			By("Validating the metrics of the volumeGroup are not exported")
+			Expect(metrics.VolumeGroupMembers.DeleteLabelValues(Namespace, VGName)).To(BeFalse())
+			Expect(metrics.VolumeGroupState.DeleteLabelValues(Namespace, VGName, metrics.VGStatePending)).To(BeFalse())
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should set DriverReachable on the volumeGroupClass of a connected driver", func(done Done) {
+			By("Creating a volumeGroupClass")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = utils.CreateResourceObject(VGClass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating that the volumeGroupClass reports its driver as reachable")
+			vgclass := &volumegroupv1.VolumeGroupClass{}
+			err = utils.GetNamespacedResourceObject(VGClassName, Namespace, vgclass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionTrue(vgclass.Status.Conditions, volumegroupv1.ConditionDriverReachable)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(vgclass.Status.Conditions, volumegroupv1.ConditionReady)).To(BeTrue())
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should report the driver as unreachable once the driver stops and stay ready for the other drivers", func(done Done) {
+			By("Creating a volumeGroupClass of a running driver")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = utils.CreateResourceObject(VGClass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgclass := &volumegroupv1.VolumeGroupClass{}
+			err = utils.GetNamespacedResourceObject(VGClassName, Namespace, vgclass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgclass.Driver = StoppableDriverName
+			err = k8sClient.Update(context.TODO(), vgclass)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+			readinessRequest := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+			By("Validating that the driver is reachable")
+			err = utils.GetNamespacedResourceObject(VGClassName, Namespace, vgclass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionTrue(vgclass.Status.Conditions, volumegroupv1.ConditionDriverReachable)).To(BeTrue())
+			Expect(drivers.Check(readinessRequest)).To(Succeed())
+
+			By("Stopping the driver")
+			stoppableServer.Stop()
+			time.Sleep(2 * time.Second)
+
+			By("Validating that the volumeGroupClass and the readiness check report the driver as unreachable")
+			err = utils.GetNamespacedResourceObject(VGClassName, Namespace, vgclass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionFalse(vgclass.Status.Conditions, volumegroupv1.ConditionDriverReachable)).To(BeTrue())
+			Expect(drivers.Check(readinessRequest)).To(Succeed())
+			Expect(testutil.ToFloat64(metrics.DriverReachable.WithLabelValues(StoppableDriverName))).To(Equal(0.0))
+			Expect(testutil.ToFloat64(metrics.DriverReachable.WithLabelValues(DriverName))).To(Equal(1.0))
+
+			By("Validating that the readiness check fails once no driver is reachable")
+			stoppedDrivers := grpcClient.Drivers{StoppableDriverName: drivers[StoppableDriverName]}
+			Expect(stoppedDrivers.Check(readinessRequest)).To(HaveOccurred())
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should connect to a remote driver over mutual TLS", func(done Done) {
+			By("Starting a driver that requires client certificates")
+			certificates, err := mock_grpc_server.GenerateTLSCertificates()
+			Expect(err).NotTo(HaveOccurred())
+			tlsConfig, err := certificates.ServerTLSConfig()
+			Expect(err).NotTo(HaveOccurred())
+			tlsServer, err := mock_grpc_server.CreateMockTLSServer(tlsConfig)
+			Expect(err).NotTo(HaveOccurred())
+			defer tlsServer.Stop()
+			endpoint := "tcp://" + tlsServer.Address()
+
+			By("Validating that the driver is discovered with the client certificate")
+			csiConn, err := grpcClient.New(endpoint, DriverName, 5*time.Second, grpcClient.ConnectionConfig{
+				TLS: grpcClient.TLSConfig{
+					CAFile:   certificates.CAFile,
+					CertFile: certificates.ClientCertFile,
+					KeyFile:  certificates.ClientKeyFile,
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			defer csiConn.Client.Close()
+			driver := grpcClient.NewDriver(DriverName, csiConn, grpcClient.NewRPCLimiter(DriverName, grpcClient.RateLimit{}, nil))
+			discovered, err := driver.Discover(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(discovered).To(BeTrue())
+
+			By("Validating that a driver with a certificate of another CA is rejected")
+			otherCertificates, err := mock_grpc_server.GenerateTLSCertificates()
+			Expect(err).NotTo(HaveOccurred())
+			_, err = grpcClient.New(endpoint, DriverName, time.Second, grpcClient.ConnectionConfig{
+				TLS: grpcClient.TLSConfig{
+					CAFile:   otherCertificates.CAFile,
+					CertFile: certificates.ClientCertFile,
+					KeyFile:  certificates.ClientKeyFile,
+				},
+			})
+			Expect(err).To(HaveOccurred())
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should export the state and the members of a volumeGroup as metrics", func(done Done) {
+			By("Creating volumeGroup objects with a member")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeGroupObjects(volumegroupv1.VolumeGroupContentDelete)
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating the volumeGroup metrics")
+			Expect(testutil.ToFloat64(metrics.VolumeGroupState.WithLabelValues(Namespace, VGName, metrics.VGStateReady))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(metrics.VolumeGroupState.WithLabelValues(Namespace, VGName, metrics.VGStateFailed))).To(Equal(0.0))
+			Expect(testutil.ToFloat64(metrics.VolumeGroupMembers.WithLabelValues(Namespace, VGName))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(metrics.MembershipOperationsTotal.WithLabelValues(Namespace, VGName,
+				metrics.ResultSuccess))).To(BeNumerically(">=", 1))
+			Expect(testutil.CollectAndCount(metrics.RPCDurationSeconds)).To(BeNumerically(">", 0))
 
 			close(done)
 		}, Timeout.Seconds())
@@ -22,10 +22,17 @@ import (
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
 	"github.com/IBM/csi-volume-group-operator/controllers/envtest/utils"
+	controllerUtils "github.com/IBM/csi-volume-group-operator/controllers/utils"
+	"github.com/IBM/csi-volume-group-operator/tests/mock_grpc_server"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Test controllers", func() {
@@ -101,6 +108,202 @@ var _ = Describe("Test controllers", func() {
 			pvcErr := utils.GetNamespacedResourceObject(PVCName, Namespace, pvcObj, k8sClient)
 			Expect(apierrors.IsNotFound(pvcErr)).To(BeTrue())
 
+			close(done)
+		}, Timeout.Seconds())
+		It("Should report membership drift when a volume is missing from the storage", func(done Done) {
+			By("Creating a volumeGroup and volume resources")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeGroupObjects(volumegroupv1.VolumeGroupContentDelete)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(2 * time.Second)
+
+			By("Validating VGC membership is in sync with the storage")
+			vgObj := &volumegroupv1.VolumeGroup{}
+			vgcObj, err := utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgcObj.Status.Members)).To(Equal(1))
+			Expect(meta.IsStatusConditionFalse(vgcObj.Status.Conditions, volumegroupv1.ConditionMembershipDrift)).To(BeTrue())
+
+			By("Removing the volume from the volume group on the storage")
+			mock_grpc_server.SetVolumeGroupMembers(vgcObj.Spec.Source.VolumeGroupHandle, nil)
+			time.Sleep(3 * time.Second)
+
+			By("Validating VGC reports the membership drift")
+			err = utils.GetNamespacedResourceObject(vgcObj.Name, Namespace, vgcObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionTrue(vgcObj.Status.Conditions, volumegroupv1.ConditionMembershipDrift)).To(BeTrue())
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should report a persistentVolume that is rebound to another claim", func(done Done) {
+			By("Creating a volumeGroup and volume resources")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeGroupObjects(volumegroupv1.VolumeGroupContentDelete)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating VGC persistentVolumes are bound")
+			vgObj := &volumegroupv1.VolumeGroup{}
+			vgcObj, err := utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgcObj.Status.Members)).To(Equal(1))
+			Expect(meta.IsStatusConditionTrue(vgcObj.Status.Conditions, volumegroupv1.ConditionPVsBound)).To(BeTrue())
+
+			By("Rebinding the PV to another claim")
+			pvObj := &corev1.PersistentVolume{}
+			err = k8sClient.Get(context.TODO(), client.ObjectKey{Name: PVName}, pvObj)
+			Expect(err).NotTo(HaveOccurred())
+			pvObj.Spec.ClaimRef = &corev1.ObjectReference{Name: OtherPVCName, Namespace: OtherNamespace}
+			err = k8sClient.Update(context.TODO(), pvObj)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating VGC reports the rebound PV")
+			err = utils.GetNamespacedResourceObject(vgcObj.Name, Namespace, vgcObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			pvsBound := meta.FindStatusCondition(vgcObj.Status.Conditions, volumegroupv1.ConditionPVsBound)
+			Expect(pvsBound).NotTo(BeNil())
+			Expect(pvsBound.Status).To(Equal(metav1.ConditionFalse))
+			Expect(pvsBound.Reason).To(Equal(volumegroupv1.ReasonPVRebound))
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should migrate the deprecated pvList of a vgc to members", func(done Done) {
+			By("Creating a volumeGroup and volume resources")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeGroupObjects(volumegroupv1.VolumeGroupContentDelete)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Setting the VGC status to the deprecated pvList")
+			vgObj := &volumegroupv1.VolumeGroup{}
+			vgcObj, err := utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			pvObj := &corev1.PersistentVolume{}
+			err = k8sClient.Get(context.TODO(), client.ObjectKey{Name: PVName}, pvObj)
+			Expect(err).NotTo(HaveOccurred())
+			vgcObj.Status.Members = nil
+			vgcObj.Status.PVList = []corev1.PersistentVolume{*pvObj}
+			err = k8sClient.Status().Update(context.TODO(), vgcObj)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(2 * time.Second)
+
+			By("Validating the pvList is migrated to members")
+			err = utils.GetNamespacedResourceObject(vgcObj.Name, Namespace, vgcObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(vgcObj.Status.PVList)).To(Equal(0))
+			Expect(len(vgcObj.Status.Members)).To(Equal(1))
+			Expect(vgcObj.Status.Members[0].Name).To(Equal(PVName))
+			Expect(vgcObj.Status.Members[0].VolumeHandle).To(Equal(PV.Spec.CSI.VolumeHandle))
+			Expect(vgcObj.Status.Members[0].ClaimRef.Name).To(Equal(PVCName))
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should create an unbound vgc for a discovered volume group", func(done Done) {
+			By("Creating a volume group on the storage and a volumeGroupClass with discovery")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			mock_grpc_server.SetVolumeGroupMembers(DiscoveredVGHandle, []string{PV.Spec.CSI.VolumeHandle})
+			err = utils.CreateResourceObject(DiscoveryVGClass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(2 * time.Second)
+
+			By("Validating an unbound VGC was created for the discovered volume group")
+			vgcList := &volumegroupv1.VolumeGroupContentList{}
+			err = k8sClient.List(context.TODO(), vgcList, client.InNamespace(Namespace),
+				client.MatchingLabels{controllerUtils.DiscoveredVGCLabel: "true"})
+			Expect(err).NotTo(HaveOccurred())
+			var vgcObj *volumegroupv1.VolumeGroupContent
+			for i := range vgcList.Items {
+				if vgcList.Items[i].Spec.Source.VolumeGroupHandle == DiscoveredVGHandle {
+					vgcObj = &vgcList.Items[i]
+				}
+			}
+			Expect(vgcObj).NotTo(BeNil())
+			Expect(vgcObj.Spec.VolumeGroupRef).To(BeNil())
+			Expect(*vgcObj.Spec.VolumeGroupDeletionPolicy).To(Equal(volumegroupv1.VolumeGroupContentRetain))
+			Expect(len(vgcObj.Status.Members)).To(Equal(1))
+			Expect(vgcObj.Status.Members[0].Name).To(Equal(PVName))
+
+			By("Deleting the volumeGroupClass with discovery")
+			err = k8sClient.Delete(context.TODO(), DiscoveryVGClass)
+			Expect(err).NotTo(HaveOccurred())
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should reject volume group discovery when the driver cannot list volume groups", func(done Done) {
+			By("Discovering a driver that does not implement ListVolumeGroups")
+			mock_grpc_server.SetListVolumeGroupsError(status.Error(codes.Unimplemented, "fake unimplemented"))
+			time.Sleep(2 * time.Second)
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = utils.CreateResourceObject(DiscoveryVGClass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating that the volumeGroupClass settings are not supported")
+			vgClassObj := &volumegroupv1.VolumeGroupClass{}
+			err = utils.GetNamespacedResourceObject(DiscoveryVGClassName, Namespace, vgClassObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionFalse(vgClassObj.Status.Conditions, volumegroupv1.ConditionSettingsSupported)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(vgClassObj.Status.Conditions, volumegroupv1.ConditionReady)).To(BeTrue())
+
+			By("Validating that the settings are supported once the driver implements ListVolumeGroups")
+			mock_grpc_server.SetListVolumeGroupsError(nil)
+			time.Sleep(2 * time.Second)
+			err = utils.GetNamespacedResourceObject(DiscoveryVGClassName, Namespace, vgClassObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionTrue(vgClassObj.Status.Conditions, volumegroupv1.ConditionSettingsSupported)).To(BeTrue())
+
+			err = k8sClient.Delete(context.TODO(), DiscoveryVGClass)
+			Expect(err).NotTo(HaveOccurred())
+
+			close(done)
+		}, Timeout.Seconds())
+		It("Should delete an orphaned volume group from the storage", func(done Done) {
+			By("Creating an orphaned volume group on the storage and a volumeGroupClass that deletes it")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			mock_grpc_server.SetVolumeGroupMembers(OrphanedVGHandle, nil)
+			mock_grpc_server.SetVolumeGroupMembers(UnrecordedVGHandle, nil)
+			err = utils.CreateResourceObject(OrphanGCVGClass, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Recording the orphaned volume group as created for a deleted volumeGroupContent")
+			vgClassObj := &volumegroupv1.VolumeGroupClass{}
+			err = utils.GetNamespacedResourceObject(OrphanGCVGClassName, "", vgClassObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			vgClassObj.Status.CreatedVolumeGroups = []volumegroupv1.CreatedVolumeGroup{{
+				VolumeGroupHandle: OrphanedVGHandle,
+				VolumeGroupContentRef: corev1.ObjectReference{
+					Kind: "VolumeGroupContent", Namespace: Namespace, Name: "fake-deleted-vgc-name", UID: "fake-deleted-vgc-uid"},
+			}}
+			err = k8sClient.Status().Update(context.TODO(), vgClassObj)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(2 * time.Second)
+
+			By("Validating the orphaned volume group was deleted from the storage")
+			Expect(mock_grpc_server.IsVolumeGroupExist(OrphanedVGHandle)).To(BeFalse())
+
+			By("Validating the volume group that was not recorded was kept on the storage")
+			Expect(mock_grpc_server.IsVolumeGroupExist(UnrecordedVGHandle)).To(BeTrue())
+			mock_grpc_server.RemoveVolumeGroup(UnrecordedVGHandle)
+
+			By("Deleting the volumeGroupClass that deletes orphaned volume groups")
+			err = k8sClient.Delete(context.TODO(), OrphanGCVGClass)
+			Expect(err).NotTo(HaveOccurred())
+
 			close(done)
 		}, Timeout.Seconds())
 	})
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"time"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/controllers/envtest/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+var _ = Describe("Test VolumeGroupSnapshot controllers", func() {
+	Context("Test VGS controller", func() {
+
+		BeforeEach(func() {
+			err := cleanTestNamespace()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should snapshot every member volume of the volumeGroup", func(done Done) {
+			By("Creating volumeGroup objects with a member volume")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeObjects()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeGroupObjects(volumegroupv1.VolumeGroupContentDelete)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(1 * time.Second)
+
+			By("Validating that VolumeGroupContent supports volume group snapshots")
+			vgObj := &volumegroupv1.VolumeGroup{}
+			vgcObj, err := utils.GetVGCObjectFromVG(VGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*vgcObj.Spec.SupportVolumeGroupSnapshot).To(BeTrue())
+
+			By("Creating volumeGroupSnapshot objects")
+			err = createVolumeGroupSnapshotObjects()
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(2 * time.Second)
+
+			By("Validating VolumeGroupSnapshotContent has a snapshot of the member volume")
+			vgsObj := &volumegroupv1.VolumeGroupSnapshot{}
+			vgscObj, err := utils.GetVGSCObjectFromVGS(VGSName, Namespace, vgsObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vgscObj.Spec.Source.VolumeGroupSnapshotHandle).NotTo(BeEmpty())
+			Expect(len(vgscObj.Status.VolumeSnapshotHandles)).To(Equal(1))
+			Expect(vgscObj.Status.VolumeSnapshotHandles[0].PersistentVolumeName).To(Equal(PVName))
+			Expect(vgscObj.Status.VolumeSnapshotHandles[0].VolumeHandle).To(Equal(PV.Spec.CSI.VolumeHandle))
+			Expect(*vgscObj.Spec.DeletionPolicy).To(Equal(volumegroupv1.VolumeGroupSnapshotContentDelete))
+
+			By("Validating VolumeGroupSnapshot is bound and ready")
+			Expect(*vgsObj.Status.BoundVolumeGroupSnapshotContentName).To(Equal(vgscObj.Name))
+			Expect(meta.IsStatusConditionTrue(vgsObj.Status.Conditions, volumegroupv1.ConditionReady)).To(BeTrue())
+
+			close(done)
+		}, Timeout.Seconds())
+
+		It("Should not restore a volumeGroup before its dataSource volumeGroupSnapshot exists", func(done Done) {
+			By("Creating a volumeGroup with a volumeGroupSnapshot dataSource")
+			err := createNonVolumeK8SResources()
+			Expect(err).NotTo(HaveOccurred())
+			err = createVolumeGroupObjects(volumegroupv1.VolumeGroupContentDelete)
+			Expect(err).NotTo(HaveOccurred())
+			err = utils.CreateResourceObject(RestoredVG, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(2 * time.Second)
+
+			By("Validating the volumeGroup is not restored and not bound")
+			vgObj := &volumegroupv1.VolumeGroup{}
+			err = utils.GetNamespacedResourceObject(RestoredVGName, Namespace, vgObj, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionFalse(vgObj.Status.Conditions, volumegroupv1.ConditionRestored)).To(BeTrue())
+			Expect(vgObj.Spec.Source.VolumeGroupContentName).To(BeNil())
+			Expect(vgObj.Status.RestoredMembers).To(BeEmpty())
+
+			close(done)
+		}, Timeout.Seconds())
+	})
+})
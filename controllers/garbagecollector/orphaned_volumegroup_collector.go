@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package garbagecollector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroup"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupclasses/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupcontents,verbs=get;list;watch
+
+// OrphanedVolumeGroupCollector periodically looks for volume groups on the storage that were created
+// by the operator but are not referenced by any volumeGroupContent, for example when the volumeGroupContent
+// was deleted between creating the volume group and persisting its handle.
+// Only the volume groups recorded in the volumeGroupClass status are collected, see utils.GetOrphanedVGs.
+type OrphanedVolumeGroupCollector struct {
+	client.Client
+	Log          logr.Logger
+	DriverConfig *config.DriverConfig
+	Drivers      grpcClient.Drivers
+
+	orphanedSince map[string]time.Time
+}
+
+func (c *OrphanedVolumeGroupCollector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.DriverConfig.OrphanGCInterval)
+	defer ticker.Stop()
+	for {
+		c.collect(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *OrphanedVolumeGroupCollector) NeedLeaderElection() bool {
+	return true
+}
+
+func (c *OrphanedVolumeGroupCollector) collect(ctx context.Context) {
+	orphanedSince := map[string]time.Time{}
+	deletedVGs := map[string]bool{}
+	for _, driverName := range c.Drivers.Names() {
+		driver, _ := c.Drivers.Get(driverName)
+		if err := utils.CheckDriverReachable(ctx, c.Log, driver); err != nil {
+			c.keepOrphanedSince(driver, orphanedSince)
+			continue
+		}
+		if !driver.Capabilities().ListVolumeGroups {
+			c.Log.Info(fmt.Sprintf(messages.ListVGsIsNotSupportedByDriver, driver.Name))
+			continue
+		}
+		vgClasses, err := utils.GetVGClassList(ctx, c.Log, c.Client, driver.Name)
+		if err != nil {
+			continue
+		}
+		for i := range vgClasses {
+			c.collectVGClass(ctx, driver, c.Log.WithValues("VGClassName", vgClasses[i].Name), &vgClasses[i], orphanedSince, deletedVGs)
+		}
+	}
+	c.orphanedSince = orphanedSince
+}
+
+func (c *OrphanedVolumeGroupCollector) collectVGClass(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger,
+	vgClass *volumegroupv1.VolumeGroupClass, orphanedSince map[string]time.Time, deletedVGs map[string]bool) {
+	logger.Info(fmt.Sprintf(messages.CollectOrphanedVGs, vgClass.Name))
+	secrets, err := utils.GetSecretDataFromClass(ctx, c.Client, vgClass, logger)
+	if err != nil {
+		return
+	}
+	volumeGroups, err := utils.ListVGsOnStorage(ctx, logger, driver.VolumeGroup, secrets)
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			logger.Info(messages.ListVGsIsNotSupported)
+		}
+		return
+	}
+	orphanedVGs, err := utils.GetOrphanedVGs(ctx, logger, c.Client, vgClass, volumeGroups)
+	if err != nil {
+		return
+	}
+	metrics.OrphanedVolumeGroups.WithLabelValues(vgClass.Name).Set(float64(len(orphanedVGs)))
+
+	for _, orphanedVG := range orphanedVGs {
+		vgId := orphanedVG.GetVolumeGroupId()
+		// The ids of volume groups are unique per driver only.
+		orphanKey := driver.Name + "/" + vgId
+		if deletedVGs[orphanKey] {
+			continue
+		}
+		if _, isReported := orphanedSince[orphanKey]; !isReported {
+			orphanedSince[orphanKey] = c.getOrphanedSince(ctx, logger, vgClass, orphanKey, vgId)
+		}
+		if time.Since(orphanedSince[orphanKey]) < c.DriverConfig.OrphanGCGracePeriod ||
+			!utils.GetBoolField(vgClass, "DeleteOrphanedVolumeGroups") {
+			continue
+		}
+		if c.DriverConfig.OrphanGCDryRun {
+			logger.Info(fmt.Sprintf(messages.OrphanedVGDryRun, vgId))
+			continue
+		}
+		if err = c.deleteVG(ctx, driver, logger, vgId, secrets); err != nil {
+			continue
+		}
+		deletedVGs[orphanKey] = true
+		delete(orphanedSince, orphanKey)
+		_ = utils.ForgetCreatedVGs(ctx, c.Client, logger, vgClass, vgId)
+		metrics.OrphanedVolumeGroupsDeletedTotal.WithLabelValues(vgClass.Name).Inc()
+		_ = utils.CreateVGClassEvent(ctx, logger, c.Client, vgClass, fmt.Sprintf(messages.OrphanedVGDeleted, vgId), deleteOrphanedVG, false)
+	}
+}
+
+// keepOrphanedSince keeps the orphaned volume groups of a driver that is down, so their grace period
+// is not restarted once the driver is reachable again.
+func (c *OrphanedVolumeGroupCollector) keepOrphanedSince(driver *grpcClient.Driver, orphanedSince map[string]time.Time) {
+	for orphanKey, since := range c.orphanedSince {
+		if strings.HasPrefix(orphanKey, driver.Name+"/") {
+			orphanedSince[orphanKey] = since
+		}
+	}
+}
+
+func (c *OrphanedVolumeGroupCollector) getOrphanedSince(ctx context.Context, logger logr.Logger, vgClass *volumegroupv1.VolumeGroupClass,
+	orphanKey, vgId string) time.Time {
+	if since, ok := c.orphanedSince[orphanKey]; ok {
+		return since
+	}
+	message := fmt.Sprintf(messages.OrphanedVGFound, vgId)
+	logger.Info(message)
+	_ = utils.CreateVGClassEvent(ctx, logger, c.Client, vgClass, message, orphanedVG, true)
+	return time.Now()
+}
+
+func (c *OrphanedVolumeGroupCollector) deleteVG(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, vgId string,
+	secrets map[string]string) error {
+	param := volumegroup.CommonRequestParameters{
+		VolumeGroupID: vgId,
+		Secrets:       secrets,
+		VolumeGroup:   driver.VolumeGroup,
+	}
+
+	volumeGroupRequest := volumegroup.NewVolumeGroupRequest(param)
+
+	resp := volumeGroupRequest.Delete(ctx)
+
+	if resp.Error != nil {
+		logger.Error(resp.Error, fmt.Sprintf(messages.FailedToDeleteOrphanedVG, vgId))
+		return resp.Error
+	}
+
+	return nil
+}
+
+func (c *OrphanedVolumeGroupCollector) SetupWithManager(mgr ctrl.Manager, cfg *config.DriverConfig) error {
+	if cfg.OrphanGCInterval == 0 {
+		return nil
+	}
+	return mgr.Add(c)
+}
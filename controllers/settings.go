@@ -22,7 +22,9 @@ var (
 	vgReconcile    = "vgReconcile"
 	deleteVG       = "deletingVG"
 	createVG       = "creatingVG"
+	modifyVG       = "modifyingVG"
 	createVGC      = "creatingVGC"
 	updateVGC      = "updatingVGC"
 	updateStatusVG = "updatingStatusVG"
+	restoreVG      = "restoringVG"
 )
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func GenerateCondition(conditionType string, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// GenerateFailureConditions returns the failed condition together with a
+// not ready condition, and a driver not reachable condition when the error
+// shows that the CSI driver could not be reached.
+func GenerateFailureConditions(err error, conditionType, reason string) []metav1.Condition {
+	message := GetMessageFromError(err)
+	conditions := []metav1.Condition{
+		GenerateCondition(conditionType, metav1.ConditionFalse, reason, message),
+	}
+	if conditionType != volumegroupv1.ConditionReady {
+		conditions = append(conditions, GenerateCondition(volumegroupv1.ConditionReady, metav1.ConditionFalse, reason, message))
+	}
+	if isDriverUnreachableError(err) {
+		conditions = append(conditions, GenerateCondition(volumegroupv1.ConditionDriverReachable, metav1.ConditionFalse, reason, message))
+	}
+	return conditions
+}
+
+func isDriverUnreachableError(err error) bool {
+	s, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	return s.Code() == codes.Unavailable
+}
+
+func setConditions(currentConditions *[]metav1.Condition, observedGeneration *int64, generation int64,
+	conditions ...metav1.Condition) bool {
+	isChanged := *observedGeneration != generation
+	*observedGeneration = generation
+	for _, condition := range conditions {
+		condition.ObservedGeneration = generation
+		isChanged = meta.SetStatusCondition(currentConditions, condition) || isChanged
+	}
+	return isChanged
+}
+
+func SetVGConditions(vg *volumegroupv1.VolumeGroup, conditions ...metav1.Condition) bool {
+	return setConditions(&vg.Status.Conditions, &vg.Status.ObservedGeneration, vg.Generation, conditions...)
+}
+
+func SetVGCConditions(vgc *volumegroupv1.VolumeGroupContent, conditions ...metav1.Condition) bool {
+	return setConditions(&vgc.Status.Conditions, &vgc.Status.ObservedGeneration, vgc.Generation, conditions...)
+}
+
+func SetVGClassConditions(vgClass *volumegroupv1.VolumeGroupClass, conditions ...metav1.Condition) bool {
+	return setConditions(&vgClass.Status.Conditions, &vgClass.Status.ObservedGeneration, vgClass.Generation, conditions...)
+}
+
+func SetVGSConditions(vgs *volumegroupv1.VolumeGroupSnapshot, conditions ...metav1.Condition) bool {
+	return setConditions(&vgs.Status.Conditions, &vgs.Status.ObservedGeneration, vgs.Generation, conditions...)
+}
+
+func SetVGSCConditions(vgsc *volumegroupv1.VolumeGroupSnapshotContent, conditions ...metav1.Condition) bool {
+	return setConditions(&vgsc.Status.Conditions, &vgsc.Status.ObservedGeneration, vgsc.Generation, conditions...)
+}
@@ -22,9 +22,6 @@ import (
 	"math/rand"
 	"time"
 
-	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
-
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
 	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
@@ -32,19 +29,20 @@ import (
 	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func UpdateObject(client client.Client, updateObject client.Object) error {
-	if err := client.Update(context.TODO(), updateObject); err != nil {
+func UpdateObject(ctx context.Context, client client.Client, updateObject client.Object) error {
+	if err := client.Update(ctx, updateObject); err != nil {
 		return fmt.Errorf("failed to update %s (%s/%s) %w", updateObject.GetObjectKind(), updateObject.GetNamespace(), updateObject.GetName(), err)
 	}
 	return nil
 }
 
-func UpdateObjectStatus(client client.Client, updateObject client.Object) error {
-	if err := client.Status().Update(context.TODO(), updateObject); err != nil {
+func UpdateObjectStatus(ctx context.Context, client client.Client, updateObject client.Object) error {
+	if err := client.Status().Update(ctx, updateObject); err != nil {
 		if apierrors.IsConflict(err) {
 			return err
 		}
@@ -53,9 +51,9 @@ func UpdateObjectStatus(client client.Client, updateObject client.Object) error
 	return nil
 }
 
-func getNamespacedObject(client client.Client, obj client.Object) error {
+func getNamespacedObject(ctx context.Context, client client.Client, obj client.Object) error {
 	namespacedObject := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
-	err := client.Get(context.TODO(), namespacedObject, obj)
+	err := client.Get(ctx, namespacedObject, obj)
 	if err != nil {
 		return err
 	}
@@ -82,100 +80,114 @@ func generateString() string {
 	return string(b)
 }
 
-func AddVolumeToPvcListAndPvList(logger logr.Logger, client client.Client,
+func AddVolumeToPvcListAndPvList(ctx context.Context, logger logr.Logger, client client.Client,
 	pvc *corev1.PersistentVolumeClaim, vg *volumegroupv1.VolumeGroup) error {
-	err := AddPVCToVG(logger, client, pvc, vg)
+	pv, err := GetPVFromPVC(ctx, logger, client, pvc)
+	if err != nil {
+		return err
+	}
+	err = AddPVCToVG(ctx, logger, client, pvc, pv, vg)
 	if err != nil {
 		return err
 	}
 
-	err = AddMatchingPVToMatchingVGC(logger, client, pvc, vg)
+	err = AddMatchingPVToMatchingVGC(ctx, logger, client, pv, vg)
 	if err != nil {
 		return err
 	}
 
-	if err = AddFinalizerToPVC(client, logger, pvc); err != nil {
+	if err = AddFinalizerToPVC(ctx, client, logger, pvc); err != nil {
 		return err
 	}
 
 	message := fmt.Sprintf(messages.AddedPVCToVG, pvc.Namespace, pvc.Name, vg.Namespace, vg.Name)
-	return HandleSuccessMessage(logger, client, vg, message, addingPVC)
+	return HandleSuccessMessage(ctx, logger, client, vg, message, volumegroupv1.ConditionMembershipSynced, addingPVC)
 }
 
-func RemoveVolumeFromPvcListAndPvList(logger logr.Logger, client client.Client, driver string,
-	pvc corev1.PersistentVolumeClaim, vg *volumegroupv1.VolumeGroup) error {
-	err := RemovePVCFromVG(logger, client, &pvc, vg)
+func RemoveVolumeFromPvcListAndPvList(ctx context.Context, logger logr.Logger, client client.Client, driver string,
+	member volumegroupv1.VolumeGroupMemberReference, vg *volumegroupv1.VolumeGroup) error {
+	err := RemovePVCFromVG(ctx, logger, client, member, vg)
 	if err != nil {
 		return err
 	}
-	pv, err := GetPVFromPVC(logger, client, &pvc)
-	if err != nil {
-		return err
-	}
-	vgc, err := GetVGC(client, logger, GetStringField(vg.Spec.Source, "VolumeGroupContentName"), vg.Namespace)
+	vgc, err := GetVGC(ctx, client, logger, GetStringField(vg.Spec.Source, "VolumeGroupContentName"), vg.Namespace)
 	if err != nil {
 		return err
 	}
 
-	if pv != nil {
-		err = RemovePVFromVGC(logger, client, pv, vgc)
+	// The persistentVolume of a deleted persistentVolumeClaim may be gone, it is found by the member reference.
+	if pvMember := GetVGCMemberOfClaim(vgc, member); pvMember != nil {
+		err = RemovePVFromVGC(ctx, logger, client, pvMember.Name, vgc)
 		if err != nil {
 			return err
 		}
 	}
-	err = RemoveFinalizerFromPVC(client, logger, driver, &pvc)
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: member.Name, Namespace: member.Namespace}}
+	err = RemoveFinalizerFromPVC(ctx, client, logger, driver, pvc)
 	if err != nil {
 		return err
 	}
 
-	message := fmt.Sprintf(messages.RemovedPVCFromVG, pvc.Namespace, pvc.Name, vg.Namespace, vg.Name)
-	return HandleSuccessMessage(logger, client, vg, message, removingPVC)
+	message := fmt.Sprintf(messages.RemovedPVCFromVG, member.Namespace, member.Name, vg.Namespace, vg.Name)
+	return HandleSuccessMessage(ctx, logger, client, vg, message, volumegroupv1.ConditionMembershipSynced, removingPVC)
 }
 
-func ModifyVolumesInVG(logger logr.Logger, client client.Client, vgClient grpcClient.VolumeGroup,
+// ModifyVolumesInVG sets the volumes of the matching persistentVolumeClaims as the members of the volume group on the storage.
+func ModifyVolumesInVG(ctx context.Context, logger logr.Logger, client client.Client, vgClient grpcClient.VolumeGroup,
 	matchingPvcs []corev1.PersistentVolumeClaim, vg volumegroupv1.VolumeGroup) error {
-
-	currentList := make([]corev1.PersistentVolumeClaim, len(vg.Status.PVCList))
-	copy(currentList, vg.Status.PVCList)
-
-	vg.Status.PVCList = matchingPvcs
-
-	err := ModifyVG(logger, client, &vg, vgClient)
+	members, err := generateVGMembers(ctx, logger, client, matchingPvcs)
 	if err != nil {
-		vg.Status.PVCList = currentList
 		return err
 	}
+	vg.Status.Members = members
 
-	return nil
+	return ModifyVG(ctx, logger, client, &vg, vgClient)
 }
 
-func UpdatePvcAndPvList(logger logr.Logger, vg *volumegroupv1.VolumeGroup, client client.Client, driver string,
+func UpdatePvcAndPvList(ctx context.Context, logger logr.Logger, vg *volumegroupv1.VolumeGroup, client client.Client, driver string,
 	matchingPvcs []corev1.PersistentVolumeClaim) error {
 
-	vgPvcList := make([]corev1.PersistentVolumeClaim, len(vg.Status.PVCList))
-	copy(vgPvcList, vg.Status.PVCList)
+	members, err := GetVGMembers(ctx, logger, client, vg)
+	if err != nil {
+		return err
+	}
+	vgMembers := make([]volumegroupv1.VolumeGroupMemberReference, len(members))
+	copy(vgMembers, members)
 
-	for _, pvc := range vgPvcList {
-		if !IsPVCInPVCList(&pvc, matchingPvcs) {
-			err := RemoveVolumeFromPvcListAndPvList(logger, client, driver, pvc, vg)
+	for _, member := range vgMembers {
+		if !isMemberInPVCList(member, matchingPvcs) {
+			err := RemoveVolumeFromPvcListAndPvList(ctx, logger, client, driver, member, vg)
 			if err != nil {
-				return HandleErrorMessage(logger, client, vg, err, removingPVC)
+				RecordVGMemberError(ctx, logger, client, vg, member.Namespace, member.Name, err)
+				return HandleErrorMessage(ctx, logger, client, vg, err, volumegroupv1.ConditionMembershipSynced, removingPVC)
 			}
 		}
 	}
 	for _, pvc := range matchingPvcs {
-		if !IsPVCInPVCList(&pvc, vgPvcList) {
-			err := AddVolumeToPvcListAndPvList(logger, client, &pvc, vg)
+		if !isPVCJoined(&pvc, vgMembers) {
+			err := AddVolumeToPvcListAndPvList(ctx, logger, client, &pvc, vg)
 			if err != nil {
-				return HandleErrorMessage(logger, client, vg, err, addingPVC)
+				RecordVGMemberError(ctx, logger, client, vg, pvc.Namespace, pvc.Name, err)
+				return HandleErrorMessage(ctx, logger, client, vg, err, volumegroupv1.ConditionMembershipSynced, addingPVC)
 			}
 		}
 	}
 	return nil
 }
 
-func IsPVCListEqual(x []corev1.PersistentVolumeClaim, y []corev1.PersistentVolumeClaim) bool {
-	less := func(a, b corev1.PersistentVolumeClaim) bool { return a.Name < b.Name }
-	equalIgnoreOrder := cmp.Diff(x, y, cmpopts.SortSlices(less)) == ""
-	return equalIgnoreOrder
+// IsPVCListEqualToMembers returns whether the persistentVolumeClaims are the members of the volumeGroup, ignoring order.
+func IsPVCListEqualToMembers(pvcList []corev1.PersistentVolumeClaim, members []volumegroupv1.VolumeGroupMemberReference) bool {
+	if len(pvcList) != len(members) {
+		return false
+	}
+	pvcKeys := make(map[types.NamespacedName]bool, len(pvcList))
+	for _, pvc := range pvcList {
+		pvcKeys[types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name}] = true
+	}
+	for _, member := range members {
+		if !pvcKeys[types.NamespacedName{Namespace: member.Namespace, Name: member.Name}] {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,227 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroup"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DiscoverVGs creates an unbound volumeGroupContent for every volume group on the storage
+// that is not referenced by a volumeGroupContent of the driver yet.
+// The discovery is postponed while a volume group of the driver is being created, because the storage
+// may already list the new group before its volumeGroupContent records the handle.
+func DiscoverVGs(ctx context.Context, logger logr.Logger, client client.Client, vgClient grpcClient.VolumeGroup,
+	vgClass *volumegroupv1.VolumeGroupClass, secrets map[string]string) error {
+	logger.Info(fmt.Sprintf(messages.DiscoverVGs, vgClass.Name))
+	volumeGroups, err := ListVGsOnStorage(ctx, logger, vgClient, secrets)
+	if err != nil {
+		return err
+	}
+	vgcList := &volumegroupv1.VolumeGroupContentList{}
+	if err = client.List(ctx, vgcList); err != nil {
+		logger.Error(err, messages.FailedToListVGC)
+		return err
+	}
+	isCreationInProgress, err := isVGCreationOfDriverInProgress(ctx, logger, client, vgcList.Items, vgClass.Driver)
+	if err != nil {
+		return err
+	}
+	if isCreationInProgress {
+		logger.Info(fmt.Sprintf(messages.PostponeVGDiscovery, vgClass.Name))
+		return nil
+	}
+	vgcsByHandle := getVGCsByHandle(vgcList.Items, vgClass.Driver)
+	for _, volumeGroup := range volumeGroups {
+		if vgc, ok := vgcsByHandle[volumeGroup.GetVolumeGroupId()]; ok {
+			if !isDiscoveredVGCWithoutMembers(vgc) {
+				continue
+			}
+			err = fillDiscoveredVGCMembers(ctx, logger, client, vgClass.Driver, vgc, volumeGroup)
+		} else {
+			err = createDiscoveredVGC(ctx, logger, client, vgClass, volumeGroup)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ListVGsOnStorage(ctx context.Context, logger logr.Logger, vgClient grpcClient.VolumeGroup, secrets map[string]string) ([]*csi.VolumeGroup, error) {
+	var volumeGroups []*csi.VolumeGroup
+	param := volumegroup.CommonRequestParameters{
+		MaxEntries:  discoveryPageSize,
+		Secrets:     secrets,
+		VolumeGroup: vgClient,
+	}
+	for {
+		resp := volumegroup.NewVolumeGroupRequest(param).List(ctx)
+		if resp.Error != nil {
+			logger.Error(resp.Error, messages.FailedToListVGsOnStorage)
+			return nil, resp.Error
+		}
+		listVGsResponse := resp.Response.(*csi.ListVolumeGroupsResponse)
+		for _, entry := range listVGsResponse.GetEntries() {
+			if entry.GetVolumeGroup() != nil {
+				volumeGroups = append(volumeGroups, entry.GetVolumeGroup())
+			}
+		}
+		if listVGsResponse.GetNextToken() == "" {
+			return volumeGroups, nil
+		}
+		param.StartingToken = listVGsResponse.GetNextToken()
+	}
+}
+
+func getVGCsByHandle(vgcs []volumegroupv1.VolumeGroupContent, driver string) map[string]*volumegroupv1.VolumeGroupContent {
+	vgcsByHandle := map[string]*volumegroupv1.VolumeGroupContent{}
+	for i := range vgcs {
+		vgc := &vgcs[i]
+		if vgc.Spec.Source != nil && vgc.Spec.Source.Driver == driver && vgc.Spec.Source.VolumeGroupHandle != "" {
+			vgcsByHandle[vgc.Spec.Source.VolumeGroupHandle] = vgc
+		}
+	}
+	return vgcsByHandle
+}
+
+func isVGCreationOfDriverInProgress(ctx context.Context, logger logr.Logger, client client.Client,
+	vgcs []volumegroupv1.VolumeGroupContent, driver string) (bool, error) {
+	for _, vgc := range vgcs {
+		if !IsVGCreationInProgress(&vgc) || vgc.Spec.VolumeGroupClassName == nil {
+			continue
+		}
+		vgClass, err := GetVGClass(ctx, client, logger, *vgc.Spec.VolumeGroupClassName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+		if vgClass.Driver == driver {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isDiscoveredVGCWithoutMembers returns true for a discovered volumeGroupContent that is not bound yet
+// and whose members were not filled in, e.g. when the operator restarted right after creating it.
+func isDiscoveredVGCWithoutMembers(vgc *volumegroupv1.VolumeGroupContent) bool {
+	return vgc.Labels[DiscoveredVGCLabel] == "true" && vgc.Spec.VolumeGroupRef == nil && len(vgc.Status.Members) == 0
+}
+
+func createDiscoveredVGC(ctx context.Context, logger logr.Logger, client client.Client, vgClass *volumegroupv1.VolumeGroupClass,
+	volumeGroup *csi.VolumeGroup) error {
+	vgc := generateDiscoveredVGC(vgClass, volumeGroup)
+	logger.Info(fmt.Sprintf(messages.CreateDiscoveredVGC, vgc.Namespace, vgc.Name, volumeGroup.GetVolumeGroupId()))
+	if err := client.Create(ctx, vgc); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			logger.Error(err, fmt.Sprintf(messages.FailedToCreateDiscoveredVGC, vgc.Namespace, vgc.Name))
+			return err
+		}
+		existingVGC, err := GetVGC(ctx, client, logger, vgc.Name, vgc.Namespace)
+		if err != nil {
+			return err
+		}
+		if !isDiscoveredVGCWithoutMembers(existingVGC) {
+			return nil
+		}
+		vgc = existingVGC
+	}
+	return fillDiscoveredVGCMembers(ctx, logger, client, vgClass.Driver, vgc, volumeGroup)
+}
+
+func fillDiscoveredVGCMembers(ctx context.Context, logger logr.Logger, client client.Client, driver string,
+	vgc *volumegroupv1.VolumeGroupContent, volumeGroup *csi.VolumeGroup) error {
+	pvList, err := getPVsOfVolumeIds(ctx, logger, client, driver, volumeGroup.GetVolumes())
+	if err != nil {
+		return err
+	}
+	if len(pvList) == 0 {
+		return nil
+	}
+	members := []volumegroupv1.VolumeGroupMemberReference{}
+	for _, pv := range pvList {
+		members = append(members, GenerateVGCMember(&pv))
+	}
+	return updateVGCStatusMembers(ctx, client, vgc, logger, func(_ []volumegroupv1.VolumeGroupMemberReference) []volumegroupv1.VolumeGroupMemberReference {
+		return members
+	})
+}
+
+func generateDiscoveredVGC(vgClass *volumegroupv1.VolumeGroupClass, volumeGroup *csi.VolumeGroup) *volumegroupv1.VolumeGroupContent {
+	vgClassName := vgClass.Name
+	deletionPolicy := volumegroupv1.VolumeGroupContentRetain
+	supportVolumeGroupSnapshot := GetBoolField(vgClass, "SupportVolumeGroupSnapshot")
+	secretName, secretNamespace := GetSecretCred(vgClass)
+	return &volumegroupv1.VolumeGroupContent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getDiscoveredVGCName(vgClass.Driver, volumeGroup.GetVolumeGroupId()),
+			Namespace: vgClass.VolumeGroupDiscovery.Namespace,
+			Labels:    map[string]string{DiscoveredVGCLabel: "true"},
+		},
+		Spec: volumegroupv1.VolumeGroupContentSpec{
+			VolumeGroupClassName: &vgClassName,
+			Source: &volumegroupv1.VolumeGroupContentSource{
+				Driver:                vgClass.Driver,
+				VolumeGroupHandle:     volumeGroup.GetVolumeGroupId(),
+				VolumeGroupAttributes: volumeGroup.GetVolumeGroupContext(),
+			},
+			VolumeGroupDeletionPolicy:  &deletionPolicy,
+			SupportVolumeGroupSnapshot: &supportVolumeGroupSnapshot,
+			VolumeGroupSecretRef:       generateSecretReference(secretName, secretNamespace),
+		},
+	}
+}
+
+func getDiscoveredVGCName(driver, vgId string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s", driver, vgId)))
+	return fmt.Sprintf("%s-%x", DiscoveredVGCNamePrefix, hash[:8])
+}
+
+func getPVsOfVolumeIds(ctx context.Context, logger logr.Logger, client client.Client, driver string,
+	volumes []*csi.VgVolume) ([]corev1.PersistentVolume, error) {
+	volumeIds := map[string]bool{}
+	for _, volume := range volumes {
+		volumeIds[volume.GetVolumeId()] = true
+	}
+	pvList := &corev1.PersistentVolumeList{}
+	if err := client.List(ctx, pvList); err != nil {
+		logger.Error(err, messages.FailedToListPV)
+		return nil, err
+	}
+	var pvs []corev1.PersistentVolume
+	for _, pv := range pvList.Items {
+		if pv.Spec.CSI != nil && pv.Spec.CSI.Driver == driver && volumeIds[pv.Spec.CSI.VolumeHandle] {
+			pvs = append(pvs, pv)
+		}
+	}
+	return pvs, nil
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckDriverReachable returns an Unavailable error while the connection to the driver is down, so the reconcile
+// is retried like any transient driver failure instead of calling the driver.
+func CheckDriverReachable(ctx context.Context, logger logr.Logger, driver *grpcClient.Driver) error {
+	if driver.Client.IsReachable(ctx) {
+		return nil
+	}
+	message := fmt.Sprintf(messages.DriverIsUnreachable, driver.Name)
+	logger.Info(message)
+	return status.Error(codes.Unavailable, message)
+}
@@ -28,20 +28,20 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func createSuccessNamespacedObjectEvent(logger logr.Logger, client client.Client, object client.Object,
+func createSuccessNamespacedObjectEvent(ctx context.Context, logger logr.Logger, client client.Client, object client.Object,
 	message, reason string) error {
 	event := generateEvent(object, reason, message, normalEventType)
 	logger.Info(fmt.Sprintf(messages.CreateEventForNamespacedObject, object.GetNamespace(), object.GetName(),
 		object.GetObjectKind().GroupVersionKind().Kind, message))
-	return createEvent(logger, client, event)
+	return createEvent(ctx, logger, client, event)
 }
 
-func createNamespacedObjectErrorEvent(logger logr.Logger, client client.Client, object client.Object,
+func createNamespacedObjectErrorEvent(ctx context.Context, logger logr.Logger, client client.Client, object client.Object,
 	errorMessage, reason string) error {
 	event := generateEvent(object, reason, errorMessage, warningEventType)
 	logger.Info(fmt.Sprintf(messages.CreateEventForNamespacedObject, object.GetNamespace(), object.GetName(),
 		object.GetObjectKind().GroupVersionKind().Kind, errorMessage))
-	return createEvent(logger, client, event)
+	return createEvent(ctx, logger, client, event)
 }
 
 func generateEvent(object client.Object, reason, message, eventType string) *corev1.Event {
@@ -67,8 +67,8 @@ func generateEvent(object client.Object, reason, message, eventType string) *cor
 	}
 }
 
-func createEvent(logger logr.Logger, client client.Client, event *corev1.Event) error {
-	err := client.Create(context.TODO(), event)
+func createEvent(ctx context.Context, logger logr.Logger, client client.Client, event *corev1.Event) error {
+	err := client.Create(ctx, event)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf(messages.FailedToCreateEvent, event.Namespace, event.Name))
 		return err
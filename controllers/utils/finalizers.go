@@ -17,6 +17,7 @@ limitations under the License.
 package utils
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -30,11 +31,11 @@ import (
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func AddFinalizerToVG(client runtimeclient.Client, logger logr.Logger, vg *volumegroupv1.VolumeGroup) error {
+func AddFinalizerToVG(ctx context.Context, client runtimeclient.Client, logger logr.Logger, vg *volumegroupv1.VolumeGroup) error {
 	if !commonUtils.Contains(vg.ObjectMeta.Finalizers, VGFinalizer) {
 		logger.Info("adding finalizer to VolumeGroup object", "Finalizer", VGFinalizer)
 		vg.ObjectMeta.Finalizers = append(vg.ObjectMeta.Finalizers, VGFinalizer)
-		if err := updateFinalizer(logger, client, vg.ObjectMeta.Finalizers, vg); err != nil {
+		if err := updateFinalizer(ctx, logger, client, vg.ObjectMeta.Finalizers, vg); err != nil {
 			logger.Error(err, "failed to add finalizer to volumeGroup resource", "finalizer", VGFinalizer)
 			return err
 		}
@@ -43,11 +44,11 @@ func AddFinalizerToVG(client runtimeclient.Client, logger logr.Logger, vg *volum
 	return nil
 }
 
-func AddFinalizerToVGC(client runtimeclient.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
+func AddFinalizerToVGC(ctx context.Context, client runtimeclient.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
 	if !commonUtils.Contains(vgc.ObjectMeta.Finalizers, VgcFinalizer) {
 		logger.Info("adding finalizer to volumeGroupContent object", "Name", vgc.Name, "Finalizer", VgcFinalizer)
 		vgc.ObjectMeta.Finalizers = append(vgc.ObjectMeta.Finalizers, VgcFinalizer)
-		if err := updateFinalizer(logger, client, vgc.ObjectMeta.Finalizers, vgc); err != nil {
+		if err := updateFinalizer(ctx, logger, client, vgc.ObjectMeta.Finalizers, vgc); err != nil {
 			logger.Error(err, "failed to add finalizer to volumeGroupContent resource", "finalizer", VGFinalizer)
 			return err
 		}
@@ -56,11 +57,11 @@ func AddFinalizerToVGC(client runtimeclient.Client, logger logr.Logger, vgc *vol
 	return nil
 }
 
-func RemoveFinalizerFromVG(client runtimeclient.Client, logger logr.Logger, vg *volumegroupv1.VolumeGroup) error {
+func RemoveFinalizerFromVG(ctx context.Context, client runtimeclient.Client, logger logr.Logger, vg *volumegroupv1.VolumeGroup) error {
 	if commonUtils.Contains(vg.ObjectMeta.Finalizers, VGFinalizer) {
 		logger.Info("removing finalizer from VolumeGroup object", "Finalizer", VGFinalizer)
 		vg.ObjectMeta.Finalizers = commonUtils.Remove(vg.ObjectMeta.Finalizers, VGFinalizer)
-		if err := updateFinalizer(logger, client, vg.ObjectMeta.Finalizers, vg); err != nil {
+		if err := updateFinalizer(ctx, logger, client, vg.ObjectMeta.Finalizers, vg); err != nil {
 			logger.Error(err, "failed to remove finalizer to VolumeGroup resource", "finalizer", VGFinalizer)
 			return err
 		}
@@ -69,11 +70,11 @@ func RemoveFinalizerFromVG(client runtimeclient.Client, logger logr.Logger, vg *
 	return nil
 }
 
-func RemoveFinalizerFromVGC(client runtimeclient.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
+func RemoveFinalizerFromVGC(ctx context.Context, client runtimeclient.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
 	if commonUtils.Contains(vgc.ObjectMeta.Finalizers, VgcFinalizer) {
 		logger.Info("removing finalizer from VolumeGroupContent object", "Name", vgc.Name, "Finalizer", VgcFinalizer)
 		vgc.ObjectMeta.Finalizers = commonUtils.Remove(vgc.ObjectMeta.Finalizers, VgcFinalizer)
-		if err := updateFinalizer(logger, client, vgc.ObjectMeta.Finalizers, vgc); err != nil {
+		if err := updateFinalizer(ctx, logger, client, vgc.ObjectMeta.Finalizers, vgc); err != nil {
 			logger.Error(err, "failed to remove finalizer to VolumeGroupContent resource", "finalizer", VGFinalizer)
 			return err
 		}
@@ -82,11 +83,63 @@ func RemoveFinalizerFromVGC(client runtimeclient.Client, logger logr.Logger, vgc
 	return nil
 }
 
-func AddFinalizerToPVC(client runtimeclient.Client, logger logr.Logger, pvc *corev1.PersistentVolumeClaim) error {
+func AddFinalizerToVGS(ctx context.Context, client runtimeclient.Client, logger logr.Logger, vgs *volumegroupv1.VolumeGroupSnapshot) error {
+	if !commonUtils.Contains(vgs.ObjectMeta.Finalizers, VgsFinalizer) {
+		logger.Info("adding finalizer to volumeGroupSnapshot object", "Name", vgs.Name, "Finalizer", VgsFinalizer)
+		vgs.ObjectMeta.Finalizers = append(vgs.ObjectMeta.Finalizers, VgsFinalizer)
+		if err := updateFinalizer(ctx, logger, client, vgs.ObjectMeta.Finalizers, vgs); err != nil {
+			logger.Error(err, "failed to add finalizer to volumeGroupSnapshot resource", "finalizer", VgsFinalizer)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func RemoveFinalizerFromVGS(ctx context.Context, client runtimeclient.Client, logger logr.Logger, vgs *volumegroupv1.VolumeGroupSnapshot) error {
+	if commonUtils.Contains(vgs.ObjectMeta.Finalizers, VgsFinalizer) {
+		logger.Info("removing finalizer from volumeGroupSnapshot object", "Name", vgs.Name, "Finalizer", VgsFinalizer)
+		vgs.ObjectMeta.Finalizers = commonUtils.Remove(vgs.ObjectMeta.Finalizers, VgsFinalizer)
+		if err := updateFinalizer(ctx, logger, client, vgs.ObjectMeta.Finalizers, vgs); err != nil {
+			logger.Error(err, "failed to remove finalizer to volumeGroupSnapshot resource", "finalizer", VgsFinalizer)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func AddFinalizerToVGSC(ctx context.Context, client runtimeclient.Client, logger logr.Logger, vgsc *volumegroupv1.VolumeGroupSnapshotContent) error {
+	if !commonUtils.Contains(vgsc.ObjectMeta.Finalizers, VgscFinalizer) {
+		logger.Info("adding finalizer to volumeGroupSnapshotContent object", "Name", vgsc.Name, "Finalizer", VgscFinalizer)
+		vgsc.ObjectMeta.Finalizers = append(vgsc.ObjectMeta.Finalizers, VgscFinalizer)
+		if err := updateFinalizer(ctx, logger, client, vgsc.ObjectMeta.Finalizers, vgsc); err != nil {
+			logger.Error(err, "failed to add finalizer to volumeGroupSnapshotContent resource", "finalizer", VgscFinalizer)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func RemoveFinalizerFromVGSC(ctx context.Context, client runtimeclient.Client, logger logr.Logger, vgsc *volumegroupv1.VolumeGroupSnapshotContent) error {
+	if commonUtils.Contains(vgsc.ObjectMeta.Finalizers, VgscFinalizer) {
+		logger.Info("removing finalizer from volumeGroupSnapshotContent object", "Name", vgsc.Name, "Finalizer", VgscFinalizer)
+		vgsc.ObjectMeta.Finalizers = commonUtils.Remove(vgsc.ObjectMeta.Finalizers, VgscFinalizer)
+		if err := updateFinalizer(ctx, logger, client, vgsc.ObjectMeta.Finalizers, vgsc); err != nil {
+			logger.Error(err, "failed to remove finalizer to volumeGroupSnapshotContent resource", "finalizer", VgscFinalizer)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func AddFinalizerToPVC(ctx context.Context, client runtimeclient.Client, logger logr.Logger, pvc *corev1.PersistentVolumeClaim) error {
 	if !commonUtils.Contains(pvc.ObjectMeta.Finalizers, pvcVGFinalizer) {
 		logger.Info("adding finalizer to PersistentVolumeClaim object", "Namespace", pvc.Namespace, "Name", pvc.Name, "Finalizer", pvcVGFinalizer)
 		pvc.ObjectMeta.Finalizers = append(pvc.ObjectMeta.Finalizers, pvcVGFinalizer)
-		if err := updateFinalizer(logger, client, pvc.ObjectMeta.Finalizers, pvc); err != nil {
+		if err := updateFinalizer(ctx, logger, client, pvc.ObjectMeta.Finalizers, pvc); err != nil {
 			logger.Error(err, "failed to add finalizer to PersistentVolumeClaim resource", "finalizer", VGFinalizer)
 			return err
 		}
@@ -95,21 +148,21 @@ func AddFinalizerToPVC(client runtimeclient.Client, logger logr.Logger, pvc *cor
 	return nil
 }
 
-func RemoveFinalizerFromPVC(client runtimeclient.Client, logger logr.Logger, driver string,
+func RemoveFinalizerFromPVC(ctx context.Context, client runtimeclient.Client, logger logr.Logger, driver string,
 	pvc *corev1.PersistentVolumeClaim) error {
-	removeFinalizer, err := isFinalizerShouldBeREmovedFromPVC(logger, client, driver, pvc)
+	removeFinalizer, err := isFinalizerShouldBeREmovedFromPVC(ctx, logger, client, driver, pvc)
 	if err != nil {
 		return err
 	}
 
 	if removeFinalizer {
 		logger.Info("removing finalizer from PersistentVolumeClaim object", "Namespace", pvc.Namespace, "Name", pvc.Name, "Finalizer", pvcVGFinalizer)
-		uErr := getNamespacedObject(client, pvc)
+		uErr := getNamespacedObject(ctx, client, pvc)
 		if uErr != nil {
 			return uErr
 		}
 		pvc.ObjectMeta.Finalizers = commonUtils.Remove(pvc.ObjectMeta.Finalizers, pvcVGFinalizer)
-		if err := updateFinalizer(logger, client, pvc.ObjectMeta.Finalizers, pvc); err != nil {
+		if err := updateFinalizer(ctx, logger, client, pvc.ObjectMeta.Finalizers, pvc); err != nil {
 			logger.Error(err, "failed to remove finalizer to PersistentVolumeClaim resource", "finalizer", VGFinalizer)
 			return err
 		}
@@ -118,33 +171,40 @@ func RemoveFinalizerFromPVC(client runtimeclient.Client, logger logr.Logger, dri
 	return nil
 }
 
-func isFinalizerShouldBeREmovedFromPVC(logger logr.Logger, client runtimeclient.Client, driver string,
+func isFinalizerShouldBeREmovedFromPVC(ctx context.Context, logger logr.Logger, client runtimeclient.Client, driver string,
 	pvc *corev1.PersistentVolumeClaim) (bool, error) {
-	pvc, err := GetPVC(logger, client, pvc.Name, pvc.Namespace)
+	pvc, err := GetPVC(ctx, logger, client, pvc.Name, pvc.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	vgList, err := GetVGList(ctx, logger, client, driver)
 	if err != nil {
 		return false, err
 	}
-	vgList, err := GetVGList(logger, client, driver)
+	isPVCPartAnyVG, err := IsPVCPartAnyVG(ctx, logger, client, pvc, vgList.Items)
 	if err != nil {
 		return false, err
 	}
-	return !IsPVCPartAnyVG(pvc, vgList.Items) && commonUtils.Contains(pvc.ObjectMeta.Finalizers, pvcVGFinalizer), nil
+	return !isPVCPartAnyVG && commonUtils.Contains(pvc.ObjectMeta.Finalizers, pvcVGFinalizer), nil
 }
 
-func updateFinalizer(logger logr.Logger, client runtimeclient.Client,
+func updateFinalizer(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
 	finalizers []string, obj runtimeclient.Object) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		return finalizerRetryOnConflictFunc(logger, client, finalizers, obj)
+		return finalizerRetryOnConflictFunc(ctx, logger, client, finalizers, obj)
 	})
 	return err
 }
 
-func finalizerRetryOnConflictFunc(logger logr.Logger, client runtimeclient.Client,
+func finalizerRetryOnConflictFunc(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
 	finalizers []string, obj runtimeclient.Object) error {
 	obj.SetFinalizers(finalizers)
-	err := UpdateObject(client, obj)
+	err := UpdateObject(ctx, client, obj)
 	if apierrors.IsConflict(err) {
-		uErr := getNamespacedObject(client, obj)
+		uErr := getNamespacedObject(ctx, client, obj)
 		if uErr != nil {
 			return uErr
 		}
@@ -158,6 +218,7 @@ func IsContainOtherFinalizers(object runtimeclient.Object, logger logr.Logger) b
 	for _, finalizer := range finalizers {
 		if !strings.Contains(finalizer, VGFinalizer) {
 			logger.Info(fmt.Sprintf(messages.NonVolumeGroupFinalizers, object.GetObjectKind().GroupVersionKind().Kind, object.GetName()))
+			recordFinalizerBlockedDeletion(object)
 			return true
 		}
 	}
@@ -17,21 +17,23 @@ limitations under the License.
 package utils
 
 import (
+	"context"
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func HandleErrorMessage(logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
-	err error, reason string) error {
+func HandleErrorMessage(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+	err error, conditionType, reason string) error {
 	if err != nil {
 		errorMessage := GetMessageFromError(err)
-		uErr := UpdateVGStatusError(client, vg, logger, errorMessage)
+		uErr := UpdateVGStatusConditions(ctx, client, vg, logger, GenerateFailureConditions(err, conditionType, reason)...)
 		if uErr != nil {
 			return uErr
 		}
-		uErr = createNamespacedObjectErrorEvent(logger, client, vg, errorMessage, reason)
+		uErr = createNamespacedObjectErrorEvent(ctx, logger, client, vg, errorMessage, reason)
 		if uErr != nil {
 			return uErr
 		}
@@ -40,37 +42,82 @@ func HandleErrorMessage(logger logr.Logger, client client.Client, vg *volumegrou
 	return nil
 }
 
-func HandleSuccessMessage(logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup, message, reason string) error {
-	err := UpdateVGStatusError(client, vg, logger, "")
+func HandleSuccessMessage(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+	message, conditionType, reason string) error {
+	err := UpdateVGStatusConditions(ctx, client, vg, logger,
+		GenerateCondition(conditionType, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, message))
 	if err != nil {
 		return err
 	}
-	err = createSuccessNamespacedObjectEvent(logger, client, vg, message, reason)
+	err = createSuccessNamespacedObjectEvent(ctx, logger, client, vg, message, reason)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func HandlePVCErrorMessage(logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim,
+func HandlePVCErrorMessage(ctx context.Context, logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim,
 	err error, reason string) error {
 	if err != nil {
 		errorMessage := GetMessageFromError(err)
-		if uErr := createNamespacedObjectErrorEvent(logger, client, pvc, errorMessage, reason); uErr != nil {
+		if uErr := createNamespacedObjectErrorEvent(ctx, logger, client, pvc, errorMessage, reason); uErr != nil {
 			return uErr
 		}
 	}
 	return nil
 }
 
-func HandleVGCErrorMessage(logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent,
-	err error, reason string) error {
+func HandleVGCErrorMessage(ctx context.Context, logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent,
+	err error, conditionType, reason string) error {
 	if err != nil {
 		errorMessage := GetMessageFromError(err)
-		if uErr := createNamespacedObjectErrorEvent(logger, client, vgc, errorMessage, reason); uErr != nil {
+		if uErr := UpdateVGCStatusConditions(ctx, client, vgc, logger, GenerateFailureConditions(err, conditionType, reason)...); uErr != nil {
+			return uErr
+		}
+		if uErr := createNamespacedObjectErrorEvent(ctx, logger, client, vgc, errorMessage, reason); uErr != nil {
 			return uErr
 		}
 		return err
 	}
 	return err
 }
+
+func HandleVGSErrorMessage(ctx context.Context, logger logr.Logger, client client.Client, vgs *volumegroupv1.VolumeGroupSnapshot,
+	err error, conditionType, reason string) error {
+	if err != nil {
+		errorMessage := GetMessageFromError(err)
+		if uErr := UpdateVGSStatusConditions(ctx, client, vgs, logger, GenerateFailureConditions(err, conditionType, reason)...); uErr != nil {
+			return uErr
+		}
+		if uErr := createNamespacedObjectErrorEvent(ctx, logger, client, vgs, errorMessage, reason); uErr != nil {
+			return uErr
+		}
+		return err
+	}
+	return nil
+}
+
+func HandleVGSSuccessMessage(ctx context.Context, logger logr.Logger, client client.Client, vgs *volumegroupv1.VolumeGroupSnapshot,
+	message, conditionType, reason string) error {
+	err := UpdateVGSStatusConditions(ctx, client, vgs, logger,
+		GenerateCondition(conditionType, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, message))
+	if err != nil {
+		return err
+	}
+	return createSuccessNamespacedObjectEvent(ctx, logger, client, vgs, message, reason)
+}
+
+func HandleVGSCErrorMessage(ctx context.Context, logger logr.Logger, client client.Client, vgsc *volumegroupv1.VolumeGroupSnapshotContent,
+	err error, conditionType, reason string) error {
+	if err != nil {
+		errorMessage := GetMessageFromError(err)
+		if uErr := UpdateVGSCStatusConditions(ctx, client, vgsc, logger, GenerateFailureConditions(err, conditionType, reason)...); uErr != nil {
+			return uErr
+		}
+		if uErr := createNamespacedObjectErrorEvent(ctx, logger, client, vgsc, errorMessage, reason); uErr != nil {
+			return uErr
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AddPVCIndexers indexes the persistentVolumeClaims of the manager cache by namespace plus label
+// and by storage class, so the members of a volumeGroup are found without listing the whole cluster.
+func AddPVCIndexers(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &corev1.PersistentVolumeClaim{},
+		PVCNamespaceLabelIndex, IndexPVCByNamespaceLabel); err != nil {
+		return err
+	}
+	return mgr.GetFieldIndexer().IndexField(context.TODO(), &corev1.PersistentVolumeClaim{},
+		PVCStorageClassIndex, IndexPVCByStorageClass)
+}
+
+// AddVGCIndexers indexes the volumeGroupContents of the manager cache by the volume handles of their persistentVolumes.
+func AddVGCIndexers(mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(context.TODO(), &volumegroupv1.VolumeGroupContent{},
+		VGCVolumeHandleIndex, IndexVGCByVolumeHandle)
+}
+
+// AddVGIndexers indexes the volumeGroups of the manager cache by the volumeGroupSnapshot they are restored from.
+func AddVGIndexers(mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(context.TODO(), &volumegroupv1.VolumeGroup{},
+		VGDataSourceIndex, IndexVGByDataSource)
+}
+
+func IndexVGByDataSource(obj runtimeclient.Object) []string {
+	dataSource := obj.(*volumegroupv1.VolumeGroup).Spec.Source.DataSource
+	if dataSource == nil {
+		return nil
+	}
+	return []string{dataSource.Name}
+}
+
+// AddVGSIndexers indexes the volumeGroupSnapshots of the manager cache by the volumeGroup they snapshot.
+func AddVGSIndexers(mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(context.TODO(), &volumegroupv1.VolumeGroupSnapshot{},
+		VGSVolumeGroupIndex, IndexVGSByVolumeGroup)
+}
+
+func IndexVGSByVolumeGroup(obj runtimeclient.Object) []string {
+	vgName := GetStringField(obj.(*volumegroupv1.VolumeGroupSnapshot).Spec.Source, "VolumeGroupName")
+	if vgName == "" {
+		return nil
+	}
+	return []string{vgName}
+}
+
+func IndexVGCByVolumeHandle(obj runtimeclient.Object) []string {
+	return GetVolumeIdsFromMembers(obj.(*volumegroupv1.VolumeGroupContent).Status.Members)
+}
+
+func IndexPVCByNamespaceLabel(obj runtimeclient.Object) []string {
+	var values []string
+	for key, value := range obj.GetLabels() {
+		values = append(values, getNamespaceLabelIndexValue(obj.GetNamespace(), key, value))
+	}
+	return values
+}
+
+// IndexPVCByStorageClass indexes a persistentVolumeClaim by its storageClass name, the name never changes so the index
+// stays valid when the storageClass is created after the persistentVolumeClaim. The provisioner of the storageClass is
+// resolved from the storageClass cache when the persistentVolumeClaims of a driver are listed.
+func IndexPVCByStorageClass(obj runtimeclient.Object) []string {
+	storageClassName, err := GetPVCClass(obj.(*corev1.PersistentVolumeClaim))
+	if err != nil || storageClassName == "" {
+		return nil
+	}
+	return []string{storageClassName}
+}
+
+func getNamespaceLabelIndexValue(namespace, key, value string) string {
+	return fmt.Sprintf("%s/%s=%s", namespace, key, value)
+}
+
+// GetVGCandidatePVCs returns the bound persistentVolumeClaims of the driver in the namespaces of the volumeGroup
+// that carry one of its match labels. The caller still checks the whole selector.
+func GetVGCandidatePVCs(ctx context.Context, logger logr.Logger, client runtimeclient.Client, vg *volumegroupv1.VolumeGroup,
+	driver string) ([]corev1.PersistentVolumeClaim, error) {
+	if vg.Spec.Source.Selector == nil {
+		return nil, nil
+	}
+	namespaces, err := getVGNamespaces(ctx, logger, client, vg)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []corev1.PersistentVolumeClaim
+	for _, namespace := range namespaces {
+		pvcList, err := listNamespacePVCsBySelector(ctx, logger, client, namespace, vg.Spec.Source.Selector)
+		if err != nil {
+			return nil, err
+		}
+		provisionedPVCList, err := getProvisionedPVCList(ctx, logger, client, driver, pvcList)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, provisionedPVCList.Items...)
+	}
+	return candidates, nil
+}
+
+func getVGNamespaces(ctx context.Context, logger logr.Logger, client runtimeclient.Client, vg *volumegroupv1.VolumeGroup) ([]string, error) {
+	if vg.Spec.Source.NamespaceSelector == nil {
+		return []string{vg.Namespace}, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(vg.Spec.Source.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	namespaceList := &corev1.NamespaceList{}
+	if err = client.List(ctx, namespaceList, runtimeclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.Error(err, messages.FailedToListNamespaces)
+		return nil, err
+	}
+	namespaces := []string{vg.Namespace}
+	for _, namespace := range namespaceList.Items {
+		if namespace.Name != vg.Namespace {
+			namespaces = append(namespaces, namespace.Name)
+		}
+	}
+	return namespaces, nil
+}
+
+func listNamespacePVCsBySelector(ctx context.Context, logger logr.Logger, client runtimeclient.Client, namespace string,
+	selector *metav1.LabelSelector) (corev1.PersistentVolumeClaimList, error) {
+	logger.Info(fmt.Sprintf(messages.ListNamespacePVCs, namespace))
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	listOptions := []runtimeclient.ListOption{runtimeclient.InNamespace(namespace)}
+	if len(selector.MatchLabels) > 0 {
+		keys := make([]string, 0, len(selector.MatchLabels))
+		for key := range selector.MatchLabels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		listOptions = []runtimeclient.ListOption{runtimeclient.MatchingFields{
+			PVCNamespaceLabelIndex: getNamespaceLabelIndexValue(namespace, keys[0], selector.MatchLabels[keys[0]]),
+		}}
+	}
+	if err := client.List(ctx, pvcList, listOptions...); err != nil {
+		logger.Error(err, messages.FailedToListPVC)
+		return corev1.PersistentVolumeClaimList{}, err
+	}
+	return getBoundPVCList(*pvcList)
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroup"
+	vgerrors "github.com/IBM/csi-volume-group-operator/pkg/errors"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GenerateVGMember returns the member reference of a persistentVolumeClaim, the persistentVolume may be nil.
+func GenerateVGMember(pvc *corev1.PersistentVolumeClaim, pv *corev1.PersistentVolume) volumegroupv1.VolumeGroupMemberReference {
+	member := volumegroupv1.VolumeGroupMemberReference{
+		Name:             pvc.Name,
+		Namespace:        pvc.Namespace,
+		UID:              pvc.UID,
+		StorageClassName: GetStringField(pvc.Spec, "StorageClassName"),
+		State:            volumegroupv1.MemberJoined,
+	}
+	if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		member.Capacity = &capacity
+	}
+	if pv != nil && pv.Spec.CSI != nil {
+		member.VolumeHandle = pv.Spec.CSI.VolumeHandle
+	}
+	return member
+}
+
+// GenerateVGCMember returns the member reference of a persistentVolume.
+func GenerateVGCMember(pv *corev1.PersistentVolume) volumegroupv1.VolumeGroupMemberReference {
+	member := volumegroupv1.VolumeGroupMemberReference{
+		Name:             pv.Name,
+		UID:              pv.UID,
+		StorageClassName: pv.Spec.StorageClassName,
+		State:            volumegroupv1.MemberJoined,
+	}
+	if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+		member.Capacity = &capacity
+	}
+	if pv.Spec.CSI != nil {
+		member.VolumeHandle = pv.Spec.CSI.VolumeHandle
+	}
+	if pv.Spec.ClaimRef != nil {
+		member.ClaimRef = &volumegroupv1.VolumeGroupMemberClaimReference{
+			Name:      pv.Spec.ClaimRef.Name,
+			Namespace: pv.Spec.ClaimRef.Namespace,
+			UID:       pv.Spec.ClaimRef.UID,
+		}
+	}
+	return member
+}
+
+// generateVGMembers returns the member references of the persistentVolumeClaims with the volume handles of their persistentVolumes.
+func generateVGMembers(ctx context.Context, logger logr.Logger, client client.Client,
+	pvcs []corev1.PersistentVolumeClaim) ([]volumegroupv1.VolumeGroupMemberReference, error) {
+	members := []volumegroupv1.VolumeGroupMemberReference{}
+	for _, pvc := range pvcs {
+		pv, err := GetPVFromPVC(ctx, logger, client, &pvc)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, GenerateVGMember(&pvc, pv))
+	}
+	return members, nil
+}
+
+func appendMember(members []volumegroupv1.VolumeGroupMemberReference,
+	member volumegroupv1.VolumeGroupMemberReference) []volumegroupv1.VolumeGroupMemberReference {
+	for _, memberFromList := range members {
+		if memberFromList.Name == member.Name && memberFromList.Namespace == member.Namespace {
+			return members
+		}
+	}
+	return append(members, member)
+}
+
+func upsertMember(members []volumegroupv1.VolumeGroupMemberReference,
+	member volumegroupv1.VolumeGroupMemberReference) []volumegroupv1.VolumeGroupMemberReference {
+	newMembers := removeMember(members, member.Namespace, member.Name)
+	return append(newMembers, member)
+}
+
+func removeMember(members []volumegroupv1.VolumeGroupMemberReference,
+	namespace, name string) []volumegroupv1.VolumeGroupMemberReference {
+	newMembers := []volumegroupv1.VolumeGroupMemberReference{}
+	for _, member := range members {
+		if member.Name != name || member.Namespace != namespace {
+			newMembers = append(newMembers, member)
+		}
+	}
+	return newMembers
+}
+
+func IsPVCInMembers(pvc *corev1.PersistentVolumeClaim, members []volumegroupv1.VolumeGroupMemberReference) bool {
+	for _, member := range members {
+		if member.Name == pvc.Name && member.Namespace == pvc.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func isPVCJoined(pvc *corev1.PersistentVolumeClaim, members []volumegroupv1.VolumeGroupMemberReference) bool {
+	for _, member := range members {
+		if member.Name == pvc.Name && member.Namespace == pvc.Namespace {
+			return isMemberJoined(member)
+		}
+	}
+	return false
+}
+
+// isMemberJoined returns whether the member is in the group on the storage, members added before
+// the member states were introduced have no state.
+func isMemberJoined(member volumegroupv1.VolumeGroupMemberReference) bool {
+	return member.State == volumegroupv1.MemberJoined || member.State == ""
+}
+
+// AreVGMembersJoined returns whether all the members are in the group on the storage.
+func AreVGMembersJoined(members []volumegroupv1.VolumeGroupMemberReference) bool {
+	for _, member := range members {
+		if !isMemberJoined(member) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetVGMembersIntent records the desired membership of the volumeGroup before it is modified on the storage,
+// the persistentVolumeClaims that should join it are pending and the members that should leave it are leaving.
+func SetVGMembersIntent(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+	matchingPvcs []corev1.PersistentVolumeClaim) error {
+	members, err := GetVGMembers(ctx, logger, client, vg)
+	if err != nil {
+		return err
+	}
+	desiredMembers, err := generateVGMembers(ctx, logger, client, matchingPvcs)
+	if err != nil {
+		return err
+	}
+	intentMembers := []volumegroupv1.VolumeGroupMemberReference{}
+	for _, member := range members {
+		if !isMemberInPVCList(member, matchingPvcs) {
+			member.State = volumegroupv1.MemberLeaving
+		} else if !isMemberJoined(member) {
+			member.State = volumegroupv1.MemberPending
+		}
+		member.Reason = ""
+		intentMembers = append(intentMembers, member)
+	}
+	for _, member := range desiredMembers {
+		if !isMemberInMembers(member, intentMembers) {
+			member.State = volumegroupv1.MemberPending
+			intentMembers = append(intentMembers, member)
+		}
+	}
+	logger.Info(fmt.Sprintf(messages.SetVGMembersIntent, vg.Namespace, vg.Name))
+	return setVGMembers(ctx, logger, client, vg, members, intentMembers, "")
+}
+
+// IsVGModificationInProgress returns whether a failed membership modification may still be running on the storage,
+// its outcome is unknown so the members are not rolled back.
+func IsVGModificationInProgress(err error) bool {
+	resp := &volumegroup.Response{Error: err}
+	return resp.IsInProgress()
+}
+
+// RollbackVGMembers rolls the members back to their previous observed state after a failed membership modification,
+// the pending members failed to join the group and the leaving members are still joined.
+func RollbackVGMembers(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup, modifyErr error) error {
+	members, err := GetVGMembers(ctx, logger, client, vg)
+	if err != nil {
+		return err
+	}
+	rolledBackMembers := []volumegroupv1.VolumeGroupMemberReference{}
+	for _, member := range members {
+		switch member.State {
+		case volumegroupv1.MemberPending:
+			member.State = volumegroupv1.MemberFailed
+			member.Reason = volumegroupv1.ReasonModifyFailed
+		case volumegroupv1.MemberLeaving:
+			member.State = volumegroupv1.MemberJoined
+			member.Reason = volumegroupv1.ReasonModifyFailed
+		}
+		rolledBackMembers = append(rolledBackMembers, member)
+	}
+	logger.Info(fmt.Sprintf(messages.RollbackVGMembers, vg.Namespace, vg.Name))
+	return setVGMembers(ctx, logger, client, vg, members, rolledBackMembers, GetMessageFromError(modifyErr))
+}
+
+func isMemberInMembers(member volumegroupv1.VolumeGroupMemberReference, members []volumegroupv1.VolumeGroupMemberReference) bool {
+	for _, memberFromList := range members {
+		if memberFromList.Name == member.Name && memberFromList.Namespace == member.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func isMemberInPVCList(member volumegroupv1.VolumeGroupMemberReference, pvcList []corev1.PersistentVolumeClaim) bool {
+	for _, pvc := range pvcList {
+		if pvc.Name == member.Name && pvc.Namespace == member.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// GetVGCMemberOfClaim returns the persistentVolume member of the volumeGroupContent that holds the volume of
+// a persistentVolumeClaim member, by its volume handle or by its claim.
+func GetVGCMemberOfClaim(vgc *volumegroupv1.VolumeGroupContent,
+	pvcMember volumegroupv1.VolumeGroupMemberReference) *volumegroupv1.VolumeGroupMemberReference {
+	for i, member := range vgc.Status.Members {
+		if pvcMember.VolumeHandle != "" && member.VolumeHandle == pvcMember.VolumeHandle {
+			return &vgc.Status.Members[i]
+		}
+		if member.ClaimRef != nil && member.ClaimRef.Name == pvcMember.Name && member.ClaimRef.Namespace == pvcMember.Namespace {
+			return &vgc.Status.Members[i]
+		}
+	}
+	return nil
+}
+
+func GetVolumeIdsFromMembers(members []volumegroupv1.VolumeGroupMemberReference) []string {
+	volumeIds := []string{}
+	for _, member := range members {
+		if member.VolumeHandle != "" {
+			volumeIds = append(volumeIds, member.VolumeHandle)
+		}
+	}
+	return volumeIds
+}
+
+// MigrateVGMembers moves the persistentVolumeClaims embedded in the deprecated pvcList of the volumeGroup status to members.
+// The members are then moved to volumeGroupMembers or back to the status according to the volumeGroupClass.
+func MigrateVGMembers(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup) error {
+	if len(vg.Status.PVCList) == 0 {
+		return moveVGMembers(ctx, logger, client, vg)
+	}
+	logger.Info(fmt.Sprintf(messages.MigrateVGMembers, vg.Namespace, vg.Name))
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		members := vg.Status.Members
+		for _, pvc := range vg.Status.PVCList {
+			pv, err := GetPVFromPVC(ctx, logger, client, &pvc)
+			if err != nil {
+				var pvDoesNotExist *vgerrors.PVDoesNotExist
+				if !errors.As(err, &pvDoesNotExist) {
+					return err
+				}
+			}
+			members = appendMember(members, GenerateVGMember(&pvc, pv))
+		}
+		vg.Status.Members = members
+		vg.Status.PVCList = nil
+		return vgRetryOnConflictFunc(ctx, client, vg, logger)
+	})
+	if err != nil {
+		return err
+	}
+	return moveVGMembers(ctx, logger, client, vg)
+}
+
+// MigrateVGCMembers moves the persistentVolumes embedded in the deprecated pvList of the volumeGroupContent status to members.
+func MigrateVGCMembers(ctx context.Context, logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent) error {
+	if len(vgc.Status.PVList) == 0 {
+		return nil
+	}
+	logger.Info(fmt.Sprintf(messages.MigrateVGCMembers, vgc.Namespace, vgc.Name))
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		members := vgc.Status.Members
+		for _, pv := range vgc.Status.PVList {
+			members = appendMember(members, GenerateVGCMember(&pv))
+		}
+		vgc.Status.Members = members
+		vgc.Status.PVList = nil
+		return vgcRetryOnConflictFunc(ctx, client, vgc, logger)
+	})
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetVGMembershipBatchWindow returns the time membership changes of the volumeGroup are coalesced for.
+func GetVGMembershipBatchWindow(vg *volumegroupv1.VolumeGroup, defaultWindow time.Duration) time.Duration {
+	if vg.Spec.MembershipBatchWindow != nil {
+		return vg.Spec.MembershipBatchWindow.Duration
+	}
+	return defaultWindow
+}
+
+// CountVGMembershipChanges returns the number of persistentVolumeClaims that should join the volumeGroup
+// and of joined members that should leave it.
+func CountVGMembershipChanges(matchingPvcs []corev1.PersistentVolumeClaim,
+	members []volumegroupv1.VolumeGroupMemberReference) int {
+	changes := 0
+	for _, pvc := range matchingPvcs {
+		if !isPVCJoined(&pvc, members) {
+			changes++
+		}
+	}
+	for _, member := range members {
+		if isMemberJoined(member) && !isMemberInPVCList(member, matchingPvcs) {
+			changes++
+		}
+	}
+	return changes
+}
+
+// ScheduleVGMembershipBatch coalesces the membership changes of the volumeGroup and returns the time left until
+// they are flushed, 0 means they are flushed now. A batch that reaches maxSize changes is flushed at once.
+func ScheduleVGMembershipBatch(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+	changes int, window time.Duration, maxSize int) (time.Duration, error) {
+	if window <= 0 || (maxSize > 0 && changes >= maxSize) {
+		return 0, nil
+	}
+	flushTime := vg.Status.MembershipBatchFlushTime
+	if flushTime == nil {
+		newFlushTime := metav1.NewTime(time.Now().Add(window))
+		flushTime = &newFlushTime
+		logger.Info(fmt.Sprintf(messages.ScheduleVGMembershipBatch, changes, vg.Namespace, vg.Name, flushTime.Format(time.RFC3339)))
+	}
+	remaining := time.Until(flushTime.Time)
+	if remaining <= 0 {
+		return 0, nil
+	}
+	if err := updateVGMembershipBatch(ctx, client, vg, logger, flushTime, int32(changes)); err != nil {
+		return 0, err
+	}
+	return remaining, UpdateVGStatusConditions(ctx, client, vg, logger, GenerateCondition(
+		volumegroupv1.ConditionMembershipSynced, metav1.ConditionFalse, volumegroupv1.ReasonPending,
+		fmt.Sprintf(messages.VGMembershipBatchIsPending, changes, vg.Namespace, vg.Name, flushTime.Format(time.RFC3339))))
+}
+
+// ClearVGMembershipBatch clears the pending membership batch of the volumeGroup once it is flushed.
+func ClearVGMembershipBatch(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup) error {
+	if vg.Status.MembershipBatchFlushTime == nil && vg.Status.PendingMembershipChanges == 0 {
+		return nil
+	}
+	return updateVGMembershipBatch(ctx, client, vg, logger, nil, 0)
+}
+
+// GetVGMembershipBatch returns the persistentVolumeClaims of the volumeGroup after at most maxSize of its membership
+// changes are applied, and whether changes are left for the next batch. A deferred leaving member is kept in the batch.
+func GetVGMembershipBatch(ctx context.Context, logger logr.Logger, client client.Client, matchingPvcs []corev1.PersistentVolumeClaim,
+	members []volumegroupv1.VolumeGroupMemberReference, maxSize int) ([]corev1.PersistentVolumeClaim, bool, error) {
+	if maxSize <= 0 {
+		return matchingPvcs, false, nil
+	}
+	batch := []corev1.PersistentVolumeClaim{}
+	changes := 0
+	isTruncated := false
+	for _, pvc := range matchingPvcs {
+		if isPVCJoined(&pvc, members) {
+			batch = append(batch, pvc)
+		} else if changes < maxSize {
+			batch = append(batch, pvc)
+			changes++
+		} else {
+			isTruncated = true
+		}
+	}
+	for _, member := range members {
+		if !isMemberJoined(member) || isMemberInPVCList(member, matchingPvcs) {
+			continue
+		}
+		if changes < maxSize {
+			changes++
+			continue
+		}
+		pvc, err := GetPVC(ctx, logger, client, member.Name, member.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, false, err
+		}
+		batch = append(batch, *pvc)
+		isTruncated = true
+	}
+	if isTruncated {
+		logger.Info(fmt.Sprintf(messages.VGMembershipBatchIsTruncated, maxSize))
+	}
+	return batch, isTruncated, nil
+}
+
+func updateVGMembershipBatch(ctx context.Context, client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger,
+	flushTime *metav1.Time, pendingChanges int32) error {
+	if vg.Status.MembershipBatchFlushTime.Equal(flushTime) && vg.Status.PendingMembershipChanges == pendingChanges {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vg.Status.MembershipBatchFlushTime = flushTime
+		vg.Status.PendingMembershipChanges = pendingChanges
+		return vgRetryOnConflictFunc(ctx, client, vg, logger)
+	})
+}
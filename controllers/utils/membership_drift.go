@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetVGCMembershipDrift returns the volumes of the volumeGroupContent that are missing from the
+// volume group on the storage system and the volumes on the storage system that are not in it.
+func GetVGCMembershipDrift(vgc *volumegroupv1.VolumeGroupContent,
+	resp *csi.ControllerGetVolumeGroupResponse) (missingVolumeIds, unexpectedVolumeIds []string) {
+	backendVolumeIds := map[string]bool{}
+	for _, volume := range resp.GetVolumeGroup().GetVolumes() {
+		backendVolumeIds[volume.GetVolumeId()] = true
+	}
+	vgcVolumeIds := map[string]bool{}
+	for _, volumeId := range GetVolumeIdsFromVGC(vgc) {
+		vgcVolumeIds[volumeId] = true
+		if !backendVolumeIds[volumeId] {
+			missingVolumeIds = append(missingVolumeIds, volumeId)
+		}
+	}
+	for _, volume := range resp.GetVolumeGroup().GetVolumes() {
+		if !vgcVolumeIds[volume.GetVolumeId()] {
+			unexpectedVolumeIds = append(unexpectedVolumeIds, volume.GetVolumeId())
+		}
+	}
+	return missingVolumeIds, unexpectedVolumeIds
+}
+
+func IsMembershipDriftRemediated(vgClass *volumegroupv1.VolumeGroupClass) bool {
+	return vgClass.MembershipDriftPolicy != nil && *vgClass.MembershipDriftPolicy == volumegroupv1.MembershipDriftRemediate
+}
+
+func CreateVGCMembershipDriftEvent(ctx context.Context, logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent,
+	message string) error {
+	vgc.APIVersion = APIVersion
+	vgc.Kind = vgcKind
+	return createNamespacedObjectErrorEvent(ctx, logger, client, vgc, message, membershipDrift)
+}
+
+func CreateVGCMembershipRemediatedEvent(ctx context.Context, logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent) error {
+	vgc.APIVersion = APIVersion
+	vgc.Kind = vgcKind
+	message := fmt.Sprintf(messages.VGCMembershipDriftRemediated, vgc.Namespace, vgc.Name)
+	return createSuccessNamespacedObjectEvent(ctx, logger, client, vgc, message, remediateMembershipDrift)
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SetVGMetrics sets the state and the number of members of the volumeGroup from its status.
+func SetVGMetrics(vg *volumegroupv1.VolumeGroup) {
+	members := len(vg.Status.Members)
+	if vg.Status.MemberCounts != nil {
+		members = int(vg.Status.MemberCounts.Total)
+	}
+	metrics.SetVGMetrics(vg.Namespace, vg.Name, getVGState(vg), members)
+}
+
+func getVGState(vg *volumegroupv1.VolumeGroup) string {
+	if !vg.GetDeletionTimestamp().IsZero() {
+		return metrics.VGStateDeleting
+	}
+	ready := meta.FindStatusCondition(vg.Status.Conditions, volumegroupv1.ConditionReady)
+	switch {
+	case ready == nil:
+		return metrics.VGStatePending
+	case ready.Status == metav1.ConditionTrue:
+		return metrics.VGStateReady
+	case ready.Reason == volumegroupv1.ReasonPending || ready.Reason == volumegroupv1.ReasonInProgress:
+		return metrics.VGStatePending
+	default:
+		return metrics.VGStateFailed
+	}
+}
+
+func recordFinalizerBlockedDeletion(object runtimeclient.Object) {
+	metrics.FinalizerBlockedDeletionsTotal.WithLabelValues(reflect.Indirect(reflect.ValueOf(object)).Type().Name()).Inc()
+}
@@ -17,44 +17,45 @@ limitations under the License.
 package utils
 
 import (
+	"context"
 	"fmt"
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
 	"github.com/IBM/csi-volume-group-operator/controllers/volumegroup"
 	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func ModifyVG(logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+func ModifyVG(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
 	vgClient grpcClient.VolumeGroup) error {
-	params, err := generateModifyVGParams(logger, client, vg, vgClient)
+	params, err := generateModifyVGParams(ctx, logger, client, vg, vgClient)
 	if err != nil {
 		return err
 	}
 	logger.Info(fmt.Sprintf(messages.ModifyVG, params.VolumeGroupID, params.VolumeIds))
 	volumeGroupRequest := volumegroup.NewVolumeGroupRequest(params)
-	modifyVGResponse := volumeGroupRequest.Modify()
+	modifyVGResponse := volumeGroupRequest.Modify(ctx)
 	responseError := modifyVGResponse.Error
 	if responseError != nil {
 		logger.Error(responseError, fmt.Sprintf(messages.FailedToModifyVG, vg.Namespace, vg.Name))
+		metrics.MembershipOperationsTotal.WithLabelValues(vg.Namespace, vg.Name, metrics.ResultFailure).Inc()
 		return responseError
 	}
+	metrics.MembershipOperationsTotal.WithLabelValues(vg.Namespace, vg.Name, metrics.ResultSuccess).Inc()
 	logger.Info(fmt.Sprintf(messages.ModifiedVG, params.VolumeGroupID))
 	return nil
 }
-func generateModifyVGParams(logger logr.Logger, client client.Client,
+func generateModifyVGParams(ctx context.Context, logger logr.Logger, client client.Client,
 	vg *volumegroupv1.VolumeGroup, vgClient grpcClient.VolumeGroup) (volumegroup.CommonRequestParameters, error) {
-	vgId, err := getVgId(logger, client, vg)
+	vgId, err := getVgId(ctx, logger, client, vg)
 	if err != nil {
 		return volumegroup.CommonRequestParameters{}, err
 	}
-	volumeIds, err := getPVCListVolumeIds(logger, client, vg.Status.PVCList)
-	if err != nil {
-		return volumegroup.CommonRequestParameters{}, err
-	}
-	secrets, err := getSecrets(logger, client, vg)
+	volumeIds := GetVolumeIdsFromMembers(vg.Status.Members)
+	secrets, err := getSecrets(ctx, logger, client, vg)
 	if err != nil {
 		return volumegroup.CommonRequestParameters{}, err
 	}
@@ -66,16 +67,13 @@ func generateModifyVGParams(logger logr.Logger, client client.Client,
 		VolumeIds:     volumeIds,
 	}, nil
 }
-func getSecrets(logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup) (map[string]string, error) {
-	vgc, err := GetVGClass(client, logger, GetStringField(vg.Spec, "VolumeGroupClassName"))
+func getSecrets(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup) (map[string]string, error) {
+	vgc, err := GetVGClass(ctx, client, logger, GetStringField(vg.Spec, "VolumeGroupClassName"))
 	if err != nil {
 		return nil, err
 	}
-	secrets, err := GetSecretDataFromClass(client, vgc, logger)
+	secrets, err := GetSecretDataFromClass(ctx, client, vgc, logger)
 	if err != nil {
-		if uErr := UpdateVGStatusError(client, vg, logger, err.Error()); uErr != nil {
-			return nil, err
-		}
 		return nil, err
 	}
 	return secrets, nil
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func ValidateVGNamespaceSelector(vg *volumegroupv1.VolumeGroup, vgClass *volumegroupv1.VolumeGroupClass) error {
+	if vg.Spec.Source.NamespaceSelector != nil && !GetBoolField(vgClass, "AllowNamespaceSelector") {
+		return fmt.Errorf(messages.NamespaceSelectorIsNotAllowed, vg.Namespace, vg.Name, vgClass.Name)
+	}
+	return nil
+}
+
+// pvcNamespaceResolver matches the namespace of a persistentVolumeClaim against the namespaceSelectors of volumeGroups,
+// it gets the namespace and every volumeGroupClass at most once when the persistentVolumeClaim is matched against many volumeGroups.
+type pvcNamespaceResolver struct {
+	ctx       context.Context
+	logger    logr.Logger
+	client    client.Client
+	pvc       *corev1.PersistentVolumeClaim
+	namespace *corev1.Namespace
+	vgClasses map[string]*volumegroupv1.VolumeGroupClass
+}
+
+func newPVCNamespaceResolver(ctx context.Context, logger logr.Logger, client client.Client,
+	pvc *corev1.PersistentVolumeClaim) *pvcNamespaceResolver {
+	return &pvcNamespaceResolver{ctx: ctx, logger: logger, client: client, pvc: pvc,
+		vgClasses: map[string]*volumegroupv1.VolumeGroupClass{}}
+}
+
+func (r *pvcNamespaceResolver) isPVCNamespaceMatchesVG(vg volumegroupv1.VolumeGroup) (bool, error) {
+	if r.pvc.Namespace == vg.Namespace {
+		return true, nil
+	}
+	if vg.Spec.Source.NamespaceSelector == nil {
+		return false, nil
+	}
+	vgClass, err := r.getVGClass(GetStringField(vg.Spec, "VolumeGroupClassName"))
+	if err != nil {
+		return false, err
+	}
+	if err = ValidateVGNamespaceSelector(&vg, vgClass); err != nil {
+		r.logger.Info(err.Error())
+		return false, nil
+	}
+	if r.namespace == nil {
+		if r.namespace, err = getNamespace(r.ctx, r.logger, r.client, r.pvc.Namespace); err != nil {
+			return false, err
+		}
+	}
+	return areLabelsMatchLabelSelector(r.namespace.Labels, *vg.Spec.Source.NamespaceSelector)
+}
+
+func (r *pvcNamespaceResolver) getVGClass(vgClassName string) (*volumegroupv1.VolumeGroupClass, error) {
+	if vgClass, ok := r.vgClasses[vgClassName]; ok {
+		return vgClass, nil
+	}
+	vgClass, err := GetVGClass(r.ctx, r.client, r.logger, vgClassName)
+	if err != nil {
+		return nil, err
+	}
+	r.vgClasses[vgClassName] = vgClass
+	return vgClass, nil
+}
+
+func getNamespace(ctx context.Context, logger logr.Logger, client client.Client, name string) (*corev1.Namespace, error) {
+	logger.Info(fmt.Sprintf(messages.GetNamespace, name))
+	namespace := &corev1.Namespace{}
+	err := client.Get(ctx, types.NamespacedName{Name: name}, namespace)
+	if err != nil {
+		logger.Error(err, fmt.Sprintf(messages.FailedToGetNamespace, name))
+		return nil, err
+	}
+	return namespace, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetOrphanedVGs returns the volume groups on the storage that the operator created for the volumeGroupClass and recorded
+// in its status, but whose volumeGroupContent was deleted before it recorded their handle.
+// Volume groups that were never recorded are never returned: they may belong to another cluster that uses the same array,
+// or were released by a volumeGroupContent with a Retain deletion policy.
+// The recorded volume groups that are referenced by a volumeGroupContent or are gone from the storage are forgotten.
+func GetOrphanedVGs(ctx context.Context, logger logr.Logger, client client.Client, vgClass *volumegroupv1.VolumeGroupClass,
+	volumeGroups []*csi.VolumeGroup) ([]*csi.VolumeGroup, error) {
+	if len(vgClass.Status.CreatedVolumeGroups) == 0 {
+		return nil, nil
+	}
+	vgcList := &volumegroupv1.VolumeGroupContentList{}
+	if err := client.List(ctx, vgcList); err != nil {
+		logger.Error(err, messages.FailedToListVGC)
+		return nil, err
+	}
+	vgcsByHandle := getVGCsByHandle(vgcList.Items, vgClass.Driver)
+	vgcUIDs := map[types.UID]bool{}
+	for _, vgc := range vgcList.Items {
+		vgcUIDs[vgc.UID] = true
+	}
+	volumeGroupsById := map[string]*csi.VolumeGroup{}
+	for _, volumeGroup := range volumeGroups {
+		volumeGroupsById[volumeGroup.GetVolumeGroupId()] = volumeGroup
+	}
+
+	var orphanedVolumeGroups []*csi.VolumeGroup
+	var forgottenVGIds []string
+	for _, createdVG := range vgClass.Status.CreatedVolumeGroups {
+		vgId := createdVG.VolumeGroupHandle
+		volumeGroup, isOnStorage := volumeGroupsById[vgId]
+		if _, isReferenced := vgcsByHandle[vgId]; isReferenced || !isOnStorage {
+			forgottenVGIds = append(forgottenVGIds, vgId)
+			continue
+		}
+		if !vgcUIDs[createdVG.VolumeGroupContentRef.UID] {
+			orphanedVolumeGroups = append(orphanedVolumeGroups, volumeGroup)
+		}
+	}
+	if len(forgottenVGIds) > 0 {
+		if err := ForgetCreatedVGs(ctx, client, logger, vgClass, forgottenVGIds...); err != nil {
+			return nil, err
+		}
+	}
+	return orphanedVolumeGroups, nil
+}
+
+func GetVGClassList(ctx context.Context, logger logr.Logger, client client.Client, driver string) ([]volumegroupv1.VolumeGroupClass, error) {
+	vgClassList := &volumegroupv1.VolumeGroupClassList{}
+	if err := client.List(ctx, vgClassList); err != nil {
+		logger.Error(err, messages.FailedToListVGClass)
+		return nil, err
+	}
+	var vgClasses []volumegroupv1.VolumeGroupClass
+	for _, vgClass := range vgClassList.Items {
+		if vgClass.Driver == driver {
+			vgClasses = append(vgClasses, vgClass)
+		}
+	}
+	return vgClasses, nil
+}
+
+func CreateVGClassEvent(ctx context.Context, logger logr.Logger, client client.Client, vgClass *volumegroupv1.VolumeGroupClass,
+	message, reason string, isWarning bool) error {
+	vgClass.APIVersion = APIVersion
+	vgClass.Kind = vgClassKind
+	eventType := normalEventType
+	if isWarning {
+		eventType = warningEventType
+	}
+	event := generateEvent(vgClass, reason, message, eventType)
+	event.Namespace = metav1.NamespaceDefault
+	return createEvent(ctx, logger, client, event)
+}
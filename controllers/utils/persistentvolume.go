@@ -20,16 +20,19 @@ import (
 	"context"
 	"fmt"
 
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
 	vgerrors "github.com/IBM/csi-volume-group-operator/pkg/errors"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func GetPVFromPVC(logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolume, error) {
+func GetPVFromPVC(ctx context.Context, logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolume, error) {
 	logger.Info(fmt.Sprintf(messages.GetPVOfPVC, pvc.Namespace, pvc.Name))
 	pvName := getPVNameFromPVC(pvc)
 	if pvName == "" {
@@ -37,7 +40,7 @@ func GetPVFromPVC(logger logr.Logger, client client.Client, pvc *corev1.Persiste
 		return nil, nil
 	}
 
-	pv, err := getPV(logger, client, pvName)
+	pv, err := getPV(ctx, logger, client, pvName)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return nil, &vgerrors.PVDoesNotExist{pvName, pvc.Namespace, err.Error()}
@@ -51,14 +54,106 @@ func getPVNameFromPVC(pvc *corev1.PersistentVolumeClaim) string {
 	return GetStringField(pvc.Spec, "VolumeName")
 }
 
-func getPV(logger logr.Logger, client client.Client, pvName string) (*corev1.PersistentVolume, error) {
+func getPV(ctx context.Context, logger logr.Logger, client client.Client, pvName string) (*corev1.PersistentVolume, error) {
 	logger.Info(fmt.Sprintf(messages.GetPV, pvName))
 	pv := &corev1.PersistentVolume{}
 	namespacedPV := types.NamespacedName{Name: pvName}
-	err := client.Get(context.TODO(), namespacedPV, pv)
+	err := client.Get(ctx, namespacedPV, pv)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf(messages.FailedToGetPV, pvName))
 		return nil, err
 	}
 	return pv, nil
 }
+
+// RefreshVGCMembers updates the persistentVolume members in the volumeGroupContent status from the current objects
+// and returns the PVsBound condition. A deleted or rebound persistentVolume keeps its last known member, and a released
+// one stays a member, until their claim leaves the volumeGroup.
+func RefreshVGCMembers(ctx context.Context, logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent) (metav1.Condition, error) {
+	logger.Info(fmt.Sprintf(messages.RefreshVGCMembers, vgc.Namespace, vgc.Name))
+	var deletedPVs, reboundPVs, releasedPVs []string
+	members := make([]volumegroupv1.VolumeGroupMemberReference, 0, len(vgc.Status.Members))
+	for _, member := range vgc.Status.Members {
+		pv, err := getPV(ctx, logger, client, member.Name)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return metav1.Condition{}, err
+			}
+			deletedPVs = append(deletedPVs, member.Name)
+			members = append(members, member)
+			continue
+		}
+		if isPVRebound(member, pv) {
+			reboundPVs = append(reboundPVs, member.Name)
+			members = append(members, member)
+			continue
+		}
+		if isPVReleased(pv) {
+			releasedPVs = append(releasedPVs, member.Name)
+		}
+		currentMember := GenerateVGCMember(pv)
+		currentMember.State = member.State
+		if currentMember.ClaimRef == nil {
+			currentMember.ClaimRef = member.ClaimRef
+		}
+		members = append(members, currentMember)
+	}
+
+	if !equality.Semantic.DeepEqual(members, vgc.Status.Members) {
+		if err := updateVGCStatusMembers(ctx, client, vgc, logger, func(currentMembers []volumegroupv1.VolumeGroupMemberReference) []volumegroupv1.VolumeGroupMemberReference {
+			return refreshMembers(currentMembers, members)
+		}); err != nil {
+			return metav1.Condition{}, err
+		}
+	}
+	return generatePVsBoundCondition(vgc, deletedPVs, reboundPVs, releasedPVs), nil
+}
+
+// refreshMembers replaces the current members with their refreshed members, members that joined or left
+// since they were refreshed are kept as they are.
+func refreshMembers(currentMembers, refreshedMembers []volumegroupv1.VolumeGroupMemberReference) []volumegroupv1.VolumeGroupMemberReference {
+	refreshedMembersByName := make(map[string]volumegroupv1.VolumeGroupMemberReference, len(refreshedMembers))
+	for _, member := range refreshedMembers {
+		refreshedMembersByName[member.Name] = member
+	}
+	members := make([]volumegroupv1.VolumeGroupMemberReference, 0, len(currentMembers))
+	for _, member := range currentMembers {
+		if refreshedMember, ok := refreshedMembersByName[member.Name]; ok {
+			member = refreshedMember
+		}
+		members = append(members, member)
+	}
+	return members
+}
+
+func isPVRebound(member volumegroupv1.VolumeGroupMemberReference, pv *corev1.PersistentVolume) bool {
+	claimRef, currentClaimRef := member.ClaimRef, pv.Spec.ClaimRef
+	if claimRef == nil || currentClaimRef == nil {
+		return false
+	}
+	if claimRef.Namespace != currentClaimRef.Namespace || claimRef.Name != currentClaimRef.Name {
+		return true
+	}
+	return claimRef.UID != "" && currentClaimRef.UID != "" && claimRef.UID != currentClaimRef.UID
+}
+
+func isPVReleased(pv *corev1.PersistentVolume) bool {
+	return pv.Status.Phase == corev1.VolumeReleased || pv.Spec.ClaimRef == nil
+}
+
+func generatePVsBoundCondition(vgc *volumegroupv1.VolumeGroupContent, deletedPVs, reboundPVs,
+	releasedPVs []string) metav1.Condition {
+	var reason string
+	switch {
+	case len(deletedPVs) > 0:
+		reason = volumegroupv1.ReasonPVDeleted
+	case len(reboundPVs) > 0:
+		reason = volumegroupv1.ReasonPVRebound
+	case len(releasedPVs) > 0:
+		reason = volumegroupv1.ReasonPVReleased
+	default:
+		return GenerateCondition(volumegroupv1.ConditionPVsBound, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, "")
+	}
+	return GenerateCondition(volumegroupv1.ConditionPVsBound, metav1.ConditionFalse, reason,
+		fmt.Sprintf(messages.VGCPVsNotBound, vgc.Namespace, vgc.Name, deletedPVs, reboundPVs, releasedPVs))
+}
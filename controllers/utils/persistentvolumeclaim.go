@@ -22,6 +22,7 @@ import (
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -29,27 +30,15 @@ import (
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func getPVCListVolumeIds(logger logr.Logger, client runtimeclient.Client, pvcList []corev1.PersistentVolumeClaim) ([]string, error) {
-	volumeIds := []string{}
-	for _, pvc := range pvcList {
-		pv, err := GetPVFromPVC(logger, client, &pvc)
-		if err != nil {
-			return nil, err
-		}
-		if pv != nil {
-			volumeIds = append(volumeIds, string(pv.Spec.CSI.VolumeHandle))
-		}
-	}
-	return volumeIds, nil
-}
-
-func IsPVCCanBeAddedToVG(logger logr.Logger, pvc *corev1.PersistentVolumeClaim, vgs []volumegroupv1.VolumeGroup) error {
+func IsPVCCanBeAddedToVG(ctx context.Context, logger logr.Logger, client runtimeclient.Client, pvc *corev1.PersistentVolumeClaim,
+	vgs []volumegroupv1.VolumeGroup) error {
 	vgsWithPVC := []string{}
 	newVGsForPVC := []string{}
+	namespaceResolver := newPVCNamespaceResolver(ctx, logger, client, pvc)
 	for _, vg := range vgs {
-		if IsPVCInPVCList(pvc, vg.Status.PVCList) {
+		if isPVCInVG, _ := IsPVCInVG(ctx, logger, client, pvc, &vg); isPVCInVG {
 			vgsWithPVC = append(vgsWithPVC, vg.Name)
-		} else if isPVCMatchesVG, _ := IsPVCMatchesVG(logger, pvc, vg); isPVCMatchesVG {
+		} else if isPVCMatchesVG, _ := isPVCMatchesVG(logger, namespaceResolver, pvc, vg); isPVCMatchesVG {
 			newVGsForPVC = append(newVGsForPVC, vg.Name)
 		}
 	}
@@ -60,18 +49,20 @@ func checkIfPVCCanBeAddedToVG(logger logr.Logger, pvc *corev1.PersistentVolumeCl
 	if len(vgsWithPVC) > 0 && len(newVGsForPVC) > 0 {
 		message := fmt.Sprintf(messages.PVCIsAlreadyBelongToGroup, pvc.Namespace, pvc.Name, newVGsForPVC, vgsWithPVC)
 		logger.Info(message)
+		metrics.RejectedPVCsTotal.WithLabelValues(pvc.Namespace, metrics.RejectedPVCInOtherVG).Inc()
 		return fmt.Errorf(message)
 	}
 	if len(newVGsForPVC) > 1 {
 		message := fmt.Sprintf(messages.PVCMatchedWithMultipleNewGroups, pvc.Namespace, pvc.Name, newVGsForPVC)
 		logger.Info(message)
+		metrics.RejectedPVCsTotal.WithLabelValues(pvc.Namespace, metrics.RejectedPVCMatchesMultipleVGs).Inc()
 		return fmt.Errorf(message)
 	}
 	return nil
 }
 
-func IsPVCNeedToBeHandled(reqLogger logr.Logger, pvc *corev1.PersistentVolumeClaim, client runtimeclient.Client, driverName string) (bool, error) {
-	isPVCHasMatchingDriver, err := IsPVCHasMatchingDriver(reqLogger, client, pvc, driverName)
+func IsPVCNeedToBeHandled(ctx context.Context, reqLogger logr.Logger, pvc *corev1.PersistentVolumeClaim, client runtimeclient.Client, driverName string) (bool, error) {
+	isPVCHasMatchingDriver, err := IsPVCHasMatchingDriver(ctx, reqLogger, client, pvc, driverName)
 	if err != nil {
 		return false, err
 	}
@@ -82,7 +73,11 @@ func IsPVCNeedToBeHandled(reqLogger logr.Logger, pvc *corev1.PersistentVolumeCla
 		reqLogger.Info(messages.PVCIsNotInBoundPhase)
 		return false, nil
 	}
-	isSCHasVGParam, err := IsPVCInStaticVG(reqLogger, client, pvc)
+	if !pvc.GetDeletionTimestamp().IsZero() {
+		reqLogger.Info(fmt.Sprintf(messages.PVCIsBeingDeleted, pvc.Namespace, pvc.Name))
+		return false, nil
+	}
+	isSCHasVGParam, err := IsPVCInStaticVG(ctx, reqLogger, client, pvc)
 	if err != nil {
 		return false, err
 	}
@@ -93,8 +88,9 @@ func IsPVCNeedToBeHandled(reqLogger logr.Logger, pvc *corev1.PersistentVolumeCla
 		}
 		msg := fmt.Sprintf(messages.StorageClassHasVGParameter, storageClassName, pvc.Namespace, pvc.Name)
 		reqLogger.Info(msg)
+		metrics.RejectedPVCsTotal.WithLabelValues(pvc.Namespace, metrics.RejectedPVCInStaticVG).Inc()
 		mErr := fmt.Errorf(msg)
-		err = HandlePVCErrorMessage(reqLogger, client, pvc, mErr, addingPVC)
+		err = HandlePVCErrorMessage(ctx, reqLogger, client, pvc, mErr, addingPVC)
 		if err != nil {
 			return false, err
 		}
@@ -103,21 +99,21 @@ func IsPVCNeedToBeHandled(reqLogger logr.Logger, pvc *corev1.PersistentVolumeCla
 	return true, nil
 }
 
-func IsPVCInStaticVG(logger logr.Logger, client runtimeclient.Client, pvc *corev1.PersistentVolumeClaim) (bool, error) {
+func IsPVCInStaticVG(ctx context.Context, logger logr.Logger, client runtimeclient.Client, pvc *corev1.PersistentVolumeClaim) (bool, error) {
 	storageClassName, sErr := GetPVCClass(pvc)
 	if sErr != nil {
 		return false, sErr
 	}
-	sc, err := getStorageClass(logger, client, storageClassName)
+	sc, err := getStorageClass(ctx, logger, client, storageClassName)
 	if err != nil {
 		return false, err
 	}
 	return isSCHasParam(sc, storageClassVGParameter), nil
 }
 
-func getMatchingPVCFromPVCListToPV(logger logr.Logger, client runtimeclient.Client,
+func getMatchingPVCFromPVCListToPV(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
 	pvName, driver string) (corev1.PersistentVolumeClaim, error) {
-	pvcList, err := GetPVCList(logger, client, driver)
+	pvcList, err := GetPVCList(ctx, logger, client, driver)
 	if err != nil {
 		return corev1.PersistentVolumeClaim{}, err
 	}
@@ -130,8 +126,8 @@ func getMatchingPVCFromPVCListToPV(logger logr.Logger, client runtimeclient.Clie
 	return corev1.PersistentVolumeClaim{}, nil
 }
 
-func GetPVCList(logger logr.Logger, client runtimeclient.Client, driver string) (corev1.PersistentVolumeClaimList, error) {
-	pvcList, err := getPVCList(logger, client)
+func GetPVCList(ctx context.Context, logger logr.Logger, client runtimeclient.Client, driver string) (corev1.PersistentVolumeClaimList, error) {
+	pvcList, err := getPVCList(ctx, logger, client, driver)
 	if err != nil {
 		return corev1.PersistentVolumeClaimList{}, err
 	}
@@ -140,17 +136,26 @@ func GetPVCList(logger logr.Logger, client runtimeclient.Client, driver string)
 		return corev1.PersistentVolumeClaimList{}, err
 	}
 
-	return getProvisionedPVCList(logger, client, driver, boundPVCList)
+	return getProvisionedPVCList(ctx, logger, client, driver, boundPVCList)
 }
 
-func getPVCList(logger logr.Logger, client runtimeclient.Client) (corev1.PersistentVolumeClaimList, error) {
+// getPVCList lists the persistentVolumeClaims of the storageClasses of the driver through the storage class index.
+func getPVCList(ctx context.Context, logger logr.Logger, client runtimeclient.Client, driver string) (corev1.PersistentVolumeClaimList, error) {
 	logger.Info(messages.ListPVCs)
-	pvcList := &corev1.PersistentVolumeClaimList{}
-	if err := client.List(context.TODO(), pvcList); err != nil {
-		logger.Error(err, messages.FailedToListPVC)
+	scNames, err := getStorageClassNamesOfProvisioner(ctx, logger, client, driver)
+	if err != nil {
 		return corev1.PersistentVolumeClaimList{}, err
 	}
-	return *pvcList, nil
+	pvcList := corev1.PersistentVolumeClaimList{}
+	for _, scName := range scNames {
+		scPVCList := &corev1.PersistentVolumeClaimList{}
+		if err := client.List(ctx, scPVCList, runtimeclient.MatchingFields{PVCStorageClassIndex: scName}); err != nil {
+			logger.Error(err, messages.FailedToListPVC)
+			return corev1.PersistentVolumeClaimList{}, err
+		}
+		pvcList.Items = append(pvcList.Items, scPVCList.Items...)
+	}
+	return pvcList, nil
 }
 
 func getBoundPVCList(pvcList corev1.PersistentVolumeClaimList) (corev1.PersistentVolumeClaimList, error) {
@@ -163,11 +168,11 @@ func getBoundPVCList(pvcList corev1.PersistentVolumeClaimList) (corev1.Persisten
 	return newPVCList, nil
 }
 
-func getProvisionedPVCList(logger logr.Logger, client runtimeclient.Client, driver string,
+func getProvisionedPVCList(ctx context.Context, logger logr.Logger, client runtimeclient.Client, driver string,
 	pvcList corev1.PersistentVolumeClaimList) (corev1.PersistentVolumeClaimList, error) {
 	newPVCList := corev1.PersistentVolumeClaimList{}
 	for _, pvc := range pvcList.Items {
-		isPVCHasMatchingDriver, err := IsPVCHasMatchingDriver(logger, client, &pvc, driver)
+		isPVCHasMatchingDriver, err := IsPVCHasMatchingDriver(ctx, logger, client, &pvc, driver)
 		if err != nil {
 			return corev1.PersistentVolumeClaimList{}, err
 		}
@@ -178,52 +183,44 @@ func getProvisionedPVCList(logger logr.Logger, client runtimeclient.Client, driv
 	return newPVCList, nil
 }
 
-func IsPVCHasMatchingDriver(logger logr.Logger, client runtimeclient.Client,
+func IsPVCHasMatchingDriver(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
 	pvc *corev1.PersistentVolumeClaim, driver string) (bool, error) {
 	storageClassName, sErr := GetPVCClass(pvc)
 	if sErr != nil {
 		return false, sErr
 	}
-	scProvisioner, err := getStorageClassProvisioner(logger, client, storageClassName)
+	scProvisioner, err := getStorageClassProvisioner(ctx, logger, client, storageClassName)
 	if err != nil {
 		return false, err
 	}
 	return scProvisioner == driver, nil
 }
 
-func getPVCNameFromPV(pv corev1.PersistentVolume) string {
-	return GetStringField(pv.Spec.ClaimRef, "Name")
-}
-
-func getPVCNamespaceFromPV(pv corev1.PersistentVolume) string {
-	return GetStringField(pv.Spec.ClaimRef, "Namespace")
-}
-
-func deletePVC(logger logr.Logger, client runtimeclient.Client, name, namespace, driver string) error {
+func deletePVC(ctx context.Context, logger logr.Logger, client runtimeclient.Client, name, namespace, driver string) error {
 	logger.Info(fmt.Sprintf(messages.DeletePVC, namespace, name))
-	pvc, err := GetPVC(logger, client, name, namespace)
+	pvc, err := GetPVC(ctx, logger, client, name, namespace)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil
 		}
 		return err
 	}
-	err = RemoveFinalizerFromPVC(client, logger, driver, pvc)
+	err = RemoveFinalizerFromPVC(ctx, client, logger, driver, pvc)
 	if err != nil {
 		return err
 	}
-	err = removePVCObject(logger, client, pvc)
+	err = removePVCObject(ctx, logger, client, pvc)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func GetPVC(logger logr.Logger, client runtimeclient.Client, name, namespace string) (*corev1.PersistentVolumeClaim, error) {
+func GetPVC(ctx context.Context, logger logr.Logger, client runtimeclient.Client, name, namespace string) (*corev1.PersistentVolumeClaim, error) {
 	logger.Info(fmt.Sprintf(messages.GetPVC, namespace, name))
 	pvc := &corev1.PersistentVolumeClaim{}
 	namespacedPVC := types.NamespacedName{Name: name, Namespace: namespace}
-	err := client.Get(context.TODO(), namespacedPVC, pvc)
+	err := client.Get(ctx, namespacedPVC, pvc)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Error(err, fmt.Sprintf(messages.PVCNotFound, namespace, name))
@@ -235,8 +232,8 @@ func GetPVC(logger logr.Logger, client runtimeclient.Client, name, namespace str
 	return pvc, nil
 }
 
-func removePVCObject(logger logr.Logger, client runtimeclient.Client, pvc *corev1.PersistentVolumeClaim) error {
-	if err := client.Delete(context.TODO(), pvc); err != nil {
+func removePVCObject(ctx context.Context, logger logr.Logger, client runtimeclient.Client, pvc *corev1.PersistentVolumeClaim) error {
+	if err := client.Delete(ctx, pvc); err != nil {
 		logger.Error(err, fmt.Sprintf(messages.FailToRemovePVCObject, pvc.Namespace, pvc.Name))
 		return err
 	}
@@ -22,11 +22,13 @@ import (
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -36,11 +38,110 @@ var (
 		CreateFunc: func(e event.CreateEvent) bool {
 			return true
 		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isLabelsChanged(e.ObjectOld, e.ObjectNew) || isPhaseChanged(e.ObjectOld, e.ObjectNew) ||
+				isDeletionRequested(e.ObjectOld, e.ObjectNew)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	// VGCPredicate passes the volumeGroupContent events that its volumeGroup waits for.
+	VGCPredicate = predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isConditionsChanged(e.ObjectOld, e.ObjectNew) ||
+				e.ObjectOld.GetDeletionTimestamp().IsZero() != e.ObjectNew.GetDeletionTimestamp().IsZero()
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	// VGSCPredicate passes the volumeGroupSnapshotContent events that its volumeGroupSnapshot waits for.
+	VGSCPredicate = predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return IsVGSCReady(e.ObjectOld.(*volumegroupv1.VolumeGroupSnapshotContent)) !=
+				IsVGSCReady(e.ObjectNew.(*volumegroupv1.VolumeGroupSnapshotContent)) ||
+				e.ObjectOld.GetDeletionTimestamp().IsZero() != e.ObjectNew.GetDeletionTimestamp().IsZero()
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	// DataSourceVGSPredicate passes the volumeGroupSnapshot events that the volumeGroups restored from it wait for.
+	DataSourceVGSPredicate = predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			return false
 		},
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			return isLabelsChanged(e.ObjectOld, e.ObjectNew) || isPhaseChanged(e.ObjectOld, e.ObjectNew)
+			return meta.IsStatusConditionTrue(e.ObjectOld.(*volumegroupv1.VolumeGroupSnapshot).Status.Conditions, volumegroupv1.ConditionReady) !=
+				meta.IsStatusConditionTrue(e.ObjectNew.(*volumegroupv1.VolumeGroupSnapshot).Status.Conditions, volumegroupv1.ConditionReady)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	// SnapshotVGPredicate passes the volumeGroup events that bind it, which its volumeGroupSnapshots wait for.
+	SnapshotVGPredicate = predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return GetStringField(e.ObjectOld.(*volumegroupv1.VolumeGroup).Status, "BoundVolumeGroupContentName") !=
+				GetStringField(e.ObjectNew.(*volumegroupv1.VolumeGroup).Status, "BoundVolumeGroupContentName")
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	// PVPredicate passes the persistentVolume events that change the members of a volumeGroupContent.
+	PVPredicate = predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPV := e.ObjectOld.(*corev1.PersistentVolume)
+			newPV := e.ObjectNew.(*corev1.PersistentVolume)
+			return oldPV.Status.Phase != newPV.Status.Phase || !reflect.DeepEqual(oldPV.Spec.ClaimRef, newPV.Spec.ClaimRef) ||
+				isDeletionRequested(e.ObjectOld, e.ObjectNew)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	// NamespacePredicate passes the namespace events that can change the namespaces a namespaceSelector matches.
+	NamespacePredicate = predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return len(e.Object.GetLabels()) > 0
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
 		},
 		GenericFunc: func(e event.GenericEvent) bool {
 			return false
@@ -63,24 +164,175 @@ func isPhaseChanged(oldObject, newObject runtimeclient.Object) bool {
 		newObject.(*corev1.PersistentVolumeClaim).Status.Phase)
 }
 
+func isDeletionRequested(oldObject, newObject runtimeclient.Object) bool {
+	return oldObject.GetDeletionTimestamp().IsZero() && !newObject.GetDeletionTimestamp().IsZero()
+}
+
+func isConditionsChanged(oldObject, newObject runtimeclient.Object) bool {
+	oldConditions := oldObject.(*volumegroupv1.VolumeGroupContent).Status.Conditions
+	newConditions := newObject.(*volumegroupv1.VolumeGroupContent).Status.Conditions
+	if len(oldConditions) != len(newConditions) {
+		return true
+	}
+	for _, newCondition := range newConditions {
+		oldCondition := meta.FindStatusCondition(oldConditions, newCondition.Type)
+		if oldCondition == nil || oldCondition.Status != newCondition.Status || oldCondition.Reason != newCondition.Reason {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateVGCRequests maps a volumeGroupContent to the volumeGroup it is bound to.
+func CreateVGCRequests() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(
+		func(ctx context.Context, object runtimeclient.Object) []reconcile.Request {
+			vgc, ok := object.(*volumegroupv1.VolumeGroupContent)
+			if !ok || vgc.Spec.VolumeGroupRef == nil || vgc.Spec.VolumeGroupRef.Name == "" {
+				return []ctrl.Request{}
+			}
+			return []ctrl.Request{{
+				NamespacedName: types.NamespacedName{
+					Namespace: vgc.Spec.VolumeGroupRef.Namespace,
+					Name:      vgc.Spec.VolumeGroupRef.Name,
+				},
+			}}
+		})
+}
+
+// CreateVGSCRequests maps a volumeGroupSnapshotContent to the volumeGroupSnapshot it is bound to.
+func CreateVGSCRequests() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(
+		func(ctx context.Context, object runtimeclient.Object) []reconcile.Request {
+			vgsc, ok := object.(*volumegroupv1.VolumeGroupSnapshotContent)
+			if !ok || vgsc.Spec.VolumeGroupSnapshotRef == nil || vgsc.Spec.VolumeGroupSnapshotRef.Name == "" {
+				return []ctrl.Request{}
+			}
+			return []ctrl.Request{{
+				NamespacedName: types.NamespacedName{
+					Namespace: vgsc.Spec.VolumeGroupSnapshotRef.Namespace,
+					Name:      vgsc.Spec.VolumeGroupSnapshotRef.Name,
+				},
+			}}
+		})
+}
+
+// CreateDataSourceVGSRequests maps a volumeGroupSnapshot to the volumeGroups that are restored from it.
+func CreateDataSourceVGSRequests(client runtimeclient.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(
+		func(ctx context.Context, object runtimeclient.Object) []reconcile.Request {
+			var vgList volumegroupv1.VolumeGroupList
+			if err := client.List(ctx, &vgList, runtimeclient.InNamespace(object.GetNamespace()),
+				runtimeclient.MatchingFields{VGDataSourceIndex: object.GetName()}); err != nil {
+				return []ctrl.Request{}
+			}
+			var requests []ctrl.Request
+			for _, vg := range vgList.Items {
+				requests = append(requests, ctrl.Request{
+					NamespacedName: types.NamespacedName{Namespace: vg.Namespace, Name: vg.Name},
+				})
+			}
+			return requests
+		})
+}
+
+// CreateSnapshotVGRequests maps a volumeGroup to the volumeGroupSnapshots that snapshot it.
+func CreateSnapshotVGRequests(client runtimeclient.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(
+		func(ctx context.Context, object runtimeclient.Object) []reconcile.Request {
+			var vgsList volumegroupv1.VolumeGroupSnapshotList
+			if err := client.List(ctx, &vgsList, runtimeclient.InNamespace(object.GetNamespace()),
+				runtimeclient.MatchingFields{VGSVolumeGroupIndex: object.GetName()}); err != nil {
+				return []ctrl.Request{}
+			}
+			var requests []ctrl.Request
+			for _, vgs := range vgsList.Items {
+				requests = append(requests, ctrl.Request{
+					NamespacedName: types.NamespacedName{Namespace: vgs.Namespace, Name: vgs.Name},
+				})
+			}
+			return requests
+		})
+}
+
+// CreatePVRequests maps a persistentVolume to the volumeGroupContents that hold its volume handle.
+func CreatePVRequests(client runtimeclient.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(
+		func(ctx context.Context, object runtimeclient.Object) []reconcile.Request {
+			pv, ok := object.(*corev1.PersistentVolume)
+			if !ok || pv.Spec.CSI == nil {
+				return []ctrl.Request{}
+			}
+			var vgcList volumegroupv1.VolumeGroupContentList
+			if err := client.List(ctx, &vgcList, runtimeclient.MatchingFields{VGCVolumeHandleIndex: pv.Spec.CSI.VolumeHandle}); err != nil {
+				return []ctrl.Request{}
+			}
+			var requests []ctrl.Request
+			for _, vgc := range vgcList.Items {
+				requests = append(requests, ctrl.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: vgc.Namespace,
+						Name:      vgc.Name,
+					},
+				})
+			}
+			return requests
+		})
+}
+
+// CreateNamespaceRequests maps a namespace to the volumeGroups in other namespaces that have a namespaceSelector,
+// both the volumeGroups it starts to match and the volumeGroups it stops to match are reconciled.
+func CreateNamespaceRequests(client runtimeclient.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(
+		func(ctx context.Context, object runtimeclient.Object) []reconcile.Request {
+			var vgList volumegroupv1.VolumeGroupList
+			if err := client.List(ctx, &vgList); err != nil {
+				return []ctrl.Request{}
+			}
+			var requests []ctrl.Request
+			for _, vg := range vgList.Items {
+				if vg.Spec.Source.NamespaceSelector == nil || vg.Namespace == object.GetName() {
+					continue
+				}
+				requests = append(requests, ctrl.Request{
+					NamespacedName: types.NamespacedName{Namespace: vg.Namespace, Name: vg.Name},
+				})
+			}
+			return requests
+		})
+}
+
 func CreateRequests(client runtimeclient.Client) handler.EventHandler {
 	return handler.EnqueueRequestsFromMapFunc(
 		func(ctx context.Context, object runtimeclient.Object) []reconcile.Request {
+			logger := log.FromContext(ctx)
+			pvc, ok := object.(*corev1.PersistentVolumeClaim)
+			if !ok {
+				return []ctrl.Request{}
+			}
 			var vgList volumegroupv1.VolumeGroupList
 			if err := client.List(ctx, &vgList); err != nil {
 				return []ctrl.Request{}
 			}
-			// Create a reconcile request for each matching VolumeGroup.
+			// Create a reconcile request for each matching VolumeGroup, and for the VolumeGroup that restores the PVC.
 			var requests []ctrl.Request
+			namespaceResolver := newPVCNamespaceResolver(ctx, logger, client, pvc)
 			for _, vg := range vgList.Items {
-				if vg.Spec.Source.Selector == nil {
+				if vg.Namespace == pvc.Namespace && pvc.Labels[RestoredVGLabel] == vg.Name {
+					requests = append(requests, ctrl.Request{
+						NamespacedName: types.NamespacedName{Namespace: vg.Namespace, Name: vg.Name},
+					})
 					continue
 				}
-				isVgMatchPvc, err := areLabelsMatchLabelSelector(object.GetLabels(), *vg.Spec.Source.Selector)
+				isVgMatchPvc, err := isPVCMatchesVG(logger, namespaceResolver, pvc, vg)
 				if err != nil {
-					return []ctrl.Request{}
+					continue
+				}
+				isPVCInVG, err := IsPVCInVG(ctx, logger, client, pvc, &vg)
+				if err != nil {
+					continue
 				}
-				if isVgMatchPvc {
+				if isVgMatchPvc || isPVCInVG {
 					requests = append(requests, ctrl.Request{
 						NamespacedName: types.NamespacedName{
 							Namespace: vg.Namespace,
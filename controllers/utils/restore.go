@@ -0,0 +1,371 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	volumeGroupSnapshotKind   = "VolumeGroupSnapshot"
+	volumeSnapshotKind        = "VolumeSnapshot"
+	volumeSnapshotContentKind = "VolumeSnapshotContent"
+	snapshotAPIGroup          = "snapshot.storage.k8s.io"
+	snapshotAPIVersion        = "snapshot.storage.k8s.io/v1"
+)
+
+func ValidateVGDataSource(vg *volumegroupv1.VolumeGroup) error {
+	dataSource := vg.Spec.Source.DataSource
+	if dataSource == nil {
+		return nil
+	}
+	if dataSource.Kind != volumeGroupSnapshotKind ||
+		(dataSource.APIGroup != nil && *dataSource.APIGroup != volumegroupv1.GroupVersion.Group) {
+		return fmt.Errorf(messages.UnsupportedVGDataSource, vg.Namespace, vg.Name, dataSource.Kind)
+	}
+	if vg.Spec.Source.VolumeGroupContentName != nil {
+		return fmt.Errorf(messages.VGDataSourceWithVGC, vg.Namespace, vg.Name)
+	}
+	if vg.Spec.Source.Selector == nil || len(vg.Spec.Source.Selector.MatchLabels) == 0 {
+		return fmt.Errorf(messages.VGDataSourceWithoutMatchLabels, vg.Namespace, vg.Name)
+	}
+	return nil
+}
+
+// RestoreVGFromDataSource provisions a persistentVolumeClaim for every member of the dataSource volumeGroupSnapshot.
+// It returns no members while the volumeGroupSnapshot is not ready to use.
+func RestoreVGFromDataSource(ctx context.Context, logger logr.Logger, client client.Client,
+	vg *volumegroupv1.VolumeGroup) ([]volumegroupv1.VolumeGroupRestoredMember, error) {
+	vgs, err := GetVGS(ctx, client, logger, vg.Spec.Source.DataSource.Name, vg.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	vgscName := GetStringField(vgs.Status, "BoundVolumeGroupSnapshotContentName")
+	if vgscName == "" {
+		return nil, nil
+	}
+	vgsc, err := GetVGSC(ctx, client, logger, vgscName, vgs.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !IsVGSCReady(vgsc) {
+		return nil, nil
+	}
+
+	var members []volumegroupv1.VolumeGroupRestoredMember
+	for _, snapshotHandle := range vgsc.Status.VolumeSnapshotHandles {
+		member, err := restoreVGMember(ctx, logger, client, vg, vgsc, snapshotHandle)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// IsVGRestored returns whether every member is restored, a member that waits for its first consumer
+// is bound only once a pod uses it, so it does not hold the volumeGroup back.
+func IsVGRestored(members []volumegroupv1.VolumeGroupRestoredMember) bool {
+	if len(members) == 0 {
+		return false
+	}
+	for _, member := range members {
+		if member.Phase != volumegroupv1.RestoreBound && member.Phase != volumegroupv1.RestoreWaitingForFirstConsumer {
+			return false
+		}
+	}
+	return true
+}
+
+// IsVGRestoreCompleted returns whether the volumeGroup is restored and every member is bound,
+// so the restore snapshot objects of all the members are deleted.
+func IsVGRestoreCompleted(vg *volumegroupv1.VolumeGroup) bool {
+	if !meta.IsStatusConditionTrue(vg.Status.Conditions, volumegroupv1.ConditionRestored) {
+		return false
+	}
+	for _, member := range vg.Status.RestoredMembers {
+		if member.Phase != volumegroupv1.RestoreBound {
+			return false
+		}
+	}
+	return true
+}
+
+// DeleteFinishedRestoreSnapshots deletes the restore snapshot objects of the members that became bound or failed,
+// a bound claim no longer needs its snapshot. The volumeSnapshotContent retains the snapshot on the storage,
+// which belongs to the volumeGroupSnapshot.
+func DeleteFinishedRestoreSnapshots(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+	members []volumegroupv1.VolumeGroupRestoredMember) error {
+	for _, member := range members {
+		if member.Phase != volumegroupv1.RestoreBound && member.Phase != volumegroupv1.RestoreFailed {
+			continue
+		}
+		if previous := getRestoredMember(vg.Status.RestoredMembers, member.PersistentVolumeClaimName); previous != nil &&
+			previous.Phase == member.Phase {
+			continue
+		}
+		if err := deleteRestoreVolumeSnapshot(ctx, logger, client, vg, member.PersistentVolumeClaimName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getRestoredMember(members []volumegroupv1.VolumeGroupRestoredMember, pvcName string) *volumegroupv1.VolumeGroupRestoredMember {
+	for i := range members {
+		if members[i].PersistentVolumeClaimName == pvcName {
+			return &members[i]
+		}
+	}
+	return nil
+}
+
+func GetFailedRestoredPVCNames(members []volumegroupv1.VolumeGroupRestoredMember) []string {
+	var pvcNames []string
+	for _, member := range members {
+		if member.Phase == volumegroupv1.RestoreFailed {
+			pvcNames = append(pvcNames, member.PersistentVolumeClaimName)
+		}
+	}
+	return pvcNames
+}
+
+func restoreVGMember(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+	vgsc *volumegroupv1.VolumeGroupSnapshotContent,
+	snapshotHandle volumegroupv1.VolumeSnapshotHandle) (volumegroupv1.VolumeGroupRestoredMember, error) {
+	member := volumegroupv1.VolumeGroupRestoredMember{
+		SourcePersistentVolumeName: snapshotHandle.PersistentVolumeName,
+		SnapshotHandle:             snapshotHandle.SnapshotHandle,
+		PersistentVolumeClaimName:  getRestoredPVCName(vg, snapshotHandle),
+		Phase:                      volumegroupv1.RestorePending,
+	}
+
+	pvc, err := GetPVC(ctx, logger, client, member.PersistentVolumeClaimName, vg.Namespace)
+	if err == nil {
+		member.Phase, err = getRestorePhaseFromPVC(ctx, logger, client, pvc)
+		return member, err
+	}
+	if !apierrors.IsNotFound(err) {
+		return member, err
+	}
+
+	pvc, err = generateRestoredPVC(ctx, logger, client, vg, snapshotHandle, member.PersistentVolumeClaimName)
+	if err != nil {
+		return member, err
+	}
+	if pvc == nil {
+		member.Phase = volumegroupv1.RestoreFailed
+		member.Message = fmt.Sprintf(messages.RestoreSizeIsMissing, snapshotHandle.SnapshotHandle)
+		return member, nil
+	}
+	if err = createRestoreVolumeSnapshot(ctx, logger, client, vg, vgsc, snapshotHandle, member.PersistentVolumeClaimName); err != nil {
+		return member, err
+	}
+	logger.Info(fmt.Sprintf(messages.CreateRestoredPVC, pvc.Namespace, pvc.Name, snapshotHandle.SnapshotHandle))
+	if err = client.Create(ctx, pvc); err != nil && !apierrors.IsAlreadyExists(err) {
+		logger.Error(err, fmt.Sprintf(messages.FailedToCreateRestoredPVC, pvc.Namespace, pvc.Name))
+		return member, err
+	}
+	return member, nil
+}
+
+func getRestoredPVCName(vg *volumegroupv1.VolumeGroup, snapshotHandle volumegroupv1.VolumeSnapshotHandle) string {
+	sourceName := snapshotHandle.PersistentVolumeClaimName
+	if sourceName == "" {
+		sourceName = snapshotHandle.PersistentVolumeName
+	}
+	return fmt.Sprintf("%s-%s", vg.Name, sourceName)
+}
+
+func getRestorePhaseFromPVC(ctx context.Context, logger logr.Logger, client client.Client,
+	pvc *corev1.PersistentVolumeClaim) (volumegroupv1.VolumeGroupRestorePhase, error) {
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		return volumegroupv1.RestoreBound, nil
+	case corev1.ClaimLost:
+		return volumegroupv1.RestoreFailed, nil
+	}
+	storageClassName, err := GetPVCClass(pvc)
+	if err != nil {
+		return volumegroupv1.RestorePending, nil
+	}
+	sc, err := getStorageClass(ctx, logger, client, storageClassName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return volumegroupv1.RestorePending, nil
+		}
+		return volumegroupv1.RestorePending, err
+	}
+	if sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+		return volumegroupv1.RestoreWaitingForFirstConsumer, nil
+	}
+	return volumegroupv1.RestorePending, nil
+}
+
+func generateRestoredPVC(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+	snapshotHandle volumegroupv1.VolumeSnapshotHandle, pvcName string) (*corev1.PersistentVolumeClaim, error) {
+	accessModes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	var volumeMode *corev1.PersistentVolumeMode
+	var size *resource.Quantity
+	if snapshotHandle.RestoreSize != nil && *snapshotHandle.RestoreSize > 0 {
+		size = resource.NewQuantity(*snapshotHandle.RestoreSize, resource.BinarySI)
+	}
+
+	if snapshotHandle.PersistentVolumeName != "" {
+		pv, err := getPV(ctx, logger, client, snapshotHandle.PersistentVolumeName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			if len(pv.Spec.AccessModes) > 0 {
+				accessModes = pv.Spec.AccessModes
+			}
+			volumeMode = pv.Spec.VolumeMode
+			if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok && size == nil {
+				size = &capacity
+			}
+		}
+	}
+	if size == nil {
+		return nil, nil
+	}
+
+	apiGroup := snapshotAPIGroup
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pvcName,
+			Namespace: vg.Namespace,
+			Labels:    getRestoredPVCLabels(vg),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: accessModes,
+			VolumeMode:  volumeMode,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: *size},
+			},
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     volumeSnapshotKind,
+				Name:     pvcName,
+			},
+		},
+	}
+	if snapshotHandle.StorageClassName != "" {
+		pvc.Spec.StorageClassName = &snapshotHandle.StorageClassName
+	}
+	return pvc, nil
+}
+
+// getRestoredPVCLabels returns the selector matchLabels, so the claim joins the volumeGroup,
+// and the label that maps claim events to the volumeGroup while it is restored.
+func getRestoredPVCLabels(vg *volumegroupv1.VolumeGroup) map[string]string {
+	labels := map[string]string{RestoredVGLabel: vg.Name}
+	for key, value := range vg.Spec.Source.Selector.MatchLabels {
+		labels[key] = value
+	}
+	return labels
+}
+
+func getRestoreSnapshotContentName(vg *volumegroupv1.VolumeGroup, snapshotName string) string {
+	return fmt.Sprintf("%s-%s", vg.Namespace, snapshotName)
+}
+
+func createRestoreVolumeSnapshot(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+	vgsc *volumegroupv1.VolumeGroupSnapshotContent, snapshotHandle volumegroupv1.VolumeSnapshotHandle,
+	snapshotName string) error {
+	contentName := getRestoreSnapshotContentName(vg, snapshotName)
+	content := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": snapshotAPIVersion,
+		"kind":       volumeSnapshotContentKind,
+		"metadata":   map[string]interface{}{"name": contentName},
+		"spec": map[string]interface{}{
+			"deletionPolicy": "Retain",
+			"driver":         vgsc.Spec.Source.Driver,
+			"source":         map[string]interface{}{"snapshotHandle": snapshotHandle.SnapshotHandle},
+			"volumeSnapshotRef": map[string]interface{}{
+				"name":      snapshotName,
+				"namespace": vg.Namespace,
+			},
+		},
+	}}
+	snapshot := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": snapshotAPIVersion,
+		"kind":       volumeSnapshotKind,
+		"metadata":   map[string]interface{}{"name": snapshotName, "namespace": vg.Namespace},
+		"spec": map[string]interface{}{
+			"source": map[string]interface{}{"volumeSnapshotContentName": contentName},
+		},
+	}}
+
+	for _, obj := range []*unstructured.Unstructured{content, snapshot} {
+		logger.Info(fmt.Sprintf(messages.CreateRestoreSnapshotObject, obj.GetKind(), obj.GetNamespace(), obj.GetName()))
+		if err := client.Create(ctx, obj); err != nil && !apierrors.IsAlreadyExists(err) {
+			logger.Error(err, fmt.Sprintf(messages.FailedToCreateRestoreSnapshotObject, obj.GetKind(), obj.GetNamespace(), obj.GetName()))
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteRestoreVolumeSnapshot(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+	snapshotName string) error {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetAPIVersion(snapshotAPIVersion)
+	snapshot.SetKind(volumeSnapshotKind)
+	snapshot.SetNamespace(vg.Namespace)
+	snapshot.SetName(snapshotName)
+	content := &unstructured.Unstructured{}
+	content.SetAPIVersion(snapshotAPIVersion)
+	content.SetKind(volumeSnapshotContentKind)
+	content.SetName(getRestoreSnapshotContentName(vg, snapshotName))
+
+	for _, obj := range []*unstructured.Unstructured{snapshot, content} {
+		logger.Info(fmt.Sprintf(messages.DeleteRestoreSnapshotObject, obj.GetKind(), obj.GetNamespace(), obj.GetName()))
+		if err := client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			logger.Error(err, fmt.Sprintf(messages.FailedToDeleteRestoreSnapshotObject, obj.GetKind(), obj.GetNamespace(), obj.GetName()))
+			return err
+		}
+	}
+	return nil
+}
+
+func UpdateVGRestoredMembers(ctx context.Context, client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger,
+	members []volumegroupv1.VolumeGroupRestoredMember) error {
+	if equality.Semantic.DeepEqual(vg.Status.RestoredMembers, members) {
+		return nil
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vg.Status.RestoredMembers = members
+		return vgRetryOnConflictFunc(ctx, client, vg, logger)
+	})
+	return err
+}
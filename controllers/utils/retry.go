@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type RetryClass int
+
+const (
+	// RetrySoon is a transient failure, it is retried with a short backoff.
+	RetrySoon RetryClass = iota
+	// RetrySlowly is a failure that needs a change outside of the object, it is retried with a long backoff.
+	RetrySlowly
+	// RetryOnSpecChange is a failure that is not retried until the spec of the object changes.
+	RetryOnSpecChange
+)
+
+// GetRetryClass maps gRPC codes of the driver and kubernetes API errors to the way the failure is retried.
+// Unimplemented is retried slowly, since the driver may implement the operation once it is upgraded.
+func GetRetryClass(err error) RetryClass {
+	if s, ok := status.FromError(err); ok && s.Code() != codes.Unknown {
+		switch s.Code() {
+		case codes.DeadlineExceeded, codes.Aborted, codes.Canceled, codes.Unavailable:
+			return RetrySoon
+		case codes.InvalidArgument, codes.AlreadyExists, codes.OutOfRange:
+			return RetryOnSpecChange
+		default:
+			return RetrySlowly
+		}
+	}
+	switch {
+	case apierrors.IsConflict(err), apierrors.IsServerTimeout(err), apierrors.IsTimeout(err),
+		apierrors.IsServiceUnavailable(err), apierrors.IsInternalError(err):
+		return RetrySoon
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return RetryOnSpecChange
+	default:
+		return RetrySlowly
+	}
+}
+
+// GetRetryDelay returns the exponential backoff delay of a retry with a jitter, capped by maxDelay.
+func GetRetryDelay(retryClass RetryClass, retryCount int32, maxDelay time.Duration) time.Duration {
+	delay := retrySoonBaseDelay
+	if retryClass == RetrySlowly {
+		delay = retrySlowlyBaseDelay
+	}
+	for i := int32(1); i < retryCount && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = wait.Jitter(delay, retryJitterFactor)
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// HandleVGRetry persists the retry state of the volumeGroup and returns the result of its reconcile.
+func HandleVGRetry(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+	result ctrl.Result, err error, maxDelay time.Duration) (ctrl.Result, error) {
+	retryResult, retryErr, retryCount, nextRetryTime := getRetryResult(logger, result, err, vg.Status.RetryCount, maxDelay)
+	if uErr := updateVGRetryStatus(ctx, client, vg, logger, retryCount, nextRetryTime); uErr != nil {
+		return ctrl.Result{}, uErr
+	}
+	return retryResult, retryErr
+}
+
+// HandleVGCRetry persists the retry state of the volumeGroupContent and returns the result of its reconcile.
+func HandleVGCRetry(ctx context.Context, logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent,
+	result ctrl.Result, err error, maxDelay time.Duration) (ctrl.Result, error) {
+	retryResult, retryErr, retryCount, nextRetryTime := getRetryResult(logger, result, err, vgc.Status.RetryCount, maxDelay)
+	if uErr := updateVGCRetryStatus(ctx, client, vgc, logger, retryCount, nextRetryTime); uErr != nil {
+		return ctrl.Result{}, uErr
+	}
+	return retryResult, retryErr
+}
+
+func getRetryResult(logger logr.Logger, result ctrl.Result, err error, retryCount int32,
+	maxDelay time.Duration) (ctrl.Result, error, int32, *metav1.Time) {
+	if err == nil && !result.Requeue {
+		return result, nil, 0, nil
+	}
+	retryCount++
+	retryClass := RetrySoon
+	if err != nil {
+		retryClass = GetRetryClass(err)
+	}
+	if retryClass == RetryOnSpecChange {
+		logger.Info(fmt.Sprintf(messages.StopRetryReconcile, retryCount, GetMessageFromError(err)))
+		return ctrl.Result{}, reconcile.TerminalError(err), retryCount, nil
+	}
+	delay := GetRetryDelay(retryClass, retryCount, maxDelay)
+	if err != nil {
+		logger.Info(fmt.Sprintf(messages.RetryReconcile, retryCount, delay, GetMessageFromError(err)))
+	} else {
+		logger.Info(fmt.Sprintf(messages.WaitForReconcile, retryCount, delay))
+	}
+	nextRetryTime := metav1.NewTime(time.Now().Add(delay))
+	return ctrl.Result{RequeueAfter: delay}, nil, retryCount, &nextRetryTime
+}
+
+func updateVGRetryStatus(ctx context.Context, client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger,
+	retryCount int32, nextRetryTime *metav1.Time) error {
+	if vg.Status.RetryCount == retryCount && vg.Status.NextRetryTime == nil && nextRetryTime == nil {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vg.Status.RetryCount = retryCount
+		vg.Status.NextRetryTime = nextRetryTime
+		return vgRetryOnConflictFunc(ctx, client, vg, logger)
+	})
+}
+
+func updateVGCRetryStatus(ctx context.Context, client client.Client, vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger,
+	retryCount int32, nextRetryTime *metav1.Time) error {
+	if vgc.Status.RetryCount == retryCount && vgc.Status.NextRetryTime == nil && nextRetryTime == nil {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vgc.Status.RetryCount = retryCount
+		vgc.Status.NextRetryTime = nextRetryTime
+		return vgcRetryOnConflictFunc(ctx, client, vgc, logger)
+	})
+}
@@ -27,10 +27,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func getSecretData(client client.Client, logger logr.Logger, name, namespace string) (map[string]string, error) {
+func getSecretData(ctx context.Context, client client.Client, logger logr.Logger, name, namespace string) (map[string]string, error) {
 	namespacedName := types.NamespacedName{Name: name, Namespace: namespace}
 	secret := &corev1.Secret{}
-	err := client.Get(context.TODO(), namespacedName, secret)
+	err := client.Get(ctx, namespacedName, secret)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Error(err, "secret not found", "Secret Name", name, "Secret Namespace", namespace)
@@ -55,12 +55,12 @@ func convertMap(oldMap map[string][]byte) map[string]string {
 	return newMap
 }
 
-func GetSecretDataFromClass(client client.Client, vgClass *volumegroupv1.VolumeGroupClass, logger logr.Logger) (map[string]string, error) {
+func GetSecretDataFromClass(ctx context.Context, client client.Client, vgClass *volumegroupv1.VolumeGroupClass, logger logr.Logger) (map[string]string, error) {
 	secretName, secretNamespace := GetSecretCred(vgClass)
 	secret := make(map[string]string)
 	var err error
 	if secretName != "" && secretNamespace != "" {
-		secret, err = getSecretData(client, logger, secretName, secretNamespace)
+		secret, err = getSecretData(ctx, client, logger, secretName, secretNamespace)
 		if err != nil {
 			return nil, err
 		}
@@ -69,7 +69,18 @@ func GetSecretDataFromClass(client client.Client, vgClass *volumegroupv1.VolumeG
 }
 
 func GetSecretCred(vgClass *volumegroupv1.VolumeGroupClass) (string, string) {
-	secretName := vgClass.Parameters[PrefixedVGSecretNameKey]
-	secretNamespace := vgClass.Parameters[PrefixedVGSecretNamespaceKey]
+	return getSecretCredFromParameters(vgClass.Parameters)
+}
+
+func getSecretCredFromParameters(parameters map[string]string) (string, string) {
+	secretName := parameters[PrefixedVGSecretNameKey]
+	secretNamespace := parameters[PrefixedVGSecretNamespaceKey]
 	return secretName, secretNamespace
 }
+
+func GetSecretDataFromRef(ctx context.Context, client client.Client, secretRef *corev1.SecretReference, logger logr.Logger) (map[string]string, error) {
+	if secretRef == nil || secretRef.Name == "" || secretRef.Namespace == "" {
+		return map[string]string{}, nil
+	}
+	return getSecretData(ctx, client, logger, secretRef.Name, secretRef.Namespace)
+}
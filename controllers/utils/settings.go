@@ -16,12 +16,32 @@ limitations under the License.
 
 package utils
 
+import "time"
+
 const (
 	VGNamePrefix                 = "volumegroup"
 	vgGroupName                  = "volumegroup.storage.ibm.io"
 	VGFinalizer                  = vgGroupName
 	VGAsPrefix                   = vgGroupName + "/"
 	VgcFinalizer                 = VGAsPrefix + "vgc-protection"
+	VGCreationInProgressKey      = VGAsPrefix + "creation-in-progress"
+	VGSNamePrefix                = "volumegroupsnapshot"
+	DiscoveredVGCNamePrefix      = "volumegroup-discovered"
+	DiscoveredVGCLabel           = VGAsPrefix + "discovered"
+	PVCNamespaceLabelIndex       = VGAsPrefix + "namespace-label"
+	PVCStorageClassIndex         = VGAsPrefix + "storage-class"
+	VGCVolumeHandleIndex         = VGAsPrefix + "volume-handle"
+	VGSVolumeGroupIndex          = VGAsPrefix + "volume-group"
+	VGDataSourceIndex            = VGAsPrefix + "data-source"
+	RestoredVGLabel              = VGAsPrefix + "restored-volume-group"
+	VGMNamePrefix                = "volumegroupmember"
+	VGMVolumeGroupUIDLabel       = VGAsPrefix + "volume-group-uid"
+	discoveryPageSize            = 100
+	retrySoonBaseDelay           = time.Second
+	retrySlowlyBaseDelay         = 30 * time.Second
+	retryJitterFactor            = 0.1
+	VgsFinalizer                 = VGAsPrefix + "vgs-protection"
+	VgscFinalizer                = VGAsPrefix + "vgsc-protection"
 	pvcVGFinalizer               = VGAsPrefix + "pvc-protection"
 	PrefixedVGSecretNameKey      = VGAsPrefix + "secret-name"      // name key for secret
 	PrefixedVGSecretNamespaceKey = VGAsPrefix + "secret-namespace" // namespace key secret
@@ -33,6 +53,13 @@ const (
 	addingPVC                    = "addPVC"
 	removingPVC                  = "removePVC"
 	createVGC                    = "creatingVGC"
+	membershipDrift              = "membershipDrift"
+	remediateMembershipDrift     = "remediatingMembershipDrift"
+	vgKind                       = "VolumeGroup"
 	vgcKind                      = "VolumeGroupContent"
+	vgClassKind                  = "VolumeGroupClass"
+	createVGSC                   = "creatingVGSC"
+	vgsKind                      = "VolumeGroupSnapshot"
+	vgscKind                     = "VolumeGroupSnapshotContent"
 	APIVersion                   = "csi.ibm.com/v1"
 )
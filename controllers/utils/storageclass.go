@@ -19,6 +19,7 @@ package utils
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -26,7 +27,11 @@ import (
 	"github.com/go-logr/logr"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func GetPVCClass(claim *corev1.PersistentVolumeClaim) (string, error) {
@@ -42,8 +47,8 @@ func GetPVCClass(claim *corev1.PersistentVolumeClaim) (string, error) {
 	return "", err
 }
 
-func getStorageClassProvisioner(logger logr.Logger, client client.Client, scName string) (string, error) {
-	sc, err := getStorageClass(logger, client, scName)
+func getStorageClassProvisioner(ctx context.Context, logger logr.Logger, client client.Client, scName string) (string, error) {
+	sc, err := getStorageClass(ctx, logger, client, scName)
 	if err != nil {
 		return "", err
 	}
@@ -56,12 +61,67 @@ func isSCHasParam(sc *storagev1.StorageClass, param string) bool {
 	return ok
 }
 
-func getStorageClass(logger logr.Logger, client client.Client, scName string) (*storagev1.StorageClass, error) {
-	sc := &storagev1.StorageClass{}
-	err := client.Get(context.TODO(), types.NamespacedName{Name: scName}, sc)
+// storageClassCache keeps the storageClasses in memory, StorageClassCacheHandler keeps it in sync with the
+// storageClass watch so the storageClass of every persistentVolumeClaim is resolved without a read.
+var storageClassCache = struct {
+	sync.RWMutex
+	storageClasses map[string]*storagev1.StorageClass
+}{storageClasses: map[string]*storagev1.StorageClass{}}
+
+func getStorageClass(ctx context.Context, logger logr.Logger, client client.Client, scName string) (*storagev1.StorageClass, error) {
+	storageClassCache.RLock()
+	sc, ok := storageClassCache.storageClasses[scName]
+	storageClassCache.RUnlock()
+	if ok {
+		return sc, nil
+	}
+	sc = &storagev1.StorageClass{}
+	err := client.Get(ctx, types.NamespacedName{Name: scName}, sc)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf(messages.FailedToGetStorageClass, scName))
 		return nil, err
 	}
+	setCachedStorageClass(sc)
 	return sc, nil
 }
+
+// getStorageClassNamesOfProvisioner lists the storageClasses once per lookup and refreshes the storageClass cache with them,
+// a storageClass that is read before its watch event is still known to the per persistentVolumeClaim lookups.
+func getStorageClassNamesOfProvisioner(ctx context.Context, logger logr.Logger, client client.Client, provisioner string) ([]string, error) {
+	scList := &storagev1.StorageClassList{}
+	if err := client.List(ctx, scList); err != nil {
+		logger.Error(err, messages.FailedToListStorageClasses)
+		return nil, err
+	}
+	var scNames []string
+	for i := range scList.Items {
+		sc := &scList.Items[i]
+		setCachedStorageClass(sc)
+		if sc.Provisioner == provisioner {
+			scNames = append(scNames, sc.Name)
+		}
+	}
+	return scNames, nil
+}
+
+func setCachedStorageClass(sc *storagev1.StorageClass) {
+	storageClassCache.Lock()
+	storageClassCache.storageClasses[sc.Name] = sc
+	storageClassCache.Unlock()
+}
+
+// StorageClassCacheHandler keeps the storageClass cache in sync with the storageClass watch,
+// a storageClass that is created again with the same name may have another provisioner.
+var StorageClassCacheHandler = handler.Funcs{
+	CreateFunc: func(_ context.Context, e event.CreateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		setCachedStorageClass(e.Object.(*storagev1.StorageClass))
+	},
+	UpdateFunc: func(_ context.Context, e event.UpdateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		setCachedStorageClass(e.ObjectNew.(*storagev1.StorageClass))
+	},
+	DeleteFunc: func(_ context.Context, e event.DeleteEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		storageClassCache.Lock()
+		delete(storageClassCache.storageClasses, e.Object.GetName())
+		storageClassCache.Unlock()
+	},
+}
@@ -22,19 +22,8 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-
-	corev1 "k8s.io/api/core/v1"
 )
 
-func removeByIndexFromPVCList(pvcList []corev1.PersistentVolumeClaim, index int) []corev1.PersistentVolumeClaim {
-	return append(pvcList[:index], pvcList[index+1:]...)
-}
-
-func removeByIndexFromPVList(pvList []corev1.PersistentVolume,
-	index int) []corev1.PersistentVolume {
-	return append(pvList[:index], pvList[index+1:]...)
-}
-
 func GetStringField(object interface{}, fieldName string) string {
 	fieldValue := GetObjectField(object, fieldName)
 	if !fieldValue.IsValid() {
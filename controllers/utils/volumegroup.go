@@ -33,11 +33,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func GetVG(client client.Client, logger logr.Logger, vgName string, vgNamespace string) (*volumegroupv1.VolumeGroup, error) {
+func GetVG(ctx context.Context, client client.Client, logger logr.Logger, vgName string, vgNamespace string) (*volumegroupv1.VolumeGroup, error) {
 	logger.Info(fmt.Sprintf(messages.GetVG, vgName, vgNamespace))
 	vg := &volumegroupv1.VolumeGroup{}
 	namespacedVG := types.NamespacedName{Name: vgName, Namespace: vgNamespace}
-	err := client.Get(context.TODO(), namespacedVG, vg)
+	err := client.Get(ctx, namespacedVG, vg)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			logger.Error(err, "VolumeGroup not found", "VolumeGroup Name", vgName)
@@ -47,9 +47,9 @@ func GetVG(client client.Client, logger logr.Logger, vgName string, vgNamespace
 	return vg, nil
 }
 
-func IsVgExist(client client.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) (bool, error) {
+func IsVgExist(ctx context.Context, client client.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) (bool, error) {
 	if !GetObjectField(vgc.Spec, "VolumeGroupRef").IsNil() {
-		if vg, err := GetVG(client, logger, vgc.Spec.VolumeGroupRef.Name, vgc.Spec.VolumeGroupRef.Namespace); err != nil {
+		if vg, err := GetVG(ctx, client, logger, vgc.Spec.VolumeGroupRef.Name, vgc.Spec.VolumeGroupRef.Namespace); err != nil {
 			if !errors.IsNotFound(err) {
 				return false, err
 			}
@@ -60,19 +60,19 @@ func IsVgExist(client client.Client, logger logr.Logger, vgc *volumegroupv1.Volu
 	return false, nil
 }
 
-func UpdateVGSourceContent(client client.Client, instance *volumegroupv1.VolumeGroup,
+func UpdateVGSourceContent(ctx context.Context, client client.Client, instance *volumegroupv1.VolumeGroup,
 	vgcName string, logger logr.Logger) error {
 	instance.Spec.Source.VolumeGroupContentName = &vgcName
-	if err := UpdateObject(client, instance); err != nil {
+	if err := UpdateObject(ctx, client, instance); err != nil {
 		logger.Error(err, "failed to update source", "VGName", instance.Name)
 		return err
 	}
 	return nil
 }
 
-func updateVGStatus(client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger) error {
+func updateVGStatus(ctx context.Context, client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger) error {
 	logger.Info(fmt.Sprintf(messages.UpdateVGStatus, vg.Namespace, vg.Name))
-	if err := UpdateObjectStatus(client, vg); err != nil {
+	if err := UpdateObjectStatus(ctx, client, vg); err != nil {
 		if apierrors.IsConflict(err) {
 			return err
 		}
@@ -82,28 +82,28 @@ func updateVGStatus(client client.Client, vg *volumegroupv1.VolumeGroup, logger
 	return nil
 }
 
-func UpdateVGStatus(client client.Client, vg *volumegroupv1.VolumeGroup, vgcName string,
-	groupCreationTime *metav1.Time, ready bool, logger logr.Logger) error {
+func UpdateVGStatus(ctx context.Context, client client.Client, vg *volumegroupv1.VolumeGroup, vgcName string,
+	groupCreationTime *metav1.Time, logger logr.Logger) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		vg.Status.BoundVolumeGroupContentName = &vgcName
 		vg.Status.GroupCreationTime = groupCreationTime
-		vg.Status.Ready = &ready
-		vg.Status.Error = nil
-		err := vgRetryOnConflictFunc(client, vg, logger)
+		SetVGConditions(vg, GenerateCondition(volumegroupv1.ConditionBound, metav1.ConditionTrue,
+			volumegroupv1.ReasonSucceeded, fmt.Sprintf(messages.VGBoundToVGC, vg.Namespace, vg.Name, vgcName)))
+		err := vgRetryOnConflictFunc(ctx, client, vg, logger)
 		return err
 	})
 	if err != nil {
 		return err
 	}
 
-	return updateVGStatus(client, vg, logger)
+	return updateVGStatus(ctx, client, vg, logger)
 }
 
-func updateVGStatusPVCList(client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger,
-	pvcList []corev1.PersistentVolumeClaim) error {
+func updateVGStatusMembers(ctx context.Context, client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger,
+	members []volumegroupv1.VolumeGroupMemberReference) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		vg.Status.PVCList = pvcList
-		err := vgRetryOnConflictFunc(client, vg, logger)
+		vg.Status.Members = members
+		err := vgRetryOnConflictFunc(ctx, client, vg, logger)
 		return err
 	})
 	if err != nil {
@@ -113,10 +113,13 @@ func updateVGStatusPVCList(client client.Client, vg *volumegroupv1.VolumeGroup,
 	return nil
 }
 
-func UpdateVGStatusError(client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger, message string) error {
+func UpdateVGStatusConditions(ctx context.Context, client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger,
+	conditions ...metav1.Condition) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		vg.Status.Error = &volumegroupv1.VolumeGroupError{Message: &message}
-		err := vgRetryOnConflictFunc(client, vg, logger)
+		if !SetVGConditions(vg, conditions...) {
+			return nil
+		}
+		err := vgRetryOnConflictFunc(ctx, client, vg, logger)
 		return err
 	})
 	if err != nil {
@@ -126,10 +129,10 @@ func UpdateVGStatusError(client client.Client, vg *volumegroupv1.VolumeGroup, lo
 	return nil
 }
 
-func vgRetryOnConflictFunc(client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger) error {
-	err := updateVGStatus(client, vg, logger)
+func vgRetryOnConflictFunc(ctx context.Context, client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger) error {
+	err := updateVGStatus(ctx, client, vg, logger)
 	if apierrors.IsConflict(err) {
-		uErr := getNamespacedObject(client, vg)
+		uErr := getNamespacedObject(ctx, client, vg)
 		if uErr != nil {
 			return uErr
 		}
@@ -138,25 +141,25 @@ func vgRetryOnConflictFunc(client client.Client, vg *volumegroupv1.VolumeGroup,
 	return err
 }
 
-func GetVGList(logger logr.Logger, client client.Client, driver string) (volumegroupv1.VolumeGroupList, error) {
+func GetVGList(ctx context.Context, logger logr.Logger, client client.Client, driver string) (volumegroupv1.VolumeGroupList, error) {
 	logger.Info(messages.ListVGs)
 	vg := &volumegroupv1.VolumeGroupList{}
-	err := client.List(context.TODO(), vg)
+	err := client.List(ctx, vg)
 	if err != nil {
 		return volumegroupv1.VolumeGroupList{}, err
 	}
-	vgList, err := getProvisionedVGs(logger, client, vg, driver)
+	vgList, err := getProvisionedVGs(ctx, logger, client, vg, driver)
 	if err != nil {
 		return volumegroupv1.VolumeGroupList{}, err
 	}
 	return vgList, nil
 }
 
-func getProvisionedVGs(logger logr.Logger, client client.Client, vgList *volumegroupv1.VolumeGroupList,
+func getProvisionedVGs(ctx context.Context, logger logr.Logger, client client.Client, vgList *volumegroupv1.VolumeGroupList,
 	driver string) (volumegroupv1.VolumeGroupList, error) {
 	newVgList := volumegroupv1.VolumeGroupList{}
 	for _, vg := range vgList.Items {
-		isVGHasMatchingDriver, err := isVGHasMatchingDriver(logger, client, vg, driver)
+		isVGHasMatchingDriver, err := isVGHasMatchingDriver(ctx, logger, client, vg, driver)
 		if err != nil {
 			return volumegroupv1.VolumeGroupList{}, err
 		}
@@ -167,9 +170,9 @@ func getProvisionedVGs(logger logr.Logger, client client.Client, vgList *volumeg
 	return newVgList, nil
 }
 
-func isVGHasMatchingDriver(logger logr.Logger, client client.Client, vg volumegroupv1.VolumeGroup,
+func isVGHasMatchingDriver(ctx context.Context, logger logr.Logger, client client.Client, vg volumegroupv1.VolumeGroup,
 	driver string) (bool, error) {
-	vgClassDriver, err := getVGClassDriver(client, logger, GetStringField(vg.Spec, "VolumeGroupClassName"))
+	vgClassDriver, err := getVGClassDriver(ctx, client, logger, GetStringField(vg.Spec, "VolumeGroupClassName"))
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return false, nil
@@ -178,10 +181,26 @@ func isVGHasMatchingDriver(logger logr.Logger, client client.Client, vg volumegr
 	}
 	return vgClassDriver == driver, nil
 }
-func IsPVCMatchesVG(logger logr.Logger, pvc *corev1.PersistentVolumeClaim, vg volumegroupv1.VolumeGroup) (bool, error) {
+func IsPVCMatchesVG(ctx context.Context, logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim, vg volumegroupv1.VolumeGroup) (bool, error) {
+	return isPVCMatchesVG(logger, newPVCNamespaceResolver(ctx, logger, client, pvc), pvc, vg)
+}
+
+func isPVCMatchesVG(logger logr.Logger, namespaceResolver *pvcNamespaceResolver, pvc *corev1.PersistentVolumeClaim,
+	vg volumegroupv1.VolumeGroup) (bool, error) {
 
 	logger.Info(fmt.Sprintf(messages.CheckIfPVCMatchesVG,
 		pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
+	if vg.Spec.Source.Selector == nil {
+		logger.Info(fmt.Sprintf(messages.PVCNotMatchedToVG,
+			pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
+		return false, nil
+	}
+	isPVCNamespaceMatchesVG, err := namespaceResolver.isPVCNamespaceMatchesVG(vg)
+	if err != nil || !isPVCNamespaceMatchesVG {
+		logger.Info(fmt.Sprintf(messages.PVCNotMatchedToVG,
+			pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
+		return false, err
+	}
 	areLabelsMatchLabelSelector, err := areLabelsMatchLabelSelector(pvc.ObjectMeta.Labels, *vg.Spec.Source.Selector)
 
 	if areLabelsMatchLabelSelector {
@@ -195,47 +214,35 @@ func IsPVCMatchesVG(logger logr.Logger, pvc *corev1.PersistentVolumeClaim, vg vo
 	}
 }
 
-func RemovePVCFromVG(logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim, vg *volumegroupv1.VolumeGroup) error {
+func RemovePVCFromVG(ctx context.Context, logger logr.Logger, client client.Client, member volumegroupv1.VolumeGroupMemberReference,
+	vg *volumegroupv1.VolumeGroup) error {
 	logger.Info(fmt.Sprintf(messages.RemovePVCFromVG,
-		pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
-	vg.Status.PVCList = removeFromPVCList(pvc, vg.Status.PVCList)
-	err := updateVGStatusPVCList(client, vg, logger, vg.Status.PVCList)
+		member.Namespace, member.Name, vg.Namespace, vg.Name))
+	err := removeVGMember(ctx, logger, client, vg, member)
 	if err != nil {
-		vg.Status.PVCList = appendPVC(vg.Status.PVCList, *pvc)
 		logger.Error(err, fmt.Sprintf(messages.FailedToRemovePVCFromVG,
-			pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
+			member.Namespace, member.Name, vg.Namespace, vg.Name))
 		return err
 	}
 	logger.Info(fmt.Sprintf(messages.RemovedPVCFromVG,
-		pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
+		member.Namespace, member.Name, vg.Namespace, vg.Name))
 	return nil
 }
 
-func removeFromPVCList(pvc *corev1.PersistentVolumeClaim, pvcList []corev1.PersistentVolumeClaim) []corev1.PersistentVolumeClaim {
-	for index, pvcFromList := range pvcList {
-		if pvcFromList.Name == pvc.Name && pvcFromList.Namespace == pvc.Namespace {
-			pvcList = removeByIndexFromPVCList(pvcList, index)
-			return pvcList
-		}
-	}
-	return pvcList
-}
-
-func getVgId(logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup) (string, error) {
-	vgc, err := GetVGC(client, logger, GetStringField(vg.Spec.Source, "VolumeGroupContentName"), vg.Namespace)
+func getVgId(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup) (string, error) {
+	vgc, err := GetVGC(ctx, client, logger, GetStringField(vg.Spec.Source, "VolumeGroupContentName"), vg.Namespace)
 	if err != nil {
 		return "", err
 	}
 	return string(vgc.Spec.Source.VolumeGroupHandle), nil
 }
 
-func AddPVCToVG(logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim, vg *volumegroupv1.VolumeGroup) error {
+func AddPVCToVG(ctx context.Context, logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim, pv *corev1.PersistentVolume,
+	vg *volumegroupv1.VolumeGroup) error {
 	logger.Info(fmt.Sprintf(messages.AddPVCToVG,
 		pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
-	vg.Status.PVCList = appendPVC(vg.Status.PVCList, *pvc)
-	err := updateVGStatusPVCList(client, vg, logger, vg.Status.PVCList)
+	err := addVGMember(ctx, logger, client, vg, GenerateVGMember(pvc, pv))
 	if err != nil {
-		vg.Status.PVCList = removeFromPVCList(pvc, vg.Status.PVCList)
 		logger.Error(err, fmt.Sprintf(messages.FailedToAddPVCToVG,
 			pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
 		return err
@@ -245,23 +252,18 @@ func AddPVCToVG(logger logr.Logger, client client.Client, pvc *corev1.Persistent
 	return nil
 }
 
-func appendPVC(pvcListInVG []corev1.PersistentVolumeClaim, pvc corev1.PersistentVolumeClaim) []corev1.PersistentVolumeClaim {
-	for _, pvcFromList := range pvcListInVG {
-		if pvcFromList.Name == pvc.Name && pvcFromList.Namespace == pvc.Namespace {
-			return pvcListInVG
-		}
-	}
-	pvcListInVG = append(pvcListInVG, pvc)
-	return pvcListInVG
-}
-
-func IsPVCPartAnyVG(pvc *corev1.PersistentVolumeClaim, vgs []volumegroupv1.VolumeGroup) bool {
+func IsPVCPartAnyVG(ctx context.Context, logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim,
+	vgs []volumegroupv1.VolumeGroup) (bool, error) {
 	for _, vg := range vgs {
-		if IsPVCInPVCList(pvc, vg.Status.PVCList) {
-			return true
+		isPVCInVG, err := IsPVCInVG(ctx, logger, client, pvc, &vg)
+		if err != nil {
+			return false, err
+		}
+		if isPVCInVG {
+			return true, nil
 		}
 	}
-	return false
+	return false, nil
 }
 
 func IsPVCInPVCList(pvc *corev1.PersistentVolumeClaim, pvcList []corev1.PersistentVolumeClaim) bool {
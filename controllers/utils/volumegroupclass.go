@@ -19,28 +19,35 @@ package utils
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	commonUtils "github.com/IBM/csi-volume-group-operator/controllers/common/utils"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func getVGClassDriver(client client.Client, logger logr.Logger, vgClassName string) (string, error) {
-	vgClass, err := GetVGClass(client, logger, vgClassName)
+func getVGClassDriver(ctx context.Context, client client.Client, logger logr.Logger, vgClassName string) (string, error) {
+	vgClass, err := GetVGClass(ctx, client, logger, vgClassName)
 	if err != nil {
 		return "", err
 	}
 	return vgClass.Driver, nil
 }
 
-func GetVGClass(client client.Client, logger logr.Logger, vgClassName string) (*volumegroupv1.VolumeGroupClass, error) {
+func GetVGClass(ctx context.Context, client client.Client, logger logr.Logger, vgClassName string) (*volumegroupv1.VolumeGroupClass, error) {
 	if vgClassName == "" {
 		return nil, fmt.Errorf("VolumeGroupClass name is empty")
 	}
 	vgClass := &volumegroupv1.VolumeGroupClass{}
-	err := client.Get(context.TODO(), types.NamespacedName{Name: vgClassName}, vgClass)
+	err := client.Get(ctx, types.NamespacedName{Name: vgClassName}, vgClass)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Error(err, "VolumeGroupClass not found", "VolumeGroupClass Name", vgClassName)
@@ -52,3 +59,108 @@ func GetVGClass(client client.Client, logger logr.Logger, vgClassName string) (*
 	}
 	return vgClass, nil
 }
+
+func UpdateVGClassStatusConditions(ctx context.Context, client client.Client, vgClass *volumegroupv1.VolumeGroupClass, logger logr.Logger,
+	conditions ...metav1.Condition) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if !SetVGClassConditions(vgClass, conditions...) {
+			return nil
+		}
+		err := UpdateObjectStatus(ctx, client, vgClass)
+		if apierrors.IsConflict(err) {
+			if uErr := getNamespacedObject(ctx, client, vgClass); uErr != nil {
+				return uErr
+			}
+			logger.Info(fmt.Sprintf(messages.RetryUpdateVGClassStatus, vgClass.Name))
+		}
+		return err
+	})
+	if err != nil {
+		logger.Error(err, "failed to update volumeGroupClass status", "VGClassName", vgClass.Name)
+		return err
+	}
+	return nil
+}
+
+// RecordCreatedVG records the volume group created on the storage for the volumeGroupContent in the volumeGroupClass
+// status until the volumeGroupContent records its handle, see GetOrphanedVGs.
+func RecordCreatedVG(ctx context.Context, client client.Client, logger logr.Logger, vgClass *volumegroupv1.VolumeGroupClass,
+	vgc *volumegroupv1.VolumeGroupContent, vgId string) error {
+	createdVG := volumegroupv1.CreatedVolumeGroup{
+		VolumeGroupHandle: vgId,
+		VolumeGroupContentRef: corev1.ObjectReference{
+			Kind:      vgcKind,
+			Namespace: vgc.Namespace,
+			Name:      vgc.Name,
+			UID:       vgc.UID,
+		},
+	}
+	return updateVGClassCreatedVGs(ctx, client, logger, vgClass, func(createdVGs []volumegroupv1.CreatedVolumeGroup) []volumegroupv1.CreatedVolumeGroup {
+		for _, existingVG := range createdVGs {
+			if existingVG.VolumeGroupHandle == vgId {
+				return createdVGs
+			}
+		}
+		logger.Info(fmt.Sprintf(messages.RecordCreatedVG, vgId, vgc.Namespace, vgc.Name, vgClass.Name))
+		return append(createdVGs, createdVG)
+	})
+}
+
+// ForgetCreatedVGs removes the volume groups from the created volume groups of the volumeGroupClass status.
+func ForgetCreatedVGs(ctx context.Context, client client.Client, logger logr.Logger, vgClass *volumegroupv1.VolumeGroupClass,
+	vgIds ...string) error {
+	return updateVGClassCreatedVGs(ctx, client, logger, vgClass, func(createdVGs []volumegroupv1.CreatedVolumeGroup) []volumegroupv1.CreatedVolumeGroup {
+		var keptVGs []volumegroupv1.CreatedVolumeGroup
+		for _, createdVG := range createdVGs {
+			if !commonUtils.Contains(vgIds, createdVG.VolumeGroupHandle) {
+				keptVGs = append(keptVGs, createdVG)
+			}
+		}
+		return keptVGs
+	})
+}
+
+func updateVGClassCreatedVGs(ctx context.Context, client client.Client, logger logr.Logger, vgClass *volumegroupv1.VolumeGroupClass,
+	updateFunc func([]volumegroupv1.CreatedVolumeGroup) []volumegroupv1.CreatedVolumeGroup) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		createdVGs := updateFunc(vgClass.Status.CreatedVolumeGroups)
+		if len(createdVGs) == len(vgClass.Status.CreatedVolumeGroups) {
+			return nil
+		}
+		vgClass.Status.CreatedVolumeGroups = createdVGs
+		err := UpdateObjectStatus(ctx, client, vgClass)
+		if apierrors.IsConflict(err) {
+			if uErr := getNamespacedObject(ctx, client, vgClass); uErr != nil {
+				return uErr
+			}
+			logger.Info(fmt.Sprintf(messages.RetryUpdateVGClassStatus, vgClass.Name))
+		}
+		return err
+	})
+	if err != nil {
+		logger.Error(err, "failed to update volumeGroupClass status", "VGClassName", vgClass.Name)
+		return err
+	}
+	return nil
+}
+
+// ValidateVGClassCapabilities returns an error when settings of the volumeGroupClass need operations its driver does not support.
+func ValidateVGClassCapabilities(vgClass *volumegroupv1.VolumeGroupClass, capabilities grpcClient.Capabilities) error {
+	var unsupportedSettings []string
+	if GetBoolField(vgClass, "SupportVolumeGroupSnapshot") && !capabilities.VolumeGroupSnapshot {
+		unsupportedSettings = append(unsupportedSettings, "supportVolumeGroupSnapshot")
+	}
+	if IsMembershipDriftRemediated(vgClass) && (!capabilities.ControllerGetVolumeGroup || !capabilities.ModifyVolumeGroupMembership) {
+		unsupportedSettings = append(unsupportedSettings, "membershipDriftPolicy")
+	}
+	if vgClass.VolumeGroupDiscovery != nil && !capabilities.ListVolumeGroups {
+		unsupportedSettings = append(unsupportedSettings, "volumeGroupDiscovery")
+	}
+	if GetBoolField(vgClass, "DeleteOrphanedVolumeGroups") && !capabilities.ListVolumeGroups {
+		unsupportedSettings = append(unsupportedSettings, "deleteOrphanedVolumeGroups")
+	}
+	if len(unsupportedSettings) > 0 {
+		return fmt.Errorf(messages.VGClassSettingsAreNotSupported, vgClass.Driver, strings.Join(unsupportedSettings, ", "), vgClass.Name)
+	}
+	return nil
+}
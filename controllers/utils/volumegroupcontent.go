@@ -35,28 +35,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func AddMatchingPVToMatchingVGC(logger logr.Logger, client client.Client,
-	pvc *corev1.PersistentVolumeClaim, vg *volumegroupv1.VolumeGroup) error {
-	pv, err := GetPVFromPVC(logger, client, pvc)
-	if err != nil {
-		return err
-	}
-	vgc, err := GetVGC(client, logger, GetStringField(vg.Spec.Source, "VolumeGroupContentName"), vg.Namespace)
+func AddMatchingPVToMatchingVGC(ctx context.Context, logger logr.Logger, client client.Client,
+	pv *corev1.PersistentVolume, vg *volumegroupv1.VolumeGroup) error {
+	vgc, err := GetVGC(ctx, client, logger, GetStringField(vg.Spec.Source, "VolumeGroupContentName"), vg.Namespace)
 	if err != nil {
 		return err
 	}
 
 	if pv != nil {
-		return addPVToVGC(logger, client, pv, vgc)
+		return addPVToVGC(ctx, logger, client, pv, vgc)
 	}
 	return nil
 }
 
-func GetVGC(client client.Client, logger logr.Logger, vgcName string, vgcNamespace string) (*volumegroupv1.VolumeGroupContent, error) {
+func GetVGC(ctx context.Context, client client.Client, logger logr.Logger, vgcName string, vgcNamespace string) (*volumegroupv1.VolumeGroupContent, error) {
 	logger.Info(fmt.Sprintf(messages.GetVGC, vgcName, vgcNamespace))
 	vgc := &volumegroupv1.VolumeGroupContent{}
 	namespacedVGC := types.NamespacedName{Name: vgcName, Namespace: vgcNamespace}
-	err := client.Get(context.TODO(), namespacedVGC, vgc)
+	err := client.Get(ctx, namespacedVGC, vgc)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			logger.Error(err, "VolumeGroupContent not found", "VolumeGroupContent Name", vgcName)
@@ -67,8 +63,8 @@ func GetVGC(client client.Client, logger logr.Logger, vgcName string, vgcNamespa
 	return vgc, nil
 }
 
-func CreateVGC(client client.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
-	err := client.Create(context.TODO(), vgc)
+func CreateVGC(ctx context.Context, client client.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
+	err := client.Create(ctx, vgc)
 	if err != nil {
 		if errors.IsAlreadyExists(err) {
 			logger.Info("VolumeGroupContent is already exists")
@@ -80,20 +76,20 @@ func CreateVGC(client client.Client, logger logr.Logger, vgc *volumegroupv1.Volu
 	return err
 }
 
-func CreateSuccessVGCEvent(logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent) error {
+func CreateSuccessVGCEvent(ctx context.Context, logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent) error {
 	vgc.APIVersion = APIVersion
 	vgc.Kind = vgcKind
 	message := fmt.Sprintf(messages.VGCCreated, vgc.Namespace, vgc.Name)
-	err := createSuccessNamespacedObjectEvent(logger, client, vgc, message, createVGC)
+	err := createSuccessNamespacedObjectEvent(ctx, logger, client, vgc, message, createVGC)
 	if err != nil {
 		return nil
 	}
 	return nil
 }
 
-func UpdateVGCStatus(client client.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent, groupCreationTime *metav1.Time, ready bool) error {
+func UpdateVGCStatus(ctx context.Context, client client.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent, groupCreationTime *metav1.Time, ready bool) error {
 	updateVGCStatusFields(vgc, groupCreationTime, ready)
-	if err := UpdateObjectStatus(client, vgc); err != nil {
+	if err := UpdateObjectStatus(ctx, client, vgc); err != nil {
 		logger.Error(err, "failed to update status")
 		return err
 	}
@@ -102,7 +98,14 @@ func UpdateVGCStatus(client client.Client, logger logr.Logger, vgc *volumegroupv
 
 func updateVGCStatusFields(vgc *volumegroupv1.VolumeGroupContent, groupCreationTime *metav1.Time, ready bool) {
 	vgc.Status.GroupCreationTime = groupCreationTime
-	vgc.Status.Ready = &ready
+	if ready {
+		SetVGCConditions(vgc,
+			GenerateCondition(volumegroupv1.ConditionBackendGroupCreated, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, ""),
+			GenerateCondition(volumegroupv1.ConditionReady, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, ""))
+	} else {
+		SetVGCConditions(vgc, GenerateCondition(volumegroupv1.ConditionReady, metav1.ConditionFalse, volumegroupv1.ReasonPending,
+			fmt.Sprintf(messages.VGCWithoutVGClass, vgc.Namespace, vgc.Name)))
+	}
 }
 
 func GenerateVGC(vgname string, instance *volumegroupv1.VolumeGroup, vgClass *volumegroupv1.VolumeGroupClass, secretName string, secretNamespace string) *volumegroupv1.VolumeGroupContent {
@@ -118,7 +121,7 @@ func GenerateVGC(vgname string, instance *volumegroupv1.VolumeGroup, vgClass *vo
 func generateVGCSpec(instance *volumegroupv1.VolumeGroup, vgClass *volumegroupv1.VolumeGroupClass,
 	secretName string, secretNamespace string) volumegroupv1.VolumeGroupContentSpec {
 	vgClassName := GetStringField(instance.Spec, "VolumeGroupClassName")
-	supportVolumeGroupSnapshot := false
+	supportVolumeGroupSnapshot := GetBoolField(vgClass, "SupportVolumeGroupSnapshot")
 	return volumegroupv1.VolumeGroupContentSpec{
 		VolumeGroupClassName:       &vgClassName,
 		VolumeGroupRef:             generateObjectReference(instance),
@@ -161,39 +164,33 @@ func generateVGCSource(vgClass *volumegroupv1.VolumeGroupClass) *volumegroupv1.V
 	}
 }
 
-func RemovePVFromVGC(logger logr.Logger, client client.Client, pv *corev1.PersistentVolume, vgc *volumegroupv1.VolumeGroupContent) error {
-	logger.Info(fmt.Sprintf(messages.RemovePVFromVGC, pv.Name, vgc.Namespace, vgc.Name))
-	vgc.Status.PVList = removeFromPVList(pv, vgc.Status.PVList)
-	err := updateVGCStatusPVList(client, vgc, logger, vgc.Status.PVList)
+func RemovePVFromVGC(ctx context.Context, logger logr.Logger, client client.Client, pvName string, vgc *volumegroupv1.VolumeGroupContent) error {
+	logger.Info(fmt.Sprintf(messages.RemovePVFromVGC, pvName, vgc.Namespace, vgc.Name))
+	currentMembers := vgc.Status.Members
+	err := updateVGCStatusMembers(ctx, client, vgc, logger, func(members []volumegroupv1.VolumeGroupMemberReference) []volumegroupv1.VolumeGroupMemberReference {
+		return removeMember(members, "", pvName)
+	})
 	if err != nil {
-		vgc.Status.PVList = appendPV(vgc.Status.PVList, *pv)
+		vgc.Status.Members = currentMembers
 		logger.Error(err, fmt.Sprintf(messages.FailedToRemovePVFromVGC,
-			pv.Name, vgc.Namespace, vgc.Name))
+			pvName, vgc.Namespace, vgc.Name))
 		return err
 	}
 	logger.Info(fmt.Sprintf(messages.RemovedPVFromVGC,
-		pv.Name, vgc.Namespace, vgc.Name))
+		pvName, vgc.Namespace, vgc.Name))
 	return nil
 }
 
-func removeFromPVList(pv *corev1.PersistentVolume, pvList []corev1.PersistentVolume) []corev1.PersistentVolume {
-	for index, pvFromList := range pvList {
-		if pvFromList.Name == pv.Name && pvFromList.Namespace == pv.Namespace {
-			pvList = removeByIndexFromPVList(pvList, index)
-			return pvList
-		}
-	}
-	return pvList
-}
-
-func addPVToVGC(logger logr.Logger, client client.Client, pv *corev1.PersistentVolume,
+func addPVToVGC(ctx context.Context, logger logr.Logger, client client.Client, pv *corev1.PersistentVolume,
 	vgc *volumegroupv1.VolumeGroupContent) error {
 	logger.Info(fmt.Sprintf(messages.AddPVToVG,
 		pv.Name, vgc.Namespace, vgc.Name))
-	vgc.Status.PVList = appendPV(vgc.Status.PVList, *pv)
-	err := updateVGCStatusPVList(client, vgc, logger, vgc.Status.PVList)
+	currentMembers := vgc.Status.Members
+	err := updateVGCStatusMembers(ctx, client, vgc, logger, func(members []volumegroupv1.VolumeGroupMemberReference) []volumegroupv1.VolumeGroupMemberReference {
+		return appendMember(members, GenerateVGCMember(pv))
+	})
 	if err != nil {
-		vgc.Status.PVList = removeFromPVList(pv, vgc.Status.PVList)
+		vgc.Status.Members = currentMembers
 		logger.Error(err, fmt.Sprintf(messages.FailedToAddPVToVGC,
 			pv.Name, vgc.Namespace, vgc.Name))
 		return err
@@ -203,21 +200,13 @@ func addPVToVGC(logger logr.Logger, client client.Client, pv *corev1.PersistentV
 	return nil
 }
 
-func appendPV(pvListInVGC []corev1.PersistentVolume, pv corev1.PersistentVolume) []corev1.PersistentVolume {
-	for _, pvFromList := range pvListInVGC {
-		if pvFromList.Name == pv.Name {
-			return pvListInVGC
-		}
-	}
-	pvListInVGC = append(pvListInVGC, pv)
-	return pvListInVGC
-}
-
-func updateVGCStatusPVList(client client.Client, vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger,
-	pvList []corev1.PersistentVolume) error {
+// updateVGCStatusMembers applies the change to the members of the volumeGroupContent status. The status holds one member
+// per persistentVolume and is written whole, so the change is applied again to the current members on a conflict.
+func updateVGCStatusMembers(ctx context.Context, client client.Client, vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger,
+	updateMembers func([]volumegroupv1.VolumeGroupMemberReference) []volumegroupv1.VolumeGroupMemberReference) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		vgc.Status.PVList = pvList
-		err := vgcRetryOnConflictFunc(client, vgc, logger)
+		vgc.Status.Members = updateMembers(vgc.Status.Members)
+		err := vgcRetryOnConflictFunc(ctx, client, vgc, logger)
 		return err
 	})
 	if err != nil {
@@ -227,19 +216,22 @@ func updateVGCStatusPVList(client client.Client, vgc *volumegroupv1.VolumeGroupC
 	return nil
 }
 
-func UpdateVGCStatusError(client client.Client, vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger, message string) error {
+func UpdateVGCStatusConditions(ctx context.Context, client client.Client, vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger,
+	conditions ...metav1.Condition) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		vgc.Status.Error = &volumegroupv1.VolumeGroupError{Message: &message}
-		err := vgcRetryOnConflictFunc(client, vgc, logger)
+		if !SetVGCConditions(vgc, conditions...) {
+			return nil
+		}
+		err := vgcRetryOnConflictFunc(ctx, client, vgc, logger)
 		return err
 	})
 	return err
 }
 
-func vgcRetryOnConflictFunc(client client.Client, vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger) error {
-	err := UpdateObjectStatus(client, vgc)
+func vgcRetryOnConflictFunc(ctx context.Context, client client.Client, vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger) error {
+	err := UpdateObjectStatus(ctx, client, vgc)
 	if apierrors.IsConflict(err) {
-		uErr := getNamespacedObject(client, vgc)
+		uErr := getNamespacedObject(ctx, client, vgc)
 		if uErr != nil {
 			return uErr
 		}
@@ -248,27 +240,27 @@ func vgcRetryOnConflictFunc(client client.Client, vgc *volumegroupv1.VolumeGroup
 	return err
 }
 
-func UpdateStaticVGCFromVG(client client.Client, vg *volumegroupv1.VolumeGroup, vgClass *volumegroupv1.VolumeGroupClass, logger logr.Logger) error {
-	vgc, err := GetVGC(client, logger, *vg.Spec.Source.VolumeGroupContentName, vg.Namespace)
+func UpdateStaticVGCFromVG(ctx context.Context, client client.Client, vg *volumegroupv1.VolumeGroup, vgClass *volumegroupv1.VolumeGroupClass, logger logr.Logger) error {
+	vgc, err := GetVGC(ctx, client, logger, *vg.Spec.Source.VolumeGroupContentName, vg.Namespace)
 	if err != nil {
 		return err
 	}
 	vgc.Spec.VolumeGroupRef = generateObjectReference(vg)
 	updateStaticVGCSpec(vgClass, vgc)
-	if err = UpdateObject(client, vgc); err != nil {
+	if err = UpdateObject(ctx, client, vgc); err != nil {
 		return err
 	}
 	return nil
 }
 
-func UpdateStaticVGC(client client.Client, vgcNamespace, vgcName string,
+func UpdateStaticVGC(ctx context.Context, client client.Client, vgcNamespace, vgcName string,
 	vgClass *volumegroupv1.VolumeGroupClass, logger logr.Logger) error {
-	vgc, err := GetVGC(client, logger, vgcName, vgcNamespace)
+	vgc, err := GetVGC(ctx, client, logger, vgcName, vgcNamespace)
 	if err != nil {
 		return err
 	}
 	updateStaticVGCSpec(vgClass, vgc)
-	if err = UpdateObject(client, vgc); err != nil {
+	if err = UpdateObject(ctx, client, vgc); err != nil {
 		return err
 	}
 	return nil
@@ -288,13 +280,13 @@ func updateStaticVGCSpec(vgClass *volumegroupv1.VolumeGroupClass, vgc *volumegro
 	}
 }
 
-func UpdateThinVGC(client client.Client, vgcNamespace, vgcName string, logger logr.Logger) error {
-	vgc, err := GetVGC(client, logger, vgcName, vgcNamespace)
+func UpdateThinVGC(ctx context.Context, client client.Client, vgcNamespace, vgcName string, logger logr.Logger) error {
+	vgc, err := GetVGC(ctx, client, logger, vgcName, vgcNamespace)
 	if err != nil {
 		return err
 	}
 	updateThinVGCSpec(vgc)
-	if err = UpdateObject(client, vgc); err != nil {
+	if err = UpdateObject(ctx, client, vgc); err != nil {
 		return err
 	}
 	return nil
@@ -307,41 +299,73 @@ func updateThinVGCSpec(vgc *volumegroupv1.VolumeGroupContent) {
 	}
 }
 
-func UpdateVGCByResponse(client client.Client, vgc *volumegroupv1.VolumeGroupContent, resp *volumegroup.Response) error {
+func GetCreatedVGId(resp *volumegroup.Response) string {
+	return resp.Response.(*csi.CreateVolumeGroupResponse).GetVolumeGroup().GetVolumeGroupId()
+}
+
+func UpdateVGCByResponse(ctx context.Context, client client.Client, vgc *volumegroupv1.VolumeGroupContent, resp *volumegroup.Response) error {
 	CreateVGResponse := resp.Response.(*csi.CreateVolumeGroupResponse)
 	vgc.Spec.Source.VolumeGroupHandle = CreateVGResponse.VolumeGroup.VolumeGroupId
 	vgc.Spec.Source.VolumeGroupAttributes = CreateVGResponse.VolumeGroup.VolumeGroupContext
-	if err := UpdateObject(client, vgc); err != nil {
+	delete(vgc.Annotations, VGCreationInProgressKey)
+	if err := UpdateObject(ctx, client, vgc); err != nil {
 		return err
 	}
 	return nil
 }
 
-func DeletePVCsUnderVGC(logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent, driver string) error {
+func DeletePVCsUnderVGC(ctx context.Context, logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent, driver string) error {
 	logger.Info(fmt.Sprintf(messages.DeletePVCsUnderVGC, vgc.Namespace, vgc.Name))
-	for _, pv := range vgc.Status.PVList {
-		pvcName := getPVCNameFromPV(pv)
-		pvcNamespace := getPVCNamespaceFromPV(pv)
+	for _, member := range vgc.Status.Members {
+		var pvcName, pvcNamespace string
+		if member.ClaimRef != nil {
+			pvcName = member.ClaimRef.Name
+			pvcNamespace = member.ClaimRef.Namespace
+		}
 		if pvcNamespace == "" || pvcName == "" {
-			pvc, err := getMatchingPVCFromPVCListToPV(logger, client, pv.Name, driver)
+			pvc, err := getMatchingPVCFromPVCListToPV(ctx, logger, client, member.Name, driver)
 			if err != nil {
 				return err
 			}
 			if pvc.Name == "" || pvc.Namespace == "" {
-				logger.Info(fmt.Sprintf(messages.CannotFindMatchingPVCForPV, pv.Name))
+				logger.Info(fmt.Sprintf(messages.CannotFindMatchingPVCForPV, member.Name))
 				continue
 			}
 			pvcName = pvc.Name
 			pvcNamespace = pvc.Namespace
 		}
-		err := deletePVC(logger, client, pvcName, pvcNamespace, driver)
+		err := deletePVC(ctx, logger, client, pvcName, pvcNamespace, driver)
 		if err != nil {
 			return err
 		}
-		err = RemovePVFromVGC(logger, client, &pv, vgc)
+		err = RemovePVFromVGC(ctx, logger, client, member.Name, vgc)
 		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// MarkVGCreationInProgress persists that a CreateVolumeGroup call is issued for the volumeGroupContent,
+// so that an interrupted creation is resumed instead of being reported as a failure.
+func MarkVGCreationInProgress(ctx context.Context, client client.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
+	if IsVGCreationInProgress(vgc) {
+		return nil
+	}
+	logger.Info(fmt.Sprintf(messages.MarkVGCreationInProgress, vgc.Namespace, vgc.Name))
+	metav1.SetMetaDataAnnotation(&vgc.ObjectMeta, VGCreationInProgressKey, "true")
+	return UpdateObject(ctx, client, vgc)
+}
+
+func UnmarkVGCreationInProgress(ctx context.Context, client client.Client, vgc *volumegroupv1.VolumeGroupContent) error {
+	if !IsVGCreationInProgress(vgc) {
+		return nil
+	}
+	delete(vgc.Annotations, VGCreationInProgressKey)
+	return UpdateObject(ctx, client, vgc)
+}
+
+func IsVGCreationInProgress(vgc *volumegroupv1.VolumeGroupContent) bool {
+	_, ok := vgc.Annotations[VGCreationInProgressKey]
+	return ok
+}
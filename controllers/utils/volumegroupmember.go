@@ -0,0 +1,358 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsVGMembersInObjects returns whether the members of the volumeGroup are stored in volumeGroupMember objects
+// instead of the volumeGroup status. The member counts are set only while they are stored in volumeGroupMembers,
+// the useVolumeGroupMembers field of the volumeGroupClass is read once per reconcile by moveVGMembers.
+func IsVGMembersInObjects(vg *volumegroupv1.VolumeGroup) bool {
+	return vg.Status.MemberCounts != nil
+}
+
+// GetVGMembers returns the members of the volumeGroup from its status or from its volumeGroupMembers.
+func GetVGMembers(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
+	vg *volumegroupv1.VolumeGroup) ([]volumegroupv1.VolumeGroupMemberReference, error) {
+	if !IsVGMembersInObjects(vg) {
+		return vg.Status.Members, nil
+	}
+	vgms, err := listVGMs(ctx, logger, client, vg)
+	if err != nil {
+		return nil, err
+	}
+	return generateMembersFromVGMs(vgms), nil
+}
+
+// IsPVCInVG returns whether the persistentVolumeClaim is a member of the volumeGroup.
+func IsPVCInVG(ctx context.Context, logger logr.Logger, client runtimeclient.Client, pvc *corev1.PersistentVolumeClaim,
+	vg *volumegroupv1.VolumeGroup) (bool, error) {
+	if !IsVGMembersInObjects(vg) {
+		return IsPVCInMembers(pvc, vg.Status.Members), nil
+	}
+	vgm := &volumegroupv1.VolumeGroupMember{}
+	namespacedVGM := types.NamespacedName{Name: getVGMName(vg, pvc.Namespace, pvc.Name), Namespace: vg.Namespace}
+	if err := client.Get(ctx, namespacedVGM, vgm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// UpdateVGMemberCounts sets the member counts of a volumeGroup whose members are stored in volumeGroupMembers.
+func UpdateVGMemberCounts(ctx context.Context, logger logr.Logger, client runtimeclient.Client, vg *volumegroupv1.VolumeGroup) error {
+	if !IsVGMembersInObjects(vg) {
+		return nil
+	}
+	vgms, err := listVGMs(ctx, logger, client, vg)
+	if err != nil {
+		return err
+	}
+	memberCounts := generateVGMemberCounts(generateMembersFromVGMs(vgms))
+	if equality.Semantic.DeepEqual(vg.Status.MemberCounts, memberCounts) {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vg.Status.MemberCounts = memberCounts
+		return vgRetryOnConflictFunc(ctx, client, vg, logger)
+	})
+}
+
+// RecordVGMemberError sets the last error of the volumeGroupMember of the persistentVolumeClaim, if there is one.
+func RecordVGMemberError(ctx context.Context, logger logr.Logger, client runtimeclient.Client, vg *volumegroupv1.VolumeGroup,
+	pvcNamespace, pvcName string, vgErr error) {
+	if !IsVGMembersInObjects(vg) {
+		return
+	}
+	vgm := &volumegroupv1.VolumeGroupMember{}
+	namespacedVGM := types.NamespacedName{Name: getVGMName(vg, pvcNamespace, pvcName), Namespace: vg.Namespace}
+	if err := client.Get(ctx, namespacedVGM, vgm); err != nil {
+		return
+	}
+	status := vgm.Status
+	status.LastError = GetMessageFromError(vgErr)
+	_ = updateVGMStatus(ctx, logger, client, vgm, status)
+}
+
+func addVGMember(ctx context.Context, logger logr.Logger, client runtimeclient.Client, vg *volumegroupv1.VolumeGroup,
+	member volumegroupv1.VolumeGroupMemberReference) error {
+	if IsVGMembersInObjects(vg) {
+		return createVGM(ctx, logger, client, vg, member, "")
+	}
+	currentMembers := vg.Status.Members
+	err := updateVGStatusMembers(ctx, client, vg, logger, upsertMember(vg.Status.Members, member))
+	if err != nil {
+		vg.Status.Members = currentMembers
+	}
+	return err
+}
+
+func removeVGMember(ctx context.Context, logger logr.Logger, client runtimeclient.Client, vg *volumegroupv1.VolumeGroup,
+	member volumegroupv1.VolumeGroupMemberReference) error {
+	if IsVGMembersInObjects(vg) {
+		return deleteVGM(ctx, logger, client, vg, member)
+	}
+	currentMembers := vg.Status.Members
+	err := updateVGStatusMembers(ctx, client, vg, logger, removeMember(vg.Status.Members, member.Namespace, member.Name))
+	if err != nil {
+		vg.Status.Members = currentMembers
+	}
+	return err
+}
+
+// setVGMembers stores the members of the volumeGroup in its status or in its volumeGroupMembers,
+// only the volumeGroupMembers of changed members are updated. The last error is set on the members with a reason.
+func setVGMembers(ctx context.Context, logger logr.Logger, client runtimeclient.Client, vg *volumegroupv1.VolumeGroup,
+	currentMembers, members []volumegroupv1.VolumeGroupMemberReference, lastError string) error {
+	if !IsVGMembersInObjects(vg) {
+		if equality.Semantic.DeepEqual(vg.Status.Members, members) {
+			return nil
+		}
+		currentStatusMembers := vg.Status.Members
+		if err := updateVGStatusMembers(ctx, client, vg, logger, members); err != nil {
+			vg.Status.Members = currentStatusMembers
+			return err
+		}
+		return nil
+	}
+	for _, member := range members {
+		if isMemberUnchanged(member, currentMembers) {
+			continue
+		}
+		memberError := ""
+		if member.Reason != "" {
+			memberError = lastError
+		}
+		if err := createVGM(ctx, logger, client, vg, member, memberError); err != nil {
+			return err
+		}
+	}
+	return UpdateVGMemberCounts(ctx, logger, client, vg)
+}
+
+func isMemberUnchanged(member volumegroupv1.VolumeGroupMemberReference, members []volumegroupv1.VolumeGroupMemberReference) bool {
+	for _, memberFromList := range members {
+		if memberFromList.Name == member.Name && memberFromList.Namespace == member.Namespace {
+			return equality.Semantic.DeepEqual(memberFromList, member)
+		}
+	}
+	return false
+}
+
+// moveVGMembers moves the members of the volumeGroup between its status and volumeGroupMembers
+// when the useVolumeGroupMembers field of its volumeGroupClass changes.
+func moveVGMembers(ctx context.Context, logger logr.Logger, client runtimeclient.Client, vg *volumegroupv1.VolumeGroup) error {
+	vgClass, err := GetVGClass(ctx, client, logger, GetStringField(vg.Spec, "VolumeGroupClassName"))
+	if err != nil {
+		return err
+	}
+	isInObjects := GetBoolField(vgClass, "UseVolumeGroupMembers")
+	if isInObjects == IsVGMembersInObjects(vg) {
+		return nil
+	}
+	if isInObjects {
+		logger.Info(fmt.Sprintf(messages.MoveVGMembersToObjects, vg.Namespace, vg.Name))
+		for _, member := range vg.Status.Members {
+			if err = createVGM(ctx, logger, client, vg, member, ""); err != nil {
+				return err
+			}
+		}
+		return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			vg.Status.MemberCounts = generateVGMemberCounts(vg.Status.Members)
+			vg.Status.Members = nil
+			return vgRetryOnConflictFunc(ctx, client, vg, logger)
+		})
+	}
+
+	logger.Info(fmt.Sprintf(messages.MoveVGMembersToStatus, vg.Namespace, vg.Name))
+	vgms, err := listVGMs(ctx, logger, client, vg)
+	if err != nil {
+		return err
+	}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vg.Status.Members = generateMembersFromVGMs(vgms)
+		vg.Status.MemberCounts = nil
+		return vgRetryOnConflictFunc(ctx, client, vg, logger)
+	})
+	if err != nil {
+		return err
+	}
+	for _, vgm := range vgms {
+		if err = deleteVGM(ctx, logger, client, vg, generateMemberFromVGM(vgm)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func listVGMs(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
+	vg *volumegroupv1.VolumeGroup) ([]volumegroupv1.VolumeGroupMember, error) {
+	vgmList := &volumegroupv1.VolumeGroupMemberList{}
+	err := client.List(ctx, vgmList, runtimeclient.InNamespace(vg.Namespace),
+		runtimeclient.MatchingLabels{VGMVolumeGroupUIDLabel: string(vg.UID)})
+	if err != nil {
+		logger.Error(err, fmt.Sprintf(messages.FailedToListVGMs, vg.Namespace, vg.Name))
+		return nil, err
+	}
+	return vgmList.Items, nil
+}
+
+func createVGM(ctx context.Context, logger logr.Logger, client runtimeclient.Client, vg *volumegroupv1.VolumeGroup,
+	member volumegroupv1.VolumeGroupMemberReference, lastError string) error {
+	vgm := generateVGM(vg, member)
+	logger.Info(fmt.Sprintf(messages.CreateVGM, vgm.Namespace, vgm.Name, member.Namespace, member.Name))
+	if err := client.Create(ctx, vgm); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			logger.Error(err, fmt.Sprintf(messages.FailedToCreateVGM, vgm.Namespace, vgm.Name))
+			return err
+		}
+		if err = getNamespacedObject(ctx, client, vgm); err != nil {
+			return err
+		}
+	}
+	status := generateVGMStatus(member)
+	status.LastError = lastError
+	return updateVGMStatus(ctx, logger, client, vgm, status)
+}
+
+func deleteVGM(ctx context.Context, logger logr.Logger, client runtimeclient.Client, vg *volumegroupv1.VolumeGroup,
+	member volumegroupv1.VolumeGroupMemberReference) error {
+	vgm := &volumegroupv1.VolumeGroupMember{ObjectMeta: metav1.ObjectMeta{
+		Name:      getVGMName(vg, member.Namespace, member.Name),
+		Namespace: vg.Namespace,
+	}}
+	logger.Info(fmt.Sprintf(messages.DeleteVGM, vgm.Namespace, vgm.Name, member.Namespace, member.Name))
+	if err := client.Delete(ctx, vgm); err != nil && !apierrors.IsNotFound(err) {
+		logger.Error(err, fmt.Sprintf(messages.FailedToDeleteVGM, vgm.Namespace, vgm.Name))
+		return err
+	}
+	return nil
+}
+
+func updateVGMStatus(ctx context.Context, logger logr.Logger, client runtimeclient.Client, vgm *volumegroupv1.VolumeGroupMember,
+	status volumegroupv1.VolumeGroupMemberStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if equality.Semantic.DeepEqual(vgm.Status, status) {
+			return nil
+		}
+		vgm.Status = status
+		err := UpdateObjectStatus(ctx, client, vgm)
+		if apierrors.IsConflict(err) {
+			if uErr := getNamespacedObject(ctx, client, vgm); uErr != nil {
+				return uErr
+			}
+			return err
+		}
+		if err != nil {
+			logger.Error(err, fmt.Sprintf(messages.FailedToUpdateVGMStatus, vgm.Namespace, vgm.Name))
+		}
+		return err
+	})
+}
+
+func generateVGM(vg *volumegroupv1.VolumeGroup, member volumegroupv1.VolumeGroupMemberReference) *volumegroupv1.VolumeGroupMember {
+	return &volumegroupv1.VolumeGroupMember{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getVGMName(vg, member.Namespace, member.Name),
+			Namespace: vg.Namespace,
+			Labels:    map[string]string{VGMVolumeGroupUIDLabel: string(vg.UID)},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(vg, volumegroupv1.GroupVersion.WithKind(vgKind)),
+			},
+		},
+		Spec: volumegroupv1.VolumeGroupMemberSpec{
+			VolumeGroupName: vg.Name,
+			PersistentVolumeClaim: volumegroupv1.VolumeGroupMemberClaimReference{
+				Name:      member.Name,
+				Namespace: member.Namespace,
+				UID:       member.UID,
+			},
+		},
+	}
+}
+
+func generateVGMStatus(member volumegroupv1.VolumeGroupMemberReference) volumegroupv1.VolumeGroupMemberStatus {
+	return volumegroupv1.VolumeGroupMemberStatus{
+		VolumeHandle:     member.VolumeHandle,
+		Capacity:         member.Capacity,
+		StorageClassName: member.StorageClassName,
+		State:            member.State,
+		Reason:           member.Reason,
+	}
+}
+
+func generateMemberFromVGM(vgm volumegroupv1.VolumeGroupMember) volumegroupv1.VolumeGroupMemberReference {
+	return volumegroupv1.VolumeGroupMemberReference{
+		Name:             vgm.Spec.PersistentVolumeClaim.Name,
+		Namespace:        vgm.Spec.PersistentVolumeClaim.Namespace,
+		UID:              vgm.Spec.PersistentVolumeClaim.UID,
+		VolumeHandle:     vgm.Status.VolumeHandle,
+		Capacity:         vgm.Status.Capacity,
+		StorageClassName: vgm.Status.StorageClassName,
+		State:            vgm.Status.State,
+		Reason:           vgm.Status.Reason,
+	}
+}
+
+func generateMembersFromVGMs(vgms []volumegroupv1.VolumeGroupMember) []volumegroupv1.VolumeGroupMemberReference {
+	members := []volumegroupv1.VolumeGroupMemberReference{}
+	for _, vgm := range vgms {
+		members = append(members, generateMemberFromVGM(vgm))
+	}
+	return members
+}
+
+func generateVGMemberCounts(members []volumegroupv1.VolumeGroupMemberReference) *volumegroupv1.VolumeGroupMemberCounts {
+	memberCounts := &volumegroupv1.VolumeGroupMemberCounts{Total: int32(len(members))}
+	for _, member := range members {
+		switch member.State {
+		case volumegroupv1.MemberPending:
+			memberCounts.Pending++
+		case volumegroupv1.MemberLeaving:
+			memberCounts.Leaving++
+		case volumegroupv1.MemberFailed:
+			memberCounts.Failed++
+		default:
+			memberCounts.Joined++
+		}
+	}
+	return memberCounts
+}
+
+// getVGMName returns a stable name for the volumeGroupMember of a persistentVolumeClaim, the
+// persistentVolumeClaim may be in another namespace than its volumeGroupMember.
+func getVGMName(vg *volumegroupv1.VolumeGroup, pvcNamespace, pvcName string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", vg.UID, pvcNamespace, pvcName)))
+	return fmt.Sprintf("%s-%x", VGMNamePrefix, hash[:8])
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func GetVGS(ctx context.Context, client client.Client, logger logr.Logger, vgsName string, vgsNamespace string) (*volumegroupv1.VolumeGroupSnapshot, error) {
+	logger.Info(fmt.Sprintf(messages.GetVGS, vgsNamespace, vgsName))
+	vgs := &volumegroupv1.VolumeGroupSnapshot{}
+	namespacedVGS := types.NamespacedName{Name: vgsName, Namespace: vgsNamespace}
+	err := client.Get(ctx, namespacedVGS, vgs)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Error(err, "VolumeGroupSnapshot not found", "VolumeGroupSnapshot Name", vgsName)
+		}
+		return nil, err
+	}
+	return vgs, nil
+}
+
+func IsVgsExist(ctx context.Context, client client.Client, logger logr.Logger, vgsc *volumegroupv1.VolumeGroupSnapshotContent) (bool, error) {
+	if vgsc.Spec.VolumeGroupSnapshotRef != nil {
+		if vgs, err := GetVGS(ctx, client, logger, vgsc.Spec.VolumeGroupSnapshotRef.Name, vgsc.Spec.VolumeGroupSnapshotRef.Namespace); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return false, err
+			}
+		} else {
+			return vgs.UID == vgsc.Spec.VolumeGroupSnapshotRef.UID, nil
+		}
+	}
+	return false, nil
+}
+
+func UpdateVGSStatus(ctx context.Context, client client.Client, vgs *volumegroupv1.VolumeGroupSnapshot, vgscName string,
+	creationTime *metav1.Time, logger logr.Logger) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vgs.Status.BoundVolumeGroupSnapshotContentName = &vgscName
+		vgs.Status.CreationTime = creationTime
+		SetVGSConditions(vgs, GenerateCondition(volumegroupv1.ConditionBound, metav1.ConditionTrue,
+			volumegroupv1.ReasonSucceeded, fmt.Sprintf(messages.VGSBoundToVGSC, vgs.Namespace, vgs.Name, vgscName)))
+		return vgsRetryOnConflictFunc(ctx, client, vgs, logger)
+	})
+	return err
+}
+
+func UpdateVGSStatusConditions(ctx context.Context, client client.Client, vgs *volumegroupv1.VolumeGroupSnapshot, logger logr.Logger,
+	conditions ...metav1.Condition) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if !SetVGSConditions(vgs, conditions...) {
+			return nil
+		}
+		return vgsRetryOnConflictFunc(ctx, client, vgs, logger)
+	})
+	return err
+}
+
+func vgsRetryOnConflictFunc(ctx context.Context, client client.Client, vgs *volumegroupv1.VolumeGroupSnapshot, logger logr.Logger) error {
+	err := UpdateObjectStatus(ctx, client, vgs)
+	if apierrors.IsConflict(err) {
+		uErr := getNamespacedObject(ctx, client, vgs)
+		if uErr != nil {
+			return uErr
+		}
+		logger.Info(fmt.Sprintf(messages.RetryUpdateVGSStatus, vgs.Namespace, vgs.Name))
+	}
+	return err
+}
+
+func generateVGSObjectReference(vgs *volumegroupv1.VolumeGroupSnapshot) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		Kind:            vgsKind,
+		Namespace:       vgs.Namespace,
+		Name:            vgs.Name,
+		UID:             vgs.UID,
+		APIVersion:      APIVersion,
+		ResourceVersion: vgs.ResourceVersion,
+	}
+}
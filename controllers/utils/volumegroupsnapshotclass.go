@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func GetVGSClass(ctx context.Context, client client.Client, logger logr.Logger, vgsClassName string) (*volumegroupv1.VolumeGroupSnapshotClass, error) {
+	if vgsClassName == "" {
+		return nil, fmt.Errorf("VolumeGroupSnapshotClass name is empty")
+	}
+	vgsClass := &volumegroupv1.VolumeGroupSnapshotClass{}
+	err := client.Get(ctx, types.NamespacedName{Name: vgsClassName}, vgsClass)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Error(err, "VolumeGroupSnapshotClass not found", "VolumeGroupSnapshotClass Name", vgsClassName)
+		} else {
+			logger.Error(err, "Got an unexpected error while fetching VolumeGroupSnapshotClass", "VolumeGroupSnapshotClass", vgsClassName)
+		}
+
+		return nil, err
+	}
+	return vgsClass, nil
+}
+
+func getVolumeGroupSnapshotDeletionPolicy(vgsClass *volumegroupv1.VolumeGroupSnapshotClass) *volumegroupv1.VolumeGroupSnapshotDeletionPolicy {
+	defaultDeletionPolicy := volumegroupv1.VolumeGroupSnapshotContentDelete
+	if vgsClass.DeletionPolicy != nil {
+		return vgsClass.DeletionPolicy
+	}
+	return &defaultDeletionPolicy
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func GetVGSC(ctx context.Context, client client.Client, logger logr.Logger, vgscName string, vgscNamespace string) (*volumegroupv1.VolumeGroupSnapshotContent, error) {
+	logger.Info(fmt.Sprintf(messages.GetVGSC, vgscNamespace, vgscName))
+	vgsc := &volumegroupv1.VolumeGroupSnapshotContent{}
+	namespacedVGSC := types.NamespacedName{Name: vgscName, Namespace: vgscNamespace}
+	err := client.Get(ctx, namespacedVGSC, vgsc)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Error(err, "VolumeGroupSnapshotContent not found", "VolumeGroupSnapshotContent Name", vgscName)
+		}
+		return nil, err
+	}
+
+	return vgsc, nil
+}
+
+func CreateVGSC(ctx context.Context, client client.Client, logger logr.Logger, vgsc *volumegroupv1.VolumeGroupSnapshotContent) error {
+	err := client.Create(ctx, vgsc)
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			logger.Info("VolumeGroupSnapshotContent is already exists")
+			return nil
+		}
+		logger.Error(err, "VolumeGroupSnapshotContent creation failed", "VolumeGroupSnapshotContent Name", vgsc.Name)
+		return err
+	}
+	return nil
+}
+
+func GenerateVGSC(vgscName string, vgs *volumegroupv1.VolumeGroupSnapshot, vgc *volumegroupv1.VolumeGroupContent,
+	vgsClass *volumegroupv1.VolumeGroupSnapshotClass) *volumegroupv1.VolumeGroupSnapshotContent {
+	vgsClassName := vgsClass.Name
+	vgcName := vgc.Name
+	secretName, secretNamespace := getSecretCredFromParameters(vgsClass.Parameters)
+	return &volumegroupv1.VolumeGroupSnapshotContent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vgscName,
+			Namespace: vgs.Namespace,
+		},
+		Spec: volumegroupv1.VolumeGroupSnapshotContentSpec{
+			VolumeGroupSnapshotClassName: &vgsClassName,
+			VolumeGroupSnapshotRef:       generateVGSObjectReference(vgs),
+			Source: volumegroupv1.VolumeGroupSnapshotContentSource{
+				Driver:                 vgsClass.Driver,
+				VolumeGroupContentName: &vgcName,
+			},
+			DeletionPolicy:               getVolumeGroupSnapshotDeletionPolicy(vgsClass),
+			VolumeGroupSnapshotSecretRef: generateSecretReference(secretName, secretNamespace),
+		},
+	}
+}
+
+func UpdateStaticVGSCFromVGS(ctx context.Context, client client.Client, vgs *volumegroupv1.VolumeGroupSnapshot,
+	vgsClass *volumegroupv1.VolumeGroupSnapshotClass, logger logr.Logger) (*volumegroupv1.VolumeGroupSnapshotContent, error) {
+	vgsc, err := GetVGSC(ctx, client, logger, *vgs.Spec.Source.VolumeGroupSnapshotContentName, vgs.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if vgsc.Spec.VolumeGroupSnapshotRef != nil && vgsc.Spec.VolumeGroupSnapshotRef.UID != vgs.UID {
+		return nil, fmt.Errorf(messages.VGSCIsBoundToOtherVGS, vgsc.Namespace, vgsc.Name,
+			vgsc.Spec.VolumeGroupSnapshotRef.Namespace, vgsc.Spec.VolumeGroupSnapshotRef.Name)
+	}
+	vgsc.Spec.VolumeGroupSnapshotRef = generateVGSObjectReference(vgs)
+	if vgsc.Spec.VolumeGroupSnapshotClassName == nil {
+		vgsClassName := vgsClass.Name
+		vgsc.Spec.VolumeGroupSnapshotClassName = &vgsClassName
+	}
+	if vgsc.Spec.VolumeGroupSnapshotSecretRef == nil {
+		secretName, secretNamespace := getSecretCredFromParameters(vgsClass.Parameters)
+		vgsc.Spec.VolumeGroupSnapshotSecretRef = generateSecretReference(secretName, secretNamespace)
+	}
+	if vgsc.Spec.DeletionPolicy == nil {
+		vgsc.Spec.DeletionPolicy = getVolumeGroupSnapshotDeletionPolicy(vgsClass)
+	}
+	if err = UpdateObject(ctx, client, vgsc); err != nil {
+		return nil, err
+	}
+	return vgsc, nil
+}
+
+func IsVGSCReady(vgsc *volumegroupv1.VolumeGroupSnapshotContent) bool {
+	return GetBoolField(vgsc.Status, "ReadyToUse")
+}
+
+// GetVolumeIdsFromVGC returns the CSI volume handles of the persistent volumes in the volume group content
+func GetVolumeIdsFromVGC(vgc *volumegroupv1.VolumeGroupContent) []string {
+	return GetVolumeIdsFromMembers(vgc.Status.Members)
+}
+
+func GetSnapshotIdsFromVGSC(vgsc *volumegroupv1.VolumeGroupSnapshotContent) []string {
+	snapshotIds := []string{}
+	for _, volumeSnapshotHandle := range vgsc.Status.VolumeSnapshotHandles {
+		snapshotIds = append(snapshotIds, volumeSnapshotHandle.SnapshotHandle)
+	}
+	return snapshotIds
+}
+
+func UpdateVGSCSource(ctx context.Context, client client.Client, vgsc *volumegroupv1.VolumeGroupSnapshotContent,
+	groupSnapshot *csi.VolumeGroupSnapshot, logger logr.Logger) error {
+	vgsc.Spec.Source.VolumeGroupSnapshotHandle = groupSnapshot.GroupSnapshotId
+	if err := UpdateObject(ctx, client, vgsc); err != nil {
+		logger.Error(err, "failed to update source", "VGSCName", vgsc.Name)
+		return err
+	}
+	return nil
+}
+
+// UpdateVGSCStatus stores the per volume snapshot handles of the group snapshot in the
+// volume group snapshot content, the persistent volumes are taken from the snapshotted volume group content.
+func UpdateVGSCStatus(ctx context.Context, client client.Client, vgsc *volumegroupv1.VolumeGroupSnapshotContent,
+	groupSnapshot *csi.VolumeGroupSnapshot, members []volumegroupv1.VolumeGroupMemberReference, logger logr.Logger) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		updateVGSCStatusFields(vgsc, groupSnapshot, members)
+		return vgscRetryOnConflictFunc(ctx, client, vgsc, logger)
+	})
+	return err
+}
+
+func updateVGSCStatusFields(vgsc *volumegroupv1.VolumeGroupSnapshotContent,
+	groupSnapshot *csi.VolumeGroupSnapshot, members []volumegroupv1.VolumeGroupMemberReference) {
+	readyToUse := groupSnapshot.ReadyToUse
+	vgsc.Status.ReadyToUse = &readyToUse
+	vgsc.Status.CreationTime = getTimeFromTimestamp(groupSnapshot)
+	if len(groupSnapshot.Snapshots) > 0 {
+		vgsc.Status.VolumeSnapshotHandles = generateVolumeSnapshotHandles(groupSnapshot.Snapshots, members)
+	}
+	conditions := []metav1.Condition{
+		GenerateCondition(volumegroupv1.ConditionSnapshotCreated, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, ""),
+		GenerateCondition(volumegroupv1.ConditionDriverReachable, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, ""),
+	}
+	if readyToUse {
+		conditions = append(conditions, GenerateCondition(volumegroupv1.ConditionReady, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, ""))
+	} else {
+		conditions = append(conditions, GenerateCondition(volumegroupv1.ConditionReady, metav1.ConditionFalse, volumegroupv1.ReasonPending,
+			fmt.Sprintf(messages.VGSCIsNotReadyToUse, vgsc.Namespace, vgsc.Name)))
+	}
+	SetVGSCConditions(vgsc, conditions...)
+}
+
+func getTimeFromTimestamp(groupSnapshot *csi.VolumeGroupSnapshot) *metav1.Time {
+	if groupSnapshot.CreationTime == nil {
+		return GetCurrentTime()
+	}
+	creationTime := metav1.NewTime(groupSnapshot.CreationTime.AsTime())
+	return &creationTime
+}
+
+func generateVolumeSnapshotHandles(snapshots []*csi.Snapshot,
+	members []volumegroupv1.VolumeGroupMemberReference) []volumegroupv1.VolumeSnapshotHandle {
+	volumeSnapshotHandles := []volumegroupv1.VolumeSnapshotHandle{}
+	for _, snapshot := range snapshots {
+		readyToUse := snapshot.ReadyToUse
+		restoreSize := snapshot.SizeBytes
+		volumeSnapshotHandle := volumegroupv1.VolumeSnapshotHandle{
+			VolumeHandle:   snapshot.SourceVolumeId,
+			SnapshotHandle: snapshot.SnapshotId,
+			RestoreSize:    &restoreSize,
+			ReadyToUse:     &readyToUse,
+		}
+		if member := getMemberByVolumeHandle(snapshot.SourceVolumeId, members); member != nil {
+			volumeSnapshotHandle.PersistentVolumeName = member.Name
+			volumeSnapshotHandle.StorageClassName = member.StorageClassName
+			if member.ClaimRef != nil {
+				volumeSnapshotHandle.PersistentVolumeClaimName = member.ClaimRef.Name
+			}
+		}
+		if snapshot.CreationTime != nil {
+			creationTime := metav1.NewTime(snapshot.CreationTime.AsTime())
+			volumeSnapshotHandle.CreationTime = &creationTime
+		}
+		volumeSnapshotHandles = append(volumeSnapshotHandles, volumeSnapshotHandle)
+	}
+	return volumeSnapshotHandles
+}
+
+func getMemberByVolumeHandle(volumeHandle string,
+	members []volumegroupv1.VolumeGroupMemberReference) *volumegroupv1.VolumeGroupMemberReference {
+	for i := range members {
+		if members[i].VolumeHandle == volumeHandle {
+			return &members[i]
+		}
+	}
+	return nil
+}
+
+func UpdateVGSCStatusConditions(ctx context.Context, client client.Client, vgsc *volumegroupv1.VolumeGroupSnapshotContent, logger logr.Logger,
+	conditions ...metav1.Condition) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if !SetVGSCConditions(vgsc, conditions...) {
+			return nil
+		}
+		return vgscRetryOnConflictFunc(ctx, client, vgsc, logger)
+	})
+	return err
+}
+
+func vgscRetryOnConflictFunc(ctx context.Context, client client.Client, vgsc *volumegroupv1.VolumeGroupSnapshotContent, logger logr.Logger) error {
+	err := UpdateObjectStatus(ctx, client, vgsc)
+	if apierrors.IsConflict(err) {
+		uErr := getNamespacedObject(ctx, client, vgsc)
+		if uErr != nil {
+			return uErr
+		}
+		logger.Info(fmt.Sprintf(messages.RetryUpdateVGSCStatus, vgsc.Namespace, vgsc.Name))
+	}
+	return err
+}
+
+func CreateSuccessVGSCEvent(ctx context.Context, logger logr.Logger, client client.Client, vgsc *volumegroupv1.VolumeGroupSnapshotContent) error {
+	vgsc.APIVersion = APIVersion
+	vgsc.Kind = vgscKind
+	message := fmt.Sprintf(messages.VGSCCreated, vgsc.Namespace, vgsc.Name)
+	return createSuccessNamespacedObjectEvent(ctx, logger, client, vgsc, message, createVGSC)
+}
@@ -19,10 +19,15 @@ package volumegroup
 import "github.com/IBM/csi-volume-group-operator/pkg/client"
 
 type CommonRequestParameters struct {
-	Name          string
-	VolumeGroupID string
-	VolumeIds     []string
-	Parameters    map[string]string
-	Secrets       map[string]string
-	VolumeGroup   client.VolumeGroup
+	Name                  string
+	VolumeGroupID         string
+	VolumeGroupSnapshotID string
+	VolumeIds             []string
+	SnapshotIds           []string
+	MaxEntries            int32
+	StartingToken         string
+	Parameters            map[string]string
+	Secrets               map[string]string
+	VolumeGroup           client.VolumeGroup
+	VolumeGroupSnapshot   client.VolumeGroupSnapshot
 }
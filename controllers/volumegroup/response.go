@@ -21,6 +21,13 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+var (
+	// InProgressErrors are returned when the operation may still be running on the storage.
+	InProgressErrors = []codes.Code{codes.DeadlineExceeded, codes.Aborted}
+	// RetryableErrors are returned when the operation did not run and may succeed when it is retried.
+	RetryableErrors = []codes.Code{codes.Canceled, codes.Unavailable, codes.ResourceExhausted}
+)
+
 type Response struct {
 	Response interface{}
 	Error    error
@@ -46,3 +53,19 @@ func (r *Response) HasKnownGRPCError(knownErrors []codes.Code) bool {
 
 	return false
 }
+
+func (r *Response) IsInProgress() bool {
+	return r.HasKnownGRPCError(InProgressErrors)
+}
+
+// IsFinalError returns whether the operation failed and will fail again when it is retried as is.
+// Errors that are not gRPC errors happened before the driver was called, so they are not final.
+func (r *Response) IsFinalError() bool {
+	if r.Error == nil {
+		return false
+	}
+	if _, ok := status.FromError(r.Error); !ok {
+		return false
+	}
+	return !r.IsInProgress() && !r.HasKnownGRPCError(RetryableErrors)
+}
@@ -16,6 +16,8 @@ limitations under the License.
 
 package volumegroup
 
+import "context"
+
 type volumeGroupRequest struct {
 	Params CommonRequestParameters
 }
@@ -24,8 +26,9 @@ func NewVolumeGroupRequest(params CommonRequestParameters) *volumeGroupRequest {
 	return &volumeGroupRequest{Params: params}
 }
 
-func (r *volumeGroupRequest) Create() *Response {
+func (r *volumeGroupRequest) Create(ctx context.Context) *Response {
 	resp, err := r.Params.VolumeGroup.CreateVolumeGroup(
+		ctx,
 		r.Params.Name,
 		r.Params.Secrets,
 		r.Params.Parameters,
@@ -34,8 +37,9 @@ func (r *volumeGroupRequest) Create() *Response {
 	return &Response{Response: resp, Error: err}
 }
 
-func (r *volumeGroupRequest) Delete() *Response {
+func (r *volumeGroupRequest) Delete(ctx context.Context) *Response {
 	resp, err := r.Params.VolumeGroup.DeleteVolumeGroup(
+		ctx,
 		r.Params.VolumeGroupID,
 		r.Params.Secrets,
 	)
@@ -43,8 +47,9 @@ func (r *volumeGroupRequest) Delete() *Response {
 	return &Response{Response: resp, Error: err}
 }
 
-func (r *volumeGroupRequest) Modify() *Response {
+func (r *volumeGroupRequest) Modify(ctx context.Context) *Response {
 	resp, err := r.Params.VolumeGroup.ModifyVolumeGroupMembership(
+		ctx,
 		r.Params.VolumeGroupID,
 		r.Params.VolumeIds,
 		r.Params.Secrets,
@@ -52,3 +57,58 @@ func (r *volumeGroupRequest) Modify() *Response {
 
 	return &Response{Response: resp, Error: err}
 }
+
+func (r *volumeGroupRequest) Get(ctx context.Context) *Response {
+	resp, err := r.Params.VolumeGroup.ControllerGetVolumeGroup(
+		ctx,
+		r.Params.VolumeGroupID,
+		r.Params.Secrets,
+	)
+
+	return &Response{Response: resp, Error: err}
+}
+
+func (r *volumeGroupRequest) List(ctx context.Context) *Response {
+	resp, err := r.Params.VolumeGroup.ListVolumeGroups(
+		ctx,
+		r.Params.MaxEntries,
+		r.Params.StartingToken,
+		r.Params.Secrets,
+	)
+
+	return &Response{Response: resp, Error: err}
+}
+
+func (r *volumeGroupRequest) CreateSnapshot(ctx context.Context) *Response {
+	resp, err := r.Params.VolumeGroupSnapshot.CreateVolumeGroupSnapshot(
+		ctx,
+		r.Params.Name,
+		r.Params.VolumeIds,
+		r.Params.Secrets,
+		r.Params.Parameters,
+	)
+
+	return &Response{Response: resp, Error: err}
+}
+
+func (r *volumeGroupRequest) DeleteSnapshot(ctx context.Context) *Response {
+	resp, err := r.Params.VolumeGroupSnapshot.DeleteVolumeGroupSnapshot(
+		ctx,
+		r.Params.VolumeGroupSnapshotID,
+		r.Params.SnapshotIds,
+		r.Params.Secrets,
+	)
+
+	return &Response{Response: resp, Error: err}
+}
+
+func (r *volumeGroupRequest) GetSnapshot(ctx context.Context) *Response {
+	resp, err := r.Params.VolumeGroupSnapshot.GetVolumeGroupSnapshot(
+		ctx,
+		r.Params.VolumeGroupSnapshotID,
+		r.Params.SnapshotIds,
+		r.Params.Secrets,
+	)
+
+	return &Response{Response: resp, Error: err}
+}
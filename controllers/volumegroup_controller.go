@@ -27,8 +27,12 @@ import (
 	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
 	"github.com/IBM/csi-volume-group-operator/pkg/config"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
 	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +42,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
@@ -52,176 +57,371 @@ type VolumeGroupReconciler struct {
 	Log          logr.Logger
 	Scheme       *runtime.Scheme
 	DriverConfig *config.DriverConfig
-	GRPCClient   *grpcClient.Client
-	VGClient     grpcClient.VolumeGroup
+	Drivers      grpcClient.Drivers
 }
 
 //+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroups,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroups/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroups/finalizers,verbs=update
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupmembers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupmembers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupclasses,verbs=get;list;watch
 //+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupcontents,verbs=get;list;watch
-//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch
 //+kubebuilder:rbac:groups="",resources=persistentvolumeclaims/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups="",resources=persistentvolumeclaims/finalizers,verbs=update
-
-func (r *VolumeGroupReconciler) Reconcile(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get
+//+kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupsnapshots,verbs=get;list;watch
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupsnapshotcontents,verbs=get;list;watch
+//+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotcontents,verbs=get;list;watch;create;delete
+
+func (r *VolumeGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := r.Log.WithValues("Request.Name", req.Name, "Request.Namespace", req.Namespace)
 	logger.Info(messages.ReconcileVG)
 
+	result, err := r.reconcile(ctx, logger, req)
+	instance := &volumegroupv1.VolumeGroup{}
+	if gErr := r.Client.Get(ctx, req.NamespacedName, instance); gErr != nil {
+		if errors.IsNotFound(gErr) {
+			metrics.DeleteVGMetrics(req.Namespace, req.Name)
+		}
+		return result, err
+	}
+	if !r.isServed(ctx, logger, instance) {
+		// The instance of the operator that serves the driver exports the metrics of the volumeGroup.
+		metrics.DeleteVGMetrics(req.Namespace, req.Name)
+		return result, err
+	}
+	result, err = utils.HandleVGRetry(ctx, logger, r.Client, instance, result, err, r.DriverConfig.RetryMaxDelay)
+	utils.SetVGMetrics(instance)
+	return result, err
+}
+
+// isServed returns false for a volumeGroup whose driver is served by another instance of the operator,
+// its retry state and its metrics are handled by that instance.
+func (r *VolumeGroupReconciler) isServed(ctx context.Context, logger logr.Logger, instance *volumegroupv1.VolumeGroup) bool {
+	vgClass, err := utils.GetVGClass(ctx, r.Client, logger, utils.GetStringField(instance.Spec, "VolumeGroupClassName"))
+	if err != nil {
+		return true
+	}
+	_, ok := r.Drivers.Get(vgClass.Driver)
+	return ok
+}
+
+func (r *VolumeGroupReconciler) reconcile(ctx context.Context, logger logr.Logger, req ctrl.Request) (ctrl.Result, error) {
 	instance := &volumegroupv1.VolumeGroup{}
-	if err := r.Client.Get(context.TODO(), req.NamespacedName, instance); err != nil {
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
 		if errors.IsNotFound(err) {
 
 			logger.Info("VolumeGroup resource not found")
 
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, vgReconcile)
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, volumegroupv1.ConditionReady, vgReconcile)
 	}
 
-	vgClass, err := utils.GetVGClass(r.Client, logger, utils.GetStringField(instance.Spec, "VolumeGroupClassName"))
+	vgClass, err := utils.GetVGClass(ctx, r.Client, logger, utils.GetStringField(instance.Spec, "VolumeGroupClassName"))
 	if err != nil {
-		return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, vgReconcile)
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, volumegroupv1.ConditionReady, vgReconcile)
 	}
 
-	if r.DriverConfig.DriverName != vgClass.Driver {
+	driver, ok := r.Drivers.Get(vgClass.Driver)
+	if !ok {
 		return ctrl.Result{}, nil
 	}
-
-	if err = utils.ValidatePrefixedParameters(vgClass.Parameters); err != nil {
-		logger.Error(err, "failed to validate parameters of volumegroupClass", "VGClassName", vgClass.Name)
-		if uErr := utils.UpdateVGStatusError(r.Client, instance, logger, err.Error()); uErr != nil {
-			return ctrl.Result{}, uErr
-		}
+	if err = utils.CheckDriverReachable(ctx, logger, driver); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if err = utils.MigrateVGMembers(ctx, logger, r.Client, instance); err != nil {
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, volumegroupv1.ConditionReady, vgReconcile)
+	}
+
 	if instance.GetDeletionTimestamp().IsZero() {
-		if err = utils.AddFinalizerToVG(r.Client, logger, instance); err != nil {
-			return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, createVG)
+		// The spec is not validated once the volumeGroup is deleted, so a spec that became invalid does not block the deletion.
+		if err = r.validateVG(ctx, logger, instance, vgClass); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err = utils.AddFinalizerToVG(ctx, r.Client, logger, instance); err != nil {
+			return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, volumegroupv1.ConditionReady, createVG)
 		}
 
 	} else {
+		if err = utils.UpdateVGStatusConditions(ctx, r.Client, instance, logger, utils.GenerateCondition(
+			volumegroupv1.ConditionDeleting, metav1.ConditionTrue, volumegroupv1.ReasonDeletionRequested,
+			fmt.Sprintf(messages.VGDeletionRequested, instance.Namespace, instance.Name))); err != nil {
+			return ctrl.Result{}, err
+		}
 		if commonUtils.Contains(instance.GetFinalizers(), utils.VGFinalizer) && !utils.IsContainOtherFinalizers(instance, logger) {
-			if err = r.removeInstance(logger, instance); err != nil {
-				return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, deleteVG)
+			if err = r.removeInstance(ctx, logger, instance); err != nil {
+				return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, volumegroupv1.ConditionDeleting, deleteVG)
 			}
 			logger.Info("volumeGroup object is terminated, skipping reconciliation")
 		}
 		return ctrl.Result{}, nil
 	}
 
+	if instance.Spec.Source.DataSource != nil && !utils.IsVGRestoreCompleted(instance) {
+		isRestored, err := r.restoreVG(ctx, logger, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !isRestored {
+			// The dataSource volumeGroupSnapshot and the restored persistentVolumeClaims are watched.
+			return ctrl.Result{}, nil
+		}
+	}
+
 	groupCreationTime := utils.GetCurrentTime()
 
-	err, isStaticProvisioned := r.handleStaticProvisionedVG(instance, logger, groupCreationTime, vgClass)
+	result, err, isStaticProvisioned := r.handleStaticProvisionedVG(ctx, driver, instance, logger, groupCreationTime, vgClass)
 	if isStaticProvisioned {
-		return ctrl.Result{}, err
+		return result, err
 	}
 
 	vgName, err := utils.MakeVGName(utils.VGNamePrefix, string(instance.UID))
 	if err != nil {
-		return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, createVG)
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, volumegroupv1.ConditionBound, createVG)
 	}
 	secretName, secretNamespace := utils.GetSecretCred(vgClass)
 	vgc := utils.GenerateVGC(vgName, instance, vgClass, secretName, secretNamespace)
 	logger.Info("GenerateVolumeGroupContent", "vgc", vgc)
-	if err = utils.CreateVGC(r.Client, logger, vgc); err != nil {
-		return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, createVGC)
+	if err = utils.CreateVGC(ctx, r.Client, logger, vgc); err != nil {
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, volumegroupv1.ConditionBound, createVGC)
 	}
-	if isVGCReady, err := r.isVGCReady(logger, vgc); err != nil {
-		return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, createVGC)
+	if isVGCReady, err := r.isVGCReady(ctx, logger, vgc); err != nil {
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, volumegroupv1.ConditionBound, createVGC)
 	} else if !isVGCReady {
-		return ctrl.Result{Requeue: true}, nil
+		if err = utils.UpdateVGStatusConditions(ctx, r.Client, instance, logger, utils.GenerateCondition(
+			volumegroupv1.ConditionBound, metav1.ConditionFalse, volumegroupv1.ReasonPending,
+			fmt.Sprintf(messages.VGCIsNotReady, vgc.Namespace, vgc.Name))); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
 	}
 
-	err = r.updateItems(instance, logger, groupCreationTime, vgc.Name)
+	err = r.updateItems(ctx, instance, logger, groupCreationTime, vgc.Name)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	err = r.updatePVCs(logger, instance)
+	result, err = r.updatePVCs(ctx, driver, logger, instance)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	err = r.createSuccessVGEvent(logger, instance)
+	err = r.createSuccessVGEvent(ctx, logger, instance)
 	if err != nil {
-		return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, vgReconcile)
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, volumegroupv1.ConditionReady, vgReconcile)
 	}
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
-func (r *VolumeGroupReconciler) updatePVCs(logger logr.Logger, vg *volumegroupv1.VolumeGroup) error {
-	matchingPvcs, err := r.getMatchingPVCs(logger, *vg)
+func (r *VolumeGroupReconciler) validateVG(ctx context.Context, logger logr.Logger, instance *volumegroupv1.VolumeGroup,
+	vgClass *volumegroupv1.VolumeGroupClass) error {
+	if err := utils.ValidatePrefixedParameters(vgClass.Parameters); err != nil {
+		logger.Error(err, "failed to validate parameters of volumegroupClass", "VGClassName", vgClass.Name)
+		if uErr := utils.UpdateVGStatusConditions(ctx, r.Client, instance, logger,
+			utils.GenerateFailureConditions(err, volumegroupv1.ConditionParametersValid, vgReconcile)...); uErr != nil {
+			return uErr
+		}
+		return err
+	}
+
+	if err := utils.ValidateVGNamespaceSelector(instance, vgClass); err != nil {
+		logger.Error(err, "failed to validate namespaceSelector of volumegroup", "VGClassName", vgClass.Name)
+		if uErr := utils.UpdateVGStatusConditions(ctx, r.Client, instance, logger,
+			utils.GenerateFailureConditions(err, volumegroupv1.ConditionParametersValid, vgReconcile)...); uErr != nil {
+			return uErr
+		}
+		return err
+	}
+	if err := utils.ValidateVGDataSource(instance); err != nil {
+		logger.Error(err, "failed to validate dataSource of volumegroup", "VGName", instance.Name)
+		if uErr := utils.UpdateVGStatusConditions(ctx, r.Client, instance, logger,
+			utils.GenerateFailureConditions(err, volumegroupv1.ConditionParametersValid, vgReconcile)...); uErr != nil {
+			return uErr
+		}
+		return err
+	}
+	return utils.UpdateVGStatusConditions(ctx, r.Client, instance, logger, utils.GenerateCondition(
+		volumegroupv1.ConditionParametersValid, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, ""))
+}
+
+func (r *VolumeGroupReconciler) updatePVCs(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, vg *volumegroupv1.VolumeGroup) (ctrl.Result, error) {
+	matchingPvcs, err := r.getMatchingPVCs(ctx, driver, logger, *vg)
 	if err != nil {
-		return utils.HandleErrorMessage(logger, r.Client, vg, err, vgReconcile)
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, volumegroupv1.ConditionMembershipSynced, vgReconcile)
 	}
-	if utils.IsPVCListEqual(matchingPvcs, vg.Status.PVCList) {
-		return nil
+	members, err := utils.GetVGMembers(ctx, logger, r.Client, vg)
+	if err != nil {
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, volumegroupv1.ConditionMembershipSynced, vgReconcile)
+	}
+	if utils.IsPVCListEqualToMembers(matchingPvcs, members) && utils.AreVGMembersJoined(members) {
+		if err = utils.ClearVGMembershipBatch(ctx, logger, r.Client, vg); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err = utils.UpdateVGMemberCounts(ctx, logger, r.Client, vg); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, utils.UpdateVGStatusConditions(ctx, r.Client, vg, logger, utils.GenerateCondition(
+			volumegroupv1.ConditionMembershipSynced, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, ""))
 	}
-	err = utils.ModifyVolumesInVG(logger, r.Client, r.VGClient, matchingPvcs, *vg)
+	if !driver.Capabilities().ModifyVolumeGroupMembership {
+		err = status.Error(codes.Unimplemented, fmt.Sprintf(messages.VGModificationIsNotSupported, driver.Name))
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, volumegroupv1.ConditionMembershipSynced, modifyVG)
+	}
+
+	batchWindow := utils.GetVGMembershipBatchWindow(vg, r.DriverConfig.MembershipBatchWindow)
+	changes := utils.CountVGMembershipChanges(matchingPvcs, members)
+	flushDelay, err := utils.ScheduleVGMembershipBatch(ctx, logger, r.Client, vg, changes, batchWindow, r.DriverConfig.MembershipBatchMaxSize)
 	if err != nil {
-		return utils.HandleErrorMessage(logger, r.Client, vg, err, vgReconcile)
+		return ctrl.Result{}, err
 	}
-	err = utils.UpdatePvcAndPvList(logger, vg, r.Client, r.DriverConfig.DriverName, matchingPvcs)
+	if flushDelay > 0 {
+		return ctrl.Result{RequeueAfter: flushDelay}, nil
+	}
+	batchPvcs, isTruncated, err := utils.GetVGMembershipBatch(ctx, logger, r.Client, matchingPvcs, members,
+		r.DriverConfig.MembershipBatchMaxSize)
 	if err != nil {
-		return err
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, volumegroupv1.ConditionMembershipSynced, modifyVG)
 	}
-	return nil
+
+	if err = utils.SetVGMembersIntent(ctx, logger, r.Client, vg, batchPvcs); err != nil {
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, volumegroupv1.ConditionMembershipSynced, modifyVG)
+	}
+	err = utils.ModifyVolumesInVG(ctx, logger, r.Client, driver.VolumeGroup, batchPvcs, *vg)
+	if err != nil {
+		if utils.IsVGModificationInProgress(err) {
+			// The members stay pending and leaving, the modification is sent again when the reconcile is retried.
+			return ctrl.Result{}, r.updateVGModificationInProgress(ctx, logger, vg, err)
+		}
+		if rErr := utils.RollbackVGMembers(ctx, logger, r.Client, vg, err); rErr != nil {
+			return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, vg, rErr, volumegroupv1.ConditionMembershipSynced, modifyVG)
+		}
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, volumegroupv1.ConditionMembershipSynced, modifyVG)
+	}
+	membershipSynced := utils.GenerateCondition(volumegroupv1.ConditionMembershipSynced, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, "")
+	if isTruncated {
+		membershipSynced = utils.GenerateCondition(volumegroupv1.ConditionMembershipSynced, metav1.ConditionFalse, volumegroupv1.ReasonPending,
+			fmt.Sprintf(messages.VGMembershipBatchIsTruncated, r.DriverConfig.MembershipBatchMaxSize))
+	}
+	err = utils.UpdateVGStatusConditions(ctx, r.Client, vg, logger, membershipSynced,
+		utils.GenerateCondition(volumegroupv1.ConditionDriverReachable, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, ""))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	err = utils.UpdatePvcAndPvList(ctx, logger, vg, r.Client, driver.Name, batchPvcs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err = utils.ClearVGMembershipBatch(ctx, logger, r.Client, vg); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err = utils.UpdateVGMemberCounts(ctx, logger, r.Client, vg); err != nil {
+		return ctrl.Result{}, err
+	}
+	if isTruncated {
+		// The changes left out of the batch are sent in the next batch.
+		return ctrl.Result{RequeueAfter: max(batchWindow, time.Second)}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *VolumeGroupReconciler) updateVGModificationInProgress(ctx context.Context, logger logr.Logger, vg *volumegroupv1.VolumeGroup,
+	err error) error {
+	message := fmt.Sprintf(messages.VGModificationInProgress, vg.Namespace, vg.Name, utils.GetMessageFromError(err))
+	logger.Info(message)
+	if uErr := utils.UpdateVGStatusConditions(ctx, r.Client, vg, logger, utils.GenerateCondition(
+		volumegroupv1.ConditionMembershipSynced, metav1.ConditionFalse, volumegroupv1.ReasonInProgress, message)); uErr != nil {
+		return uErr
+	}
+	return err
 }
 
-func (r *VolumeGroupReconciler) handleStaticProvisionedVG(vg *volumegroupv1.VolumeGroup, logger logr.Logger, groupCreationTime *metav1.Time, vgClass *volumegroupv1.VolumeGroupClass) (error, bool) {
+func (r *VolumeGroupReconciler) restoreVG(ctx context.Context, logger logr.Logger, vg *volumegroupv1.VolumeGroup) (bool, error) {
+	members, err := utils.RestoreVGFromDataSource(ctx, logger, r.Client, vg)
+	if err != nil {
+		return false, utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, volumegroupv1.ConditionRestored, restoreVG)
+	}
+	if err = utils.DeleteFinishedRestoreSnapshots(ctx, logger, r.Client, vg, members); err != nil {
+		return false, utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, volumegroupv1.ConditionRestored, restoreVG)
+	}
+	if err = utils.UpdateVGRestoredMembers(ctx, r.Client, vg, logger, members); err != nil {
+		return false, err
+	}
+	if failedPVCs := utils.GetFailedRestoredPVCNames(members); len(failedPVCs) > 0 {
+		err = fmt.Errorf(messages.VGRestoreFailed, failedPVCs, vg.Namespace, vg.Name)
+		return false, utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, volumegroupv1.ConditionRestored, restoreVG)
+	}
+	if !utils.IsVGRestored(members) {
+		return false, utils.UpdateVGStatusConditions(ctx, r.Client, vg, logger, utils.GenerateCondition(
+			volumegroupv1.ConditionRestored, metav1.ConditionFalse, volumegroupv1.ReasonPending,
+			fmt.Sprintf(messages.VGIsNotRestored, vg.Namespace, vg.Name, vg.Spec.Source.DataSource.Name)))
+	}
+	return true, utils.UpdateVGStatusConditions(ctx, r.Client, vg, logger, utils.GenerateCondition(
+		volumegroupv1.ConditionRestored, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded,
+		fmt.Sprintf(messages.VGIsRestored, vg.Namespace, vg.Name, vg.Spec.Source.DataSource.Name)))
+}
+
+func (r *VolumeGroupReconciler) handleStaticProvisionedVG(ctx context.Context, driver *grpcClient.Driver, vg *volumegroupv1.VolumeGroup, logger logr.Logger, groupCreationTime *metav1.Time, vgClass *volumegroupv1.VolumeGroupClass) (ctrl.Result, error, bool) {
 	if vg.Spec.Source.VolumeGroupContentName != nil {
-		err := r.updateItems(vg, logger, groupCreationTime, *vg.Spec.Source.VolumeGroupContentName)
+		err := r.updateItems(ctx, vg, logger, groupCreationTime, *vg.Spec.Source.VolumeGroupContentName)
 		if err != nil {
-			return err, true
+			return ctrl.Result{}, err, true
 		}
-		err = utils.UpdateStaticVGCFromVG(r.Client, vg, vgClass, logger)
+		err = utils.UpdateStaticVGCFromVG(ctx, r.Client, vg, vgClass, logger)
 		if err != nil {
-			return err, true
+			return ctrl.Result{}, err, true
 		}
-		err = r.updatePVCs(logger, vg)
+		result, err := r.updatePVCs(ctx, driver, logger, vg)
 		if err != nil {
-			return err, true
+			return ctrl.Result{}, err, true
 		}
-		return nil, true
+		err = r.createSuccessVGEvent(ctx, logger, vg)
+		if err != nil {
+			return ctrl.Result{}, err, true
+		}
+		return result, nil, true
 	}
-	return nil, false
+	return ctrl.Result{}, nil, false
 }
 
-func (r *VolumeGroupReconciler) updateItems(instance *volumegroupv1.VolumeGroup, logger logr.Logger, groupCreationTime *metav1.Time, vgcName string) error {
-	if err := utils.UpdateVGSourceContent(r.Client, instance, vgcName, logger); err != nil {
-		return utils.HandleErrorMessage(logger, r.Client, instance, err, updateVGC)
+func (r *VolumeGroupReconciler) updateItems(ctx context.Context, instance *volumegroupv1.VolumeGroup, logger logr.Logger, groupCreationTime *metav1.Time, vgcName string) error {
+	if err := utils.UpdateVGSourceContent(ctx, r.Client, instance, vgcName, logger); err != nil {
+		return utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, volumegroupv1.ConditionBound, updateVGC)
 	}
-	if err := utils.UpdateVGStatus(r.Client, instance, vgcName, groupCreationTime, true, logger); err != nil {
-		return utils.HandleErrorMessage(logger, r.Client, instance, err, updateStatusVG)
+	if err := utils.UpdateVGStatus(ctx, r.Client, instance, vgcName, groupCreationTime, logger); err != nil {
+		return utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, volumegroupv1.ConditionBound, updateStatusVG)
 	}
 	return nil
 }
 
-func (r *VolumeGroupReconciler) removeInstance(logger logr.Logger, instance *volumegroupv1.VolumeGroup) error {
-	vgc, err := utils.GetVGC(r.Client, logger, utils.GetStringField(instance.Spec.Source, "VolumeGroupContentName"), instance.Namespace)
+func (r *VolumeGroupReconciler) removeInstance(ctx context.Context, logger logr.Logger, instance *volumegroupv1.VolumeGroup) error {
+	vgc, err := utils.GetVGC(ctx, r.Client, logger, utils.GetStringField(instance.Spec.Source, "VolumeGroupContentName"), instance.Namespace)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			return err
 		}
 
 	} else {
-		err = r.removeVGCObject(logger, vgc)
+		err = r.removeVGCObject(ctx, logger, vgc)
 		if err != nil {
 			return err
 		}
 	}
-	if err = utils.RemoveFinalizerFromVG(r.Client, logger, instance); err != nil {
+	if err = utils.RemoveFinalizerFromVG(ctx, r.Client, logger, instance); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *VolumeGroupReconciler) removeVGCObject(logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
+func (r *VolumeGroupReconciler) removeVGCObject(ctx context.Context, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
 	if *vgc.Spec.VolumeGroupDeletionPolicy == volumegroupv1.VolumeGroupContentDelete {
-		if err := r.Client.Delete(context.TODO(), vgc); err != nil {
+		if err := r.Client.Delete(ctx, vgc); err != nil {
 			logger.Error(err, "Failed to delete volume group content", "VGCName", vgc.Name)
 			return err
 		}
@@ -229,23 +429,24 @@ func (r *VolumeGroupReconciler) removeVGCObject(logger logr.Logger, vgc *volumeg
 	return nil
 }
 
-func (r *VolumeGroupReconciler) isPVCShouldBeRemovedFromVg(logger logr.Logger, vg volumegroupv1.VolumeGroup,
+func (r *VolumeGroupReconciler) isPVCShouldBeRemovedFromVg(ctx context.Context, logger logr.Logger, vg volumegroupv1.VolumeGroup,
 	pvc *corev1.PersistentVolumeClaim) (bool, error) {
-	if !utils.IsPVCInPVCList(pvc, vg.Status.PVCList) {
-		return false, nil
+	isPVCInVG, err := utils.IsPVCInVG(ctx, logger, r.Client, pvc, &vg)
+	if err != nil || !isPVCInVG {
+		return false, err
 	}
 
-	isPVCMatchesVG, err := utils.IsPVCMatchesVG(logger, pvc, vg)
+	isPVCMatchesVG, err := utils.IsPVCMatchesVG(ctx, logger, r.Client, pvc, vg)
 	if err != nil {
 		return false, err
 	}
 	return !isPVCMatchesVG, nil
 }
 
-func (r *VolumeGroupReconciler) isPVCShouldBeInVg(logger logr.Logger, vg volumegroupv1.VolumeGroup,
+func (r *VolumeGroupReconciler) isPVCShouldBeInVg(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, vg volumegroupv1.VolumeGroup,
 	pvc *corev1.PersistentVolumeClaim) (bool, error) {
 
-	isPVCMatchesVG, err := utils.IsPVCMatchesVG(logger, pvc, vg)
+	isPVCMatchesVG, err := utils.IsPVCMatchesVG(ctx, logger, r.Client, pvc, vg)
 	if err != nil {
 		return false, err
 	}
@@ -253,28 +454,28 @@ func (r *VolumeGroupReconciler) isPVCShouldBeInVg(logger logr.Logger, vg volumeg
 		return false, nil
 	}
 
-	if err := r.isPVCCanBeAddedToVG(logger, pvc); err != nil {
+	if err := r.isPVCCanBeAddedToVG(ctx, driver, logger, pvc); err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
-func (r VolumeGroupReconciler) isPVCCanBeAddedToVG(logger logr.Logger, pvc *corev1.PersistentVolumeClaim) error {
+func (r VolumeGroupReconciler) isPVCCanBeAddedToVG(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, pvc *corev1.PersistentVolumeClaim) error {
 	if r.DriverConfig.MultipleVGsToPVC == "true" {
 		return nil
 	}
 
-	vgList, err := utils.GetVGList(logger, r.Client, r.DriverConfig.DriverName)
+	vgList, err := utils.GetVGList(ctx, logger, r.Client, driver.Name)
 	if err != nil {
 		return err
 	}
-	err = utils.IsPVCCanBeAddedToVG(logger, pvc, vgList.Items)
+	err = utils.IsPVCCanBeAddedToVG(ctx, logger, r.Client, pvc, vgList.Items)
 	return err
 }
 
-func (r VolumeGroupReconciler) createSuccessVGEvent(logger logr.Logger, vg *volumegroupv1.VolumeGroup) error {
+func (r VolumeGroupReconciler) createSuccessVGEvent(ctx context.Context, logger logr.Logger, vg *volumegroupv1.VolumeGroup) error {
 	message := fmt.Sprintf(messages.VGCreated, vg.Namespace, vg.Name)
-	err := utils.HandleSuccessMessage(logger, r.Client, vg, message, vgReconcile)
+	err := utils.HandleSuccessMessage(ctx, logger, r.Client, vg, message, volumegroupv1.ConditionReady, vgReconcile)
 	if err != nil {
 		return nil
 	}
@@ -292,11 +493,21 @@ func (r *VolumeGroupReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.D
 	generationPred := predicate.GenerationChangedPredicate{}
 	pred := predicate.Or(generationPred, utils.FinalizerPredicate)
 
-	r.VGClient = grpcClient.NewVolumeGroupClient(r.GRPCClient.Client, cfg.RPCTimeout)
+	if err = utils.AddPVCIndexers(mgr); err != nil {
+		return err
+	}
+	if err = utils.AddVGIndexers(mgr); err != nil {
+		return err
+	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&volumegroupv1.VolumeGroup{}, builder.WithPredicates(pred)).
 		Watches(&corev1.PersistentVolumeClaim{}, utils.CreateRequests(r.Client), builder.WithPredicates(utils.PvcPredicate)).
+		Watches(&volumegroupv1.VolumeGroupContent{}, utils.CreateVGCRequests(), builder.WithPredicates(utils.VGCPredicate)).
+		Watches(&volumegroupv1.VolumeGroupSnapshot{}, utils.CreateDataSourceVGSRequests(r.Client), builder.WithPredicates(utils.DataSourceVGSPredicate)).
+		Watches(&corev1.Namespace{}, utils.CreateNamespaceRequests(r.Client), builder.WithPredicates(utils.NamespacePredicate)).
+		Watches(&storagev1.StorageClass{}, utils.StorageClassCacheHandler).
+		WithOptions(controller.Options{MaxConcurrentReconciles: cfg.VGMaxConcurrentReconciles}).
 		Complete(r)
 }
 
@@ -348,18 +559,18 @@ func (r *VolumeGroupReconciler) waitForVGResource(logger logr.Logger, resourceNa
 	}
 }
 
-func (r *VolumeGroupReconciler) getMatchingPVCs(logger logr.Logger, vg volumegroupv1.VolumeGroup) ([]corev1.PersistentVolumeClaim, error) {
+func (r *VolumeGroupReconciler) getMatchingPVCs(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, vg volumegroupv1.VolumeGroup) ([]corev1.PersistentVolumeClaim, error) {
 	var matchingPvcs []corev1.PersistentVolumeClaim
-	pvcList, err := utils.GetPVCList(logger, r.Client, r.DriverConfig.DriverName)
+	pvcList, err := utils.GetVGCandidatePVCs(ctx, logger, r.Client, &vg, driver.Name)
 	if err != nil {
 		return nil, err
 	}
-	for _, pvc := range pvcList.Items {
-		isPVCShouldBeInVg, err := r.isPVCShouldBeInVg(logger, vg, &pvc)
+	for _, pvc := range pvcList {
+		isPVCShouldBeInVg, err := r.isPVCShouldBeInVg(ctx, driver, logger, vg, &pvc)
 		if err != nil {
 			return nil, err
 		}
-		isPVCShouldBeHandled, err := utils.IsPVCNeedToBeHandled(logger, &pvc, r.Client, r.DriverConfig.DriverName)
+		isPVCShouldBeHandled, err := utils.IsPVCNeedToBeHandled(ctx, logger, &pvc, r.Client, driver.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -370,13 +581,13 @@ func (r *VolumeGroupReconciler) getMatchingPVCs(logger logr.Logger, vg volumegro
 	return matchingPvcs, err
 }
 
-func (r *VolumeGroupReconciler) isVGCReady(logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) (bool, error) {
-	vgcFromCluster, err := utils.GetVGC(r.Client, logger, vgc.Name, vgc.Namespace)
+func (r *VolumeGroupReconciler) isVGCReady(ctx context.Context, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) (bool, error) {
+	vgcFromCluster, err := utils.GetVGC(ctx, r.Client, logger, vgc.Name, vgc.Namespace)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			return false, err
 		}
 		return false, nil
 	}
-	return utils.GetBoolField(vgcFromCluster.Status, "Ready"), nil
+	return meta.IsStatusConditionTrue(vgcFromCluster.Status.Conditions, volumegroupv1.ConditionReady), nil
 }
@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	benchmarkDriverName      = "driver.name"
+	benchmarkSCName          = "benchmark-storage-class"
+	benchmarkNamespaces      = 50
+	benchmarkPVCsInNamespace = 100
+	benchmarkVGMembers       = 10
+)
+
+var benchmarkMatchLabels = map[string]string{"benchmark-vg": "members"}
+
+func newBenchmarkReconciler(b *testing.B) (*VolumeGroupReconciler, *volumegroupv1.VolumeGroup) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		b.Fatal(err)
+	}
+	if err := volumegroupv1.AddToScheme(scheme); err != nil {
+		b.Fatal(err)
+	}
+	scName := benchmarkSCName
+	objects := []client.Object{&storagev1.StorageClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: scName},
+		Provisioner: benchmarkDriverName,
+	}}
+	for i := 0; i < benchmarkNamespaces; i++ {
+		namespace := fmt.Sprintf("benchmark-namespace-%d", i)
+		for j := 0; j < benchmarkPVCsInNamespace; j++ {
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("benchmark-pvc-%d", j),
+					Namespace: namespace,
+					Labels:    map[string]string{"benchmark-pvc": fmt.Sprint(j)},
+				},
+				Spec:   corev1.PersistentVolumeClaimSpec{StorageClassName: &scName},
+				Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+			}
+			if i == 0 && j < benchmarkVGMembers {
+				pvc.Labels = benchmarkMatchLabels
+			}
+			objects = append(objects, pvc)
+		}
+	}
+	k8sClient, err := newIndexedClient(scheme, objects)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	vg := &volumegroupv1.VolumeGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "benchmark-vg", Namespace: "benchmark-namespace-0"},
+		Spec: volumegroupv1.VolumeGroupSpec{
+			Source: volumegroupv1.VolumeGroupSource{
+				Selector: &metav1.LabelSelector{MatchLabels: benchmarkMatchLabels},
+			},
+		},
+	}
+	return &VolumeGroupReconciler{
+		Client: k8sClient,
+		Log:    logr.Discard(),
+		DriverConfig: &config.DriverConfig{
+			DriverName:       benchmarkDriverName,
+			MultipleVGsToPVC: "true",
+		},
+	}, vg
+}
+
+// indexedClient serves reads from client-go indexers the way the cache of the manager does,
+// the fake client filters a full list for every field selector so it hides the cost of listing the cluster.
+type indexedClient struct {
+	client.Client
+	pvcIndexer cache.Indexer
+	scIndexer  cache.Indexer
+}
+
+func newIndexedClient(scheme *runtime.Scheme, objects []client.Object) (*indexedClient, error) {
+	toIndexFunc := func(indexFunc client.IndexerFunc) cache.IndexFunc {
+		return func(obj interface{}) ([]string, error) {
+			return indexFunc(obj.(client.Object)), nil
+		}
+	}
+	c := &indexedClient{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		pvcIndexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+			cache.NamespaceIndex:         cache.MetaNamespaceIndexFunc,
+			utils.PVCNamespaceLabelIndex: toIndexFunc(utils.IndexPVCByNamespaceLabel),
+			utils.PVCStorageClassIndex:   toIndexFunc(utils.IndexPVCByStorageClass),
+		}),
+		scIndexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}),
+	}
+	for _, obj := range objects {
+		indexer := c.pvcIndexer
+		if _, ok := obj.(*storagev1.StorageClass); ok {
+			indexer = c.scIndexer
+		}
+		if err := indexer.Add(obj); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *indexedClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	item, exists, err := c.scIndexer.GetByKey(key.Name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apierrors.NewNotFound(storagev1.Resource("storageclasses"), key.Name)
+	}
+	item.(*storagev1.StorageClass).DeepCopyInto(obj.(*storagev1.StorageClass))
+	return nil
+}
+
+func (c *indexedClient) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if scList, ok := list.(*storagev1.StorageClassList); ok {
+		for _, item := range c.scIndexer.List() {
+			scList.Items = append(scList.Items, *item.(*storagev1.StorageClass).DeepCopy())
+		}
+		return nil
+	}
+	listOptions := &client.ListOptions{}
+	listOptions.ApplyOptions(opts)
+	var items []interface{}
+	var err error
+	if listOptions.FieldSelector != nil {
+		requirement := listOptions.FieldSelector.Requirements()[0]
+		items, err = c.pvcIndexer.ByIndex(requirement.Field, requirement.Value)
+	} else if listOptions.Namespace != "" {
+		items, err = c.pvcIndexer.ByIndex(cache.NamespaceIndex, listOptions.Namespace)
+	} else {
+		items = c.pvcIndexer.List()
+	}
+	if err != nil {
+		return err
+	}
+	pvcList := list.(*corev1.PersistentVolumeClaimList)
+	for _, item := range items {
+		pvcList.Items = append(pvcList.Items, *item.(*corev1.PersistentVolumeClaim).DeepCopy())
+	}
+	return nil
+}
+
+// getMatchingPVCsFromClusterList is the membership computation before the persistentVolumeClaim indexes,
+// it lists every persistentVolumeClaim of the cluster and checks each of them with the reconciler helpers.
+func getMatchingPVCsFromClusterList(r *VolumeGroupReconciler, vg *volumegroupv1.VolumeGroup) ([]corev1.PersistentVolumeClaim, error) {
+	ctx := context.TODO()
+	driver := &grpcClient.Driver{Name: r.DriverConfig.DriverName}
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := r.Client.List(ctx, pvcList); err != nil {
+		return nil, err
+	}
+	var provisionedPVCs []corev1.PersistentVolumeClaim
+	for _, pvc := range pvcList.Items {
+		if pvc.Status.Phase != corev1.ClaimBound {
+			continue
+		}
+		isPVCHasMatchingDriver, err := utils.IsPVCHasMatchingDriver(ctx, r.Log, r.Client, &pvc, driver.Name)
+		if err != nil {
+			return nil, err
+		}
+		if isPVCHasMatchingDriver {
+			provisionedPVCs = append(provisionedPVCs, pvc)
+		}
+	}
+	var matchingPvcs []corev1.PersistentVolumeClaim
+	for _, pvc := range provisionedPVCs {
+		isPVCShouldBeInVg, err := r.isPVCShouldBeInVg(ctx, driver, r.Log, *vg, &pvc)
+		if err != nil {
+			return nil, err
+		}
+		isPVCShouldBeHandled, err := utils.IsPVCNeedToBeHandled(ctx, r.Log, &pvc, r.Client, driver.Name)
+		if err != nil {
+			return nil, err
+		}
+		if isPVCShouldBeInVg && !utils.IsPVCInPVCList(&pvc, matchingPvcs) && isPVCShouldBeHandled {
+			matchingPvcs = append(matchingPvcs, pvc)
+		}
+	}
+	return matchingPvcs, nil
+}
+
+func BenchmarkGetMatchingPVCsFromClusterList(b *testing.B) {
+	r, vg := newBenchmarkReconciler(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pvcs, err := getMatchingPVCsFromClusterList(r, vg)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(pvcs) != benchmarkVGMembers {
+			b.Fatalf("expected %d matching PVCs, got %d", benchmarkVGMembers, len(pvcs))
+		}
+	}
+}
+
+func BenchmarkGetMatchingPVCs(b *testing.B) {
+	r, vg := newBenchmarkReconciler(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pvcs, err := r.getMatchingPVCs(context.TODO(), &grpcClient.Driver{Name: benchmarkDriverName}, r.Log, *vg)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(pvcs) != benchmarkVGMembers {
+			b.Fatalf("expected %d matching PVCs, got %d", benchmarkVGMembers, len(pvcs))
+		}
+	}
+}
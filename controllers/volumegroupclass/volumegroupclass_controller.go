@@ -0,0 +1,230 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumegroupclass
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+type VolumeGroupClassReconciler struct {
+	client.Client
+	Log          logr.Logger
+	Scheme       *runtime.Scheme
+	DriverConfig *config.DriverConfig
+	Drivers      grpcClient.Drivers
+
+	driverEvents chan event.GenericEvent
+}
+
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupclasses/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupcontents,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupcontents/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch
+
+func (r *VolumeGroupClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("Request.Name", req.Name)
+	logger.Info(messages.ReconcileVGClass)
+
+	vgClass, err := utils.GetVGClass(ctx, r.Client, logger, req.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+
+			logger.Info("VolumeGroupClass resource not found")
+
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	driver, ok := r.Drivers.Get(vgClass.Driver)
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+
+	if err = utils.ValidatePrefixedParameters(vgClass.Parameters); err != nil {
+		logger.Error(err, "failed to validate parameters of volumegroupClass", "VGClassName", vgClass.Name)
+		if uErr := utils.UpdateVGClassStatusConditions(ctx, r.Client, vgClass, logger,
+			utils.GenerateFailureConditions(err, volumegroupv1.ConditionParametersValid, vgClassReconcile)...); uErr != nil {
+			return ctrl.Result{}, uErr
+		}
+		return ctrl.Result{}, nil
+	}
+
+	parametersValidCondition := utils.GenerateCondition(volumegroupv1.ConditionParametersValid, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, "")
+	if err = utils.CheckDriverReachable(ctx, logger, driver); err != nil {
+		return ctrl.Result{}, utils.UpdateVGClassStatusConditions(ctx, r.Client, vgClass, logger,
+			append(utils.GenerateFailureConditions(err, volumegroupv1.ConditionDriverReachable, driverConnection), parametersValidCondition)...)
+	}
+
+	driverReachableCondition := utils.GenerateCondition(volumegroupv1.ConditionDriverReachable, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, "")
+	if err = utils.ValidateVGClassCapabilities(vgClass, driver.Capabilities()); err != nil {
+		logger.Error(err, "failed to validate settings of volumegroupClass", "VGClassName", vgClass.Name)
+		return ctrl.Result{}, utils.UpdateVGClassStatusConditions(ctx, r.Client, vgClass, logger,
+			append(utils.GenerateFailureConditions(err, volumegroupv1.ConditionSettingsSupported, vgClassReconcile),
+				parametersValidCondition, driverReachableCondition)...)
+	}
+
+	err = utils.UpdateVGClassStatusConditions(ctx, r.Client, vgClass, logger, parametersValidCondition, driverReachableCondition,
+		utils.GenerateCondition(volumegroupv1.ConditionSettingsSupported, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, ""),
+		utils.GenerateCondition(volumegroupv1.ConditionReady, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, ""))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if vgClass.VolumeGroupDiscovery == nil || r.DriverConfig.DiscoveryInterval == 0 {
+		return ctrl.Result{}, nil
+	}
+	if err = r.discoverVGs(ctx, driver, logger, vgClass); err != nil {
+		if uErr := utils.UpdateVGClassStatusConditions(ctx, r.Client, vgClass, logger,
+			utils.GenerateFailureConditions(err, volumegroupv1.ConditionDriverReachable, discoverVGs)...); uErr != nil {
+			return ctrl.Result{}, uErr
+		}
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.DriverConfig.DiscoveryInterval}, nil
+}
+
+func (r *VolumeGroupClassReconciler) discoverVGs(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, vgClass *volumegroupv1.VolumeGroupClass) error {
+	secret, err := utils.GetSecretDataFromClass(ctx, r.Client, vgClass, logger)
+	if err != nil {
+		return err
+	}
+	return utils.DiscoverVGs(ctx, logger, r.Client, driver.VolumeGroup, vgClass, secret)
+}
+
+// watchDrivers rediscovers the drivers periodically and when the connection to them comes back, and requeues
+// their volumeGroupClasses every time the connection goes down or comes back or their capabilities change.
+func (r *VolumeGroupClassReconciler) watchDrivers(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, driverName := range r.Drivers.Names() {
+		driver, _ := r.Drivers.Get(driverName)
+		setDriverReachableMetric(driver, driver.Client.IsReachable(ctx))
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			driver.Client.WatchConnection(ctx, func(reachable bool) {
+				r.handleDriverConnectionChange(ctx, driver, reachable)
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			r.discoverDriverPeriodically(ctx, driver)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+func (r *VolumeGroupClassReconciler) handleDriverConnectionChange(ctx context.Context, driver *grpcClient.Driver, reachable bool) {
+	setDriverReachableMetric(driver, reachable)
+	if reachable {
+		r.Log.Info(fmt.Sprintf(messages.DriverIsReachable, driver.Name))
+		r.discoverDriver(ctx, driver)
+	} else {
+		r.Log.Info(fmt.Sprintf(messages.DriverIsUnreachable, driver.Name))
+	}
+	r.requeueVGClasses(ctx, driver)
+}
+
+func (r *VolumeGroupClassReconciler) discoverDriverPeriodically(ctx context.Context, driver *grpcClient.Driver) {
+	if r.DriverConfig.CapabilityDiscoveryInterval == 0 {
+		return
+	}
+	ticker := time.NewTicker(r.DriverConfig.CapabilityDiscoveryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if driver.Client.IsReachable(ctx) && r.discoverDriver(ctx, driver) {
+			r.requeueVGClasses(ctx, driver)
+		}
+	}
+}
+
+// discoverDriver discovers the capabilities of the driver and returns whether they changed.
+func (r *VolumeGroupClassReconciler) discoverDriver(ctx context.Context, driver *grpcClient.Driver) bool {
+	isChanged, err := driver.Discover(ctx)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf(messages.FailedToDiscoverDriver, driver.Name))
+		return false
+	}
+	if isChanged {
+		r.Log.Info(fmt.Sprintf(messages.DriverCapabilitiesDiscovered, driver.Name, driver.Capabilities()))
+	}
+	return isChanged
+}
+
+func (r *VolumeGroupClassReconciler) requeueVGClasses(ctx context.Context, driver *grpcClient.Driver) {
+	vgClasses, err := utils.GetVGClassList(ctx, r.Log, r.Client, driver.Name)
+	if err != nil {
+		return
+	}
+	for i := range vgClasses {
+		select {
+		case r.driverEvents <- event.GenericEvent{Object: &vgClasses[i]}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func setDriverReachableMetric(driver *grpcClient.Driver, reachable bool) {
+	value := 0.0
+	if reachable {
+		value = 1
+	}
+	metrics.DriverReachable.WithLabelValues(driver.Name).Set(value)
+}
+
+func (r *VolumeGroupClassReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.DriverConfig) error {
+	r.driverEvents = make(chan event.GenericEvent)
+	if err := mgr.Add(manager.RunnableFunc(r.watchDrivers)); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&volumegroupv1.VolumeGroupClass{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WatchesRawSource(source.Channel(r.driverEvents, &handler.EnqueueRequestForObject{})).
+		WithOptions(controller.Options{MaxConcurrentReconciles: cfg.VGClassMaxConcurrentReconciles}).
+		Complete(r)
+}
@@ -21,4 +21,6 @@ var (
 	deleteVGC       = "deletingVG"
 	createVGC       = "creatingVG"
 	updateStatusVGC = "updatingStatusVGC"
+	verifyVGC       = "verifyingVGC"
+	refreshMembers  = "refreshingMembers"
 )
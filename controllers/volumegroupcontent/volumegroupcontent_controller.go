@@ -27,12 +27,20 @@ import (
 	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
 	"github.com/IBM/csi-volume-group-operator/pkg/config"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
 	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
@@ -41,15 +49,38 @@ type VolumeGroupContentReconciler struct {
 	Log          logr.Logger
 	Scheme       *runtime.Scheme
 	DriverConfig *config.DriverConfig
-	GRPCClient   *grpcClient.Client
-	VGClient     grpcClient.VolumeGroup
+	Drivers      grpcClient.Drivers
 }
 
-func (r *VolumeGroupContentReconciler) Reconcile(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *VolumeGroupContentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := r.Log.WithValues("Request.Name", req.Name, "Request.Namespace", req.Namespace)
 	logger.Info(messages.ReconcileVG)
 
-	vgc, err := utils.GetVGC(r.Client, logger, req.Name, req.Namespace)
+	result, err := r.reconcile(ctx, logger, req)
+	vgc := &volumegroupv1.VolumeGroupContent{}
+	if gErr := r.Client.Get(ctx, req.NamespacedName, vgc); gErr != nil || !r.isServed(ctx, logger, vgc) {
+		return result, err
+	}
+	return utils.HandleVGCRetry(ctx, logger, r.Client, vgc, result, err, r.DriverConfig.RetryMaxDelay)
+}
+
+// isServed returns false for a volumeGroupContent whose driver is served by another instance of the operator,
+// its retry state is handled by that instance.
+func (r *VolumeGroupContentReconciler) isServed(ctx context.Context, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) bool {
+	vgClassName := utils.GetStringField(vgc.Spec, "VolumeGroupClassName")
+	if vgClassName == "" {
+		return true
+	}
+	vgClass, err := utils.GetVGClass(ctx, r.Client, logger, vgClassName)
+	if err != nil {
+		return true
+	}
+	_, ok := r.Drivers.Get(vgClass.Driver)
+	return ok
+}
+
+func (r *VolumeGroupContentReconciler) reconcile(ctx context.Context, logger logr.Logger, req ctrl.Request) (ctrl.Result, error) {
+	vgc, err := utils.GetVGC(ctx, r.Client, logger, req.Name, req.Namespace)
 	if err != nil {
 		if errors.IsNotFound(err) {
 
@@ -57,80 +88,109 @@ func (r *VolumeGroupContentReconciler) Reconcile(_ context.Context, req ctrl.Req
 
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, utils.HandleVGCErrorMessage(logger, r.Client, vgc, err, vgcReconcile)
+		return ctrl.Result{}, utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, volumegroupv1.ConditionReady, vgcReconcile)
 	}
 
 	vgClassName := utils.GetStringField(vgc.Spec, "VolumeGroupClassName")
 	if vgClassName == "" {
-		if err := utils.UpdateThinVGC(r.Client, vgc.Namespace, vgc.Name, logger); err != nil {
+		if err := utils.UpdateThinVGC(ctx, r.Client, vgc.Namespace, vgc.Name, logger); err != nil {
 			return ctrl.Result{}, err
 		}
-		if err := utils.UpdateVGCStatus(r.Client, logger, vgc, utils.GetCurrentTime(), false); err != nil {
+		if err := utils.UpdateVGCStatus(ctx, r.Client, logger, vgc, utils.GetCurrentTime(), false); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{}, nil
 	}
 
-	vgClass, err := utils.GetVGClass(r.Client, logger, vgClassName)
+	vgClass, err := utils.GetVGClass(ctx, r.Client, logger, vgClassName)
 	if err != nil {
-		return ctrl.Result{}, utils.HandleVGCErrorMessage(logger, r.Client, vgc, err, vgcReconcile)
+		return ctrl.Result{}, utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, volumegroupv1.ConditionReady, vgcReconcile)
 	}
 
-	if r.DriverConfig.DriverName != vgClass.Driver {
+	driver, ok := r.Drivers.Get(vgClass.Driver)
+	if !ok {
 		return ctrl.Result{}, nil
 	}
+	if err = utils.CheckDriverReachable(ctx, logger, driver); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err = utils.MigrateVGCMembers(ctx, logger, r.Client, vgc); err != nil {
+		return ctrl.Result{}, utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, volumegroupv1.ConditionReady, vgcReconcile)
+	}
 
 	if err = utils.ValidatePrefixedParameters(vgClass.Parameters); err != nil {
 		logger.Error(err, "failed to validate parameters of volumegroupClass", "VGClassName", vgClass.Name)
-		if uErr := utils.UpdateVGCStatusError(r.Client, vgc, logger, err.Error()); uErr != nil {
+		if uErr := utils.UpdateVGCStatusConditions(ctx, r.Client, vgc, logger,
+			utils.GenerateFailureConditions(err, volumegroupv1.ConditionParametersValid, vgcReconcile)...); uErr != nil {
 			return ctrl.Result{}, uErr
 		}
 		return ctrl.Result{}, err
 	}
-	secret, err := utils.GetSecretDataFromClass(r.Client, vgClass, logger)
+	if err = utils.UpdateVGCStatusConditions(ctx, r.Client, vgc, logger, utils.GenerateCondition(
+		volumegroupv1.ConditionParametersValid, metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, "")); err != nil {
+		return ctrl.Result{}, err
+	}
+	secret, err := utils.GetSecretDataFromClass(ctx, r.Client, vgClass, logger)
 	if err != nil {
-		return ctrl.Result{}, utils.HandleVGCErrorMessage(logger, r.Client, vgc, err, vgcReconcile)
+		return ctrl.Result{}, utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, volumegroupv1.ConditionReady, vgcReconcile)
 	}
 
 	if vgc.GetDeletionTimestamp().IsZero() {
-		if err = utils.AddFinalizerToVGC(r.Client, logger, vgc); err != nil {
-			return ctrl.Result{}, utils.HandleVGCErrorMessage(logger, r.Client, vgc, err, createVGC)
+		if err = utils.AddFinalizerToVGC(ctx, r.Client, logger, vgc); err != nil {
+			return ctrl.Result{}, utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, volumegroupv1.ConditionBackendGroupCreated, createVGC)
 		}
 	} else {
-		if err = r.handleVGCWithDeletionTimestamp(logger, vgc, secret); err != nil {
-			return ctrl.Result{}, utils.HandleVGCErrorMessage(logger, r.Client, vgc, err, deleteVGC)
+		if err = utils.UpdateVGCStatusConditions(ctx, r.Client, vgc, logger, utils.GenerateCondition(
+			volumegroupv1.ConditionDeleting, metav1.ConditionTrue, volumegroupv1.ReasonDeletionRequested,
+			fmt.Sprintf(messages.VGCDeletionRequested, vgc.Namespace, vgc.Name))); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err = r.handleVGCWithDeletionTimestamp(ctx, driver, logger, vgc, secret); err != nil {
+			return ctrl.Result{}, utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, volumegroupv1.ConditionDeleting, deleteVGC)
 		}
 		return ctrl.Result{}, nil
 	}
 
-	err, isStaticProvisioned := r.handleStaticProvisionedVGC(vgc, logger)
+	if err = r.refreshMembers(ctx, logger, vgc); err != nil {
+		return ctrl.Result{}, utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, volumegroupv1.ConditionPVsBound, refreshMembers)
+	}
+
+	err, isStaticProvisioned := r.handleStaticProvisionedVGC(ctx, vgc, logger)
 	if isStaticProvisioned {
-		return ctrl.Result{}, err
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.verifyMembership(ctx, driver, logger, vgc, vgClass, secret)
 	}
 
-	if err = r.handleCreateVG(logger, vgc, vgClass, secret); err != nil {
-		return ctrl.Result{}, utils.HandleVGCErrorMessage(logger, r.Client, vgc, err, createVGC)
+	isInProgress, err := r.handleCreateVG(ctx, driver, logger, vgc, vgClass, secret)
+	if err != nil {
+		return ctrl.Result{}, utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, volumegroupv1.ConditionBackendGroupCreated, createVGC)
+	}
+	if isInProgress {
+		return ctrl.Result{Requeue: true}, nil
 	}
 
-	if err = utils.CreateSuccessVGCEvent(logger, r.Client, vgc); err != nil {
-		return ctrl.Result{}, utils.HandleVGCErrorMessage(logger, r.Client, vgc, err, vgcReconcile)
+	if err = utils.CreateSuccessVGCEvent(ctx, logger, r.Client, vgc); err != nil {
+		return ctrl.Result{}, utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, volumegroupv1.ConditionReady, vgcReconcile)
 	}
 	return ctrl.Result{}, nil
 }
 
-func (r *VolumeGroupContentReconciler) handleVGCWithDeletionTimestamp(logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent, secret map[string]string) error {
-	if isVgExist, err := utils.IsVgExist(r.Client, logger, vgc); err != nil {
+func (r *VolumeGroupContentReconciler) handleVGCWithDeletionTimestamp(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent, secret map[string]string) error {
+	if isVgExist, err := utils.IsVgExist(ctx, r.Client, logger, vgc); err != nil {
 		return err
 	} else if isVgExist {
 		return fmt.Errorf(messages.VgIsStillExist, vgc.Name, vgc.Namespace)
 	}
 	if commonUtils.Contains(vgc.GetFinalizers(), utils.VgcFinalizer) && !utils.IsContainOtherFinalizers(vgc, logger) {
 		if r.DriverConfig.DisableDeletePvcs == "false" {
-			if err := utils.DeletePVCsUnderVGC(logger, r.Client, vgc, r.DriverConfig.DriverName); err != nil {
+			if err := utils.DeletePVCsUnderVGC(ctx, logger, r.Client, vgc, driver.Name); err != nil {
 				return err
 			}
 		}
-		if err := r.removeVGC(logger, vgc, secret); err != nil {
+		if err := r.removeVGC(ctx, driver, logger, vgc, secret); err != nil {
 			return err
 		}
 		logger.Info("VolumeGroupContent object is terminated, skipping reconciliation")
@@ -138,30 +198,31 @@ func (r *VolumeGroupContentReconciler) handleVGCWithDeletionTimestamp(logger log
 	return nil
 }
 
-func (r *VolumeGroupContentReconciler) removeVGC(logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent, secret map[string]string) error {
+func (r *VolumeGroupContentReconciler) removeVGC(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent, secret map[string]string) error {
 	if *vgc.Spec.VolumeGroupDeletionPolicy == volumegroupv1.VolumeGroupContentDelete {
 		vgId := vgc.Spec.Source.VolumeGroupHandle
-		if err := r.deleteVG(logger, vgId, secret); err != nil {
+		if err := r.deleteVG(ctx, driver, logger, vgId, secret); err != nil {
 			return err
 		}
 	}
-	err := utils.RemoveFinalizerFromVGC(r.Client, logger, vgc)
+	err := utils.RemoveFinalizerFromVGC(ctx, r.Client, logger, vgc)
 	if err != nil {
 		return err
 	}
+	metrics.DeleteVGCMetrics(vgc.Namespace, vgc.Name)
 	return nil
 }
 
-func (r *VolumeGroupContentReconciler) deleteVG(logger logr.Logger, vgId string, secrets map[string]string) error {
+func (r *VolumeGroupContentReconciler) deleteVG(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, vgId string, secrets map[string]string) error {
 	param := volumegroup.CommonRequestParameters{
 		VolumeGroupID: vgId,
 		Secrets:       secrets,
-		VolumeGroup:   r.VGClient,
+		VolumeGroup:   driver.VolumeGroup,
 	}
 
 	volumeGroupRequest := volumegroup.NewVolumeGroupRequest(param)
 
-	resp := volumeGroupRequest.Delete()
+	resp := volumeGroupRequest.Delete(ctx)
 
 	if resp.Error != nil {
 		logger.Error(resp.Error, "failed to delete volume group")
@@ -171,74 +232,218 @@ func (r *VolumeGroupContentReconciler) deleteVG(logger logr.Logger, vgId string,
 	return nil
 }
 
-func (r *VolumeGroupContentReconciler) handleCreateVG(logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent, vgClass *volumegroupv1.VolumeGroupClass, secret map[string]string) error {
+// handleCreateVG creates the volume group on the storage. The creation is marked on the volumeGroupContent
+// before the call, CreateVolumeGroup is idempotent by name so an interrupted creation is resumed by issuing it again.
+// It returns true when the creation is still in progress on the storage.
+func (r *VolumeGroupContentReconciler) handleCreateVG(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent,
+	vgClass *volumegroupv1.VolumeGroupClass, secret map[string]string) (bool, error) {
+	if utils.IsVGCreationInProgress(vgc) {
+		logger.Info(fmt.Sprintf(messages.ResumeVGCreation, vgc.Namespace, vgc.Name))
+	} else if err := utils.MarkVGCreationInProgress(ctx, r.Client, logger, vgc); err != nil {
+		return false, err
+	}
 	parameters := utils.FilterPrefixedParameters(utils.VGAsPrefix, vgClass.Parameters)
-	createVGResponse := r.createVG(vgc.Name, parameters, secret)
+	createVGResponse := r.createVG(ctx, driver, vgc.Name, parameters, secret)
+	if createVGResponse.IsInProgress() {
+		return true, r.updateVGCreationInProgress(ctx, logger, vgc, createVGResponse.Error)
+	}
 	if createVGResponse.Error != nil {
 		logger.Error(createVGResponse.Error, "failed to create volume group")
-		return createVGResponse.Error
+		if createVGResponse.IsFinalError() {
+			if err := utils.UnmarkVGCreationInProgress(ctx, r.Client, vgc); err != nil {
+				return false, err
+			}
+		}
+		return false, createVGResponse.Error
 	}
-	if err := utils.UpdateVGCByResponse(r.Client, vgc, createVGResponse); err != nil {
-		return err
+	if err := utils.RecordCreatedVG(ctx, r.Client, logger, vgClass, vgc, utils.GetCreatedVGId(createVGResponse)); err != nil {
+		return false, err
 	}
-	if err := utils.UpdateVGCStatus(r.Client, logger, vgc, utils.GetCurrentTime(), true); err != nil {
-		return utils.HandleVGCErrorMessage(logger, r.Client, vgc, err, updateStatusVGC)
+	if err := utils.UpdateVGCByResponse(ctx, r.Client, vgc, createVGResponse); err != nil {
+		return false, err
 	}
-	return nil
+	// The orphaned volume group collector forgets the volume group once it is referenced when this fails.
+	_ = utils.ForgetCreatedVGs(ctx, r.Client, logger, vgClass, vgc.Spec.Source.VolumeGroupHandle)
+	utils.SetVGCConditions(vgc, utils.GenerateCondition(volumegroupv1.ConditionDriverReachable,
+		metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, ""))
+	if err := utils.UpdateVGCStatus(ctx, r.Client, logger, vgc, utils.GetCurrentTime(), true); err != nil {
+		return false, utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, volumegroupv1.ConditionReady, updateStatusVGC)
+	}
+	return false, nil
 }
 
-func (r *VolumeGroupContentReconciler) createVG(vgName string, parameters, secrets map[string]string) *volumegroup.Response {
+func (r *VolumeGroupContentReconciler) updateVGCreationInProgress(ctx context.Context, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent,
+	err error) error {
+	message := fmt.Sprintf(messages.VGCreationInProgress, vgc.Namespace, vgc.Name, utils.GetMessageFromError(err))
+	logger.Info(message)
+	return utils.UpdateVGCStatusConditions(ctx, r.Client, vgc, logger,
+		utils.GenerateCondition(volumegroupv1.ConditionBackendGroupCreated, metav1.ConditionFalse, volumegroupv1.ReasonInProgress, message),
+		utils.GenerateCondition(volumegroupv1.ConditionReady, metav1.ConditionFalse, volumegroupv1.ReasonInProgress, message))
+}
+
+func (r *VolumeGroupContentReconciler) createVG(ctx context.Context, driver *grpcClient.Driver, vgName string, parameters, secrets map[string]string) *volumegroup.Response {
 	param := volumegroup.CommonRequestParameters{
 		Name:        vgName,
 		Parameters:  parameters,
 		Secrets:     secrets,
-		VolumeGroup: r.VGClient,
+		VolumeGroup: driver.VolumeGroup,
 	}
 
 	volumeGroupRequest := volumegroup.NewVolumeGroupRequest(param)
 
-	resp := volumeGroupRequest.Create()
+	resp := volumeGroupRequest.Create(ctx)
 
 	return resp
 }
 
-func (r *VolumeGroupContentReconciler) handleStaticProvisionedVGC(vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger) (error, bool) {
+// refreshMembers updates the persistentVolume members of the volumeGroupContent from the cluster. A persistentVolume that
+// is deleted, rebound or released turns PVsBound to false, which triggers the volumeGroup to fix its membership.
+func (r *VolumeGroupContentReconciler) refreshMembers(ctx context.Context, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
+	pvsBound, err := utils.RefreshVGCMembers(ctx, logger, r.Client, vgc)
+	if err != nil {
+		return err
+	}
+	return utils.UpdateVGCStatusConditions(ctx, r.Client, vgc, logger, pvsBound)
+}
+
+func (r *VolumeGroupContentReconciler) handleStaticProvisionedVGC(ctx context.Context, vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger) (error, bool) {
 	if vgcSpec := utils.GetObjectField(vgc.Spec, "Source"); !vgcSpec.IsNil() {
 		if vgc.Spec.Source.VolumeGroupHandle != "" {
-			return r.updateStaticVGC(vgc, logger), true
+			return r.updateStaticVGC(ctx, vgc, logger), true
 		}
 	}
 	return nil, false
 }
 
-func (r *VolumeGroupContentReconciler) updateStaticVGC(vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger) error {
-	if err := r.updateStaticVGCSpec(vgc, logger); err != nil {
+func (r *VolumeGroupContentReconciler) updateStaticVGC(ctx context.Context, vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger) error {
+	if err := r.updateStaticVGCSpec(ctx, vgc, logger); err != nil {
 		return err
 	}
-	if err := utils.UpdateVGCStatus(r.Client, logger, vgc, utils.GetCurrentTime(), true); err != nil {
+	if err := utils.UpdateVGCStatus(ctx, r.Client, logger, vgc, utils.GetCurrentTime(), true); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *VolumeGroupContentReconciler) updateStaticVGCSpec(vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger) error {
-	vgClass, err := utils.GetVGClass(r.Client, logger, *vgc.Spec.VolumeGroupClassName)
+func (r *VolumeGroupContentReconciler) updateStaticVGCSpec(ctx context.Context, vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger) error {
+	vgClass, err := utils.GetVGClass(ctx, r.Client, logger, *vgc.Spec.VolumeGroupClassName)
 	if err != nil {
 		return err
 	}
-	if err = utils.UpdateStaticVGC(r.Client, vgc.Namespace, vgc.Name, vgClass, logger); err != nil {
+	if err = utils.UpdateStaticVGC(ctx, r.Client, vgc.Namespace, vgc.Name, vgClass, logger); err != nil {
 		return err
 	}
 	return nil
 }
 
+func (r *VolumeGroupContentReconciler) verifyMembership(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent,
+	vgClass *volumegroupv1.VolumeGroupClass, secret map[string]string) (ctrl.Result, error) {
+	if r.DriverConfig.DriftCheckInterval == 0 {
+		return ctrl.Result{}, nil
+	}
+	if !driver.Capabilities().ControllerGetVolumeGroup {
+		logger.Info(fmt.Sprintf(messages.GetVGIsNotSupportedByDriver, driver.Name))
+		return ctrl.Result{}, nil
+	}
+	if err := r.checkMembershipDrift(ctx, driver, logger, vgc, vgClass, secret); err != nil {
+		return ctrl.Result{}, utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, volumegroupv1.ConditionDriverReachable, verifyVGC)
+	}
+	return ctrl.Result{RequeueAfter: r.DriverConfig.DriftCheckInterval}, nil
+}
+
+func (r *VolumeGroupContentReconciler) checkMembershipDrift(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent,
+	vgClass *volumegroupv1.VolumeGroupClass, secret map[string]string) error {
+	vgId := vgc.Spec.Source.VolumeGroupHandle
+	logger.Info(fmt.Sprintf(messages.GetVGOnStorage, vgId, vgc.Namespace, vgc.Name))
+	getVGResponse := r.getVG(ctx, driver, vgId, secret)
+	if getVGResponse.Error != nil {
+		if status.Code(getVGResponse.Error) == codes.Unimplemented {
+			logger.Info(messages.GetVGIsNotSupported)
+			return nil
+		}
+		logger.Error(getVGResponse.Error, "failed to get volume group")
+		return getVGResponse.Error
+	}
+
+	missingVolumeIds, unexpectedVolumeIds := utils.GetVGCMembershipDrift(vgc,
+		getVGResponse.Response.(*csi.ControllerGetVolumeGroupResponse))
+	driftedVolumes := len(missingVolumeIds) + len(unexpectedVolumeIds)
+	metrics.MembershipDriftVolumes.WithLabelValues(vgc.Namespace, vgc.Name).Set(float64(driftedVolumes))
+	driverReachable := utils.GenerateCondition(volumegroupv1.ConditionDriverReachable,
+		metav1.ConditionTrue, volumegroupv1.ReasonSucceeded, "")
+	if driftedVolumes == 0 {
+		return utils.UpdateVGCStatusConditions(ctx, r.Client, vgc, logger, driverReachable, utils.GenerateCondition(
+			volumegroupv1.ConditionMembershipDrift, metav1.ConditionFalse, volumegroupv1.ReasonSucceeded, ""))
+	}
+
+	// A drift is remediated only when it is seen by two checks in a row, so a membership change
+	// that is not in the volumeGroupContent status yet is not reverted.
+	if !meta.IsStatusConditionTrue(vgc.Status.Conditions, volumegroupv1.ConditionMembershipDrift) {
+		message := fmt.Sprintf(messages.VGCMembershipDrift, vgc.Namespace, vgc.Name, missingVolumeIds, unexpectedVolumeIds)
+		if err := utils.UpdateVGCStatusConditions(ctx, r.Client, vgc, logger, driverReachable, utils.GenerateCondition(
+			volumegroupv1.ConditionMembershipDrift, metav1.ConditionTrue, volumegroupv1.ReasonDriftDetected, message)); err != nil {
+			return err
+		}
+		return utils.CreateVGCMembershipDriftEvent(ctx, logger, r.Client, vgc, message)
+	}
+	// An unbound volumeGroupContent, like a discovered one, may not have a persistentVolume
+	// for every volume on the storage, so its drift is never remediated.
+	if !utils.IsMembershipDriftRemediated(vgClass) || vgc.Spec.VolumeGroupRef == nil {
+		return nil
+	}
+	return r.remediateMembershipDrift(ctx, driver, logger, vgc, secret)
+}
+
+func (r *VolumeGroupContentReconciler) remediateMembershipDrift(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent,
+	secret map[string]string) error {
+	param := volumegroup.CommonRequestParameters{
+		VolumeGroupID: vgc.Spec.Source.VolumeGroupHandle,
+		VolumeIds:     utils.GetVolumeIdsFromVGC(vgc),
+		Secrets:       secret,
+		VolumeGroup:   driver.VolumeGroup,
+	}
+	logger.Info(fmt.Sprintf(messages.ModifyVG, param.VolumeGroupID, param.VolumeIds))
+	resp := volumegroup.NewVolumeGroupRequest(param).Modify(ctx)
+	if resp.Error != nil {
+		logger.Error(resp.Error, "failed to remediate volume group membership")
+		return resp.Error
+	}
+	metrics.MembershipDriftRemediationsTotal.WithLabelValues(vgc.Namespace, vgc.Name).Inc()
+	metrics.MembershipDriftVolumes.WithLabelValues(vgc.Namespace, vgc.Name).Set(0)
+	if err := utils.UpdateVGCStatusConditions(ctx, r.Client, vgc, logger, utils.GenerateCondition(
+		volumegroupv1.ConditionMembershipDrift, metav1.ConditionFalse, volumegroupv1.ReasonDriftRemediated,
+		fmt.Sprintf(messages.VGCMembershipDriftRemediated, vgc.Namespace, vgc.Name))); err != nil {
+		return err
+	}
+	return utils.CreateVGCMembershipRemediatedEvent(ctx, logger, r.Client, vgc)
+}
+
+func (r *VolumeGroupContentReconciler) getVG(ctx context.Context, driver *grpcClient.Driver, vgId string, secrets map[string]string) *volumegroup.Response {
+	param := volumegroup.CommonRequestParameters{
+		VolumeGroupID: vgId,
+		Secrets:       secrets,
+		VolumeGroup:   driver.VolumeGroup,
+	}
+
+	volumeGroupRequest := volumegroup.NewVolumeGroupRequest(param)
+
+	resp := volumeGroupRequest.Get(ctx)
+
+	return resp
+}
+
 func (r *VolumeGroupContentReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.DriverConfig) error {
-	r.VGClient = grpcClient.NewVolumeGroupClient(r.GRPCClient.Client, cfg.RPCTimeout)
 
 	generationPred := predicate.GenerationChangedPredicate{}
 	pred := predicate.Or(generationPred, utils.FinalizerPredicate)
 
+	if err := utils.AddVGCIndexers(mgr); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&volumegroupv1.VolumeGroupContent{}, builder.WithPredicates(pred)).
+		Watches(&corev1.PersistentVolume{}, utils.CreatePVRequests(r.Client), builder.WithPredicates(utils.PVPredicate)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: cfg.VGCMaxConcurrentReconciles}).
 		Complete(r)
 }
@@ -0,0 +1,224 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumegroupsnapshot
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	commonUtils "github.com/IBM/csi-volume-group-operator/controllers/common/utils"
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+type VolumeGroupSnapshotReconciler struct {
+	client.Client
+	Log          logr.Logger
+	Scheme       *runtime.Scheme
+	DriverConfig *config.DriverConfig
+	Drivers      grpcClient.Drivers
+}
+
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupsnapshots,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupsnapshots/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupsnapshots/finalizers,verbs=update
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupsnapshotclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupsnapshotcontents,verbs=get;list;watch;create;update;patch;delete
+
+func (r *VolumeGroupSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("Request.Name", req.Name, "Request.Namespace", req.Namespace)
+	logger.Info(messages.ReconcileVGS)
+
+	vgs, err := utils.GetVGS(ctx, r.Client, logger, req.Name, req.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+
+			logger.Info("VolumeGroupSnapshot resource not found")
+
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	vgsClass, err := utils.GetVGSClass(ctx, r.Client, logger, utils.GetStringField(vgs.Spec, "VolumeGroupSnapshotClassName"))
+	if err != nil {
+		return ctrl.Result{}, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionReady, vgsReconcile)
+	}
+
+	if _, ok := r.Drivers.Get(vgsClass.Driver); !ok {
+		return ctrl.Result{}, nil
+	}
+
+	if err = utils.ValidatePrefixedParameters(vgsClass.Parameters); err != nil {
+		logger.Error(err, "failed to validate parameters of volumeGroupSnapshotClass", "VGSClassName", vgsClass.Name)
+		return ctrl.Result{}, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionParametersValid, vgsReconcile)
+	}
+
+	if vgs.GetDeletionTimestamp().IsZero() {
+		if err = utils.AddFinalizerToVGS(ctx, r.Client, logger, vgs); err != nil {
+			return ctrl.Result{}, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionReady, createVGS)
+		}
+	} else {
+		if err = utils.UpdateVGSStatusConditions(ctx, r.Client, vgs, logger, utils.GenerateCondition(
+			volumegroupv1.ConditionDeleting, metav1.ConditionTrue, volumegroupv1.ReasonDeletionRequested,
+			fmt.Sprintf(messages.VGSDeletionRequested, vgs.Namespace, vgs.Name))); err != nil {
+			return ctrl.Result{}, err
+		}
+		if commonUtils.Contains(vgs.GetFinalizers(), utils.VgsFinalizer) && !utils.IsContainOtherFinalizers(vgs, logger) {
+			if err = r.removeInstance(ctx, logger, vgs); err != nil {
+				return ctrl.Result{}, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionDeleting, deleteVGS)
+			}
+			logger.Info("volumeGroupSnapshot object is terminated, skipping reconciliation")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	vgsc, err := r.getOrCreateVGSC(ctx, logger, vgs, vgsClass)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if vgsc == nil {
+		// The volumeGroup binding and the volumeGroupSnapshotContent creation are watched.
+		return ctrl.Result{}, nil
+	}
+
+	if !utils.IsVGSCReady(vgsc) {
+		if err = utils.UpdateVGSStatusConditions(ctx, r.Client, vgs, logger, utils.GenerateCondition(
+			volumegroupv1.ConditionReady, metav1.ConditionFalse, volumegroupv1.ReasonPending,
+			fmt.Sprintf(messages.VGSCIsNotReady, vgsc.Namespace, vgsc.Name))); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if err = utils.UpdateVGSStatus(ctx, r.Client, vgs, vgsc.Name, vgsc.Status.CreationTime, logger); err != nil {
+		return ctrl.Result{}, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionBound, updateVGSC)
+	}
+	message := fmt.Sprintf(messages.VGSCreated, vgs.Namespace, vgs.Name)
+	if err = utils.HandleVGSSuccessMessage(ctx, logger, r.Client, vgs, message, volumegroupv1.ConditionReady, vgsReconcile); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// getOrCreateVGSC returns the volume group snapshot content bound to the volume group snapshot,
+// a nil content without an error means that the volume group is not ready to be snapshotted yet.
+func (r *VolumeGroupSnapshotReconciler) getOrCreateVGSC(ctx context.Context, logger logr.Logger, vgs *volumegroupv1.VolumeGroupSnapshot,
+	vgsClass *volumegroupv1.VolumeGroupSnapshotClass) (*volumegroupv1.VolumeGroupSnapshotContent, error) {
+	if vgs.Spec.Source.VolumeGroupSnapshotContentName != nil {
+		vgsc, err := utils.UpdateStaticVGSCFromVGS(ctx, r.Client, vgs, vgsClass, logger)
+		if err != nil {
+			return nil, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionBound, updateVGSC)
+		}
+		return vgsc, nil
+	}
+
+	if vgs.Spec.Source.VolumeGroupName == nil {
+		err := fmt.Errorf(messages.VGSourceIsMissing, vgs.Namespace, vgs.Name)
+		return nil, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionBound, createVGSC)
+	}
+	vg, err := utils.GetVG(ctx, r.Client, logger, *vgs.Spec.Source.VolumeGroupName, vgs.Namespace)
+	if err != nil {
+		return nil, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionBound, createVGSC)
+	}
+	vgcName := utils.GetStringField(vg.Status, "BoundVolumeGroupContentName")
+	if vgcName == "" {
+		return nil, utils.UpdateVGSStatusConditions(ctx, r.Client, vgs, logger, utils.GenerateCondition(
+			volumegroupv1.ConditionBound, metav1.ConditionFalse, volumegroupv1.ReasonPending,
+			fmt.Sprintf(messages.VGIsNotBound, vg.Namespace, vg.Name)))
+	}
+	vgc, err := utils.GetVGC(ctx, r.Client, logger, vgcName, vg.Namespace)
+	if err != nil {
+		return nil, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionBound, createVGSC)
+	}
+	if !utils.GetBoolField(vgc.Spec, "SupportVolumeGroupSnapshot") {
+		err = fmt.Errorf(messages.VGCDoesNotSupportSnapshot, vgc.Namespace, vgc.Name)
+		return nil, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionBound, createVGSC)
+	}
+
+	vgscName, err := utils.MakeVGName(utils.VGSNamePrefix, string(vgs.UID))
+	if err != nil {
+		return nil, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionBound, createVGSC)
+	}
+	vgsc := utils.GenerateVGSC(vgscName, vgs, vgc, vgsClass)
+	if err = utils.CreateVGSC(ctx, r.Client, logger, vgsc); err != nil {
+		return nil, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionBound, createVGSC)
+	}
+	vgsc, err = utils.GetVGSC(ctx, r.Client, logger, vgscName, vgs.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, utils.HandleVGSErrorMessage(ctx, logger, r.Client, vgs, err, volumegroupv1.ConditionBound, createVGSC)
+	}
+	return vgsc, nil
+}
+
+func (r *VolumeGroupSnapshotReconciler) removeInstance(ctx context.Context, logger logr.Logger, vgs *volumegroupv1.VolumeGroupSnapshot) error {
+	vgscName := utils.GetStringField(vgs.Status, "BoundVolumeGroupSnapshotContentName")
+	if vgscName == "" && vgs.Spec.Source.VolumeGroupSnapshotContentName == nil {
+		vgscName, _ = utils.MakeVGName(utils.VGSNamePrefix, string(vgs.UID))
+	}
+	if vgscName != "" {
+		vgsc, err := utils.GetVGSC(ctx, r.Client, logger, vgscName, vgs.Namespace)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+		} else if err = r.removeVGSCObject(ctx, logger, vgsc); err != nil {
+			return err
+		}
+	}
+	return utils.RemoveFinalizerFromVGS(ctx, r.Client, logger, vgs)
+}
+
+func (r *VolumeGroupSnapshotReconciler) removeVGSCObject(ctx context.Context, logger logr.Logger, vgsc *volumegroupv1.VolumeGroupSnapshotContent) error {
+	if utils.GetStringField(vgsc.Spec, "DeletionPolicy") == string(volumegroupv1.VolumeGroupSnapshotContentDelete) {
+		if err := r.Client.Delete(ctx, vgsc); err != nil {
+			logger.Error(err, "Failed to delete volume group snapshot content", "VGSCName", vgsc.Name)
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *VolumeGroupSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	generationPred := predicate.GenerationChangedPredicate{}
+	pred := predicate.Or(generationPred, utils.FinalizerPredicate)
+
+	if err := utils.AddVGSIndexers(mgr); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&volumegroupv1.VolumeGroupSnapshot{}, builder.WithPredicates(pred)).
+		Watches(&volumegroupv1.VolumeGroupSnapshotContent{}, utils.CreateVGSCRequests(), builder.WithPredicates(utils.VGSCPredicate)).
+		Watches(&volumegroupv1.VolumeGroup{}, utils.CreateSnapshotVGRequests(r.Client), builder.WithPredicates(utils.SnapshotVGPredicate)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.DriverConfig.VGSMaxConcurrentReconciles}).
+		Complete(r)
+}
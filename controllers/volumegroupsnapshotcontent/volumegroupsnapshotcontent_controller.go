@@ -0,0 +1,257 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumegroupsnapshotcontent
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	commonUtils "github.com/IBM/csi-volume-group-operator/controllers/common/utils"
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroup"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+type VolumeGroupSnapshotContentReconciler struct {
+	client.Client
+	Log          logr.Logger
+	Scheme       *runtime.Scheme
+	DriverConfig *config.DriverConfig
+	Drivers      grpcClient.Drivers
+}
+
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupsnapshotcontents,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupsnapshotcontents/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupsnapshotcontents/finalizers,verbs=update
+
+func (r *VolumeGroupSnapshotContentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("Request.Name", req.Name, "Request.Namespace", req.Namespace)
+	logger.Info(messages.ReconcileVGSC)
+
+	vgsc, err := utils.GetVGSC(ctx, r.Client, logger, req.Name, req.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+
+			logger.Info("VolumeGroupSnapshotContent resource not found")
+
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	driver, ok := r.Drivers.Get(vgsc.Spec.Source.Driver)
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+	if err = utils.CheckDriverReachable(ctx, logger, driver); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	secret, err := utils.GetSecretDataFromRef(ctx, r.Client, vgsc.Spec.VolumeGroupSnapshotSecretRef, logger)
+	if err != nil {
+		return ctrl.Result{}, utils.HandleVGSCErrorMessage(ctx, logger, r.Client, vgsc, err, volumegroupv1.ConditionReady, vgscReconcile)
+	}
+
+	if vgsc.GetDeletionTimestamp().IsZero() {
+		if err = utils.AddFinalizerToVGSC(ctx, r.Client, logger, vgsc); err != nil {
+			return ctrl.Result{}, utils.HandleVGSCErrorMessage(ctx, logger, r.Client, vgsc, err, volumegroupv1.ConditionReady, vgscReconcile)
+		}
+	} else {
+		if err = utils.UpdateVGSCStatusConditions(ctx, r.Client, vgsc, logger, utils.GenerateCondition(
+			volumegroupv1.ConditionDeleting, metav1.ConditionTrue, volumegroupv1.ReasonDeletionRequested,
+			fmt.Sprintf(messages.VGSCDeletionRequested, vgsc.Namespace, vgsc.Name))); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err = r.handleVGSCWithDeletionTimestamp(ctx, driver, logger, vgsc, secret); err != nil {
+			return ctrl.Result{}, utils.HandleVGSCErrorMessage(ctx, logger, r.Client, vgsc, err, volumegroupv1.ConditionDeleting, deleteVGSnapshot)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if vgsc.Spec.Source.VolumeGroupSnapshotHandle != "" {
+		if utils.IsVGSCReady(vgsc) {
+			return ctrl.Result{}, nil
+		}
+		if err = r.refreshVGSnapshot(ctx, driver, logger, vgsc, secret); err != nil {
+			return ctrl.Result{}, utils.HandleVGSCErrorMessage(ctx, logger, r.Client, vgsc, err, volumegroupv1.ConditionReady, getVGSnapshot)
+		}
+		return ctrl.Result{Requeue: !utils.IsVGSCReady(vgsc)}, nil
+	}
+
+	if err = r.handleCreateVGSnapshot(ctx, driver, logger, vgsc, secret); err != nil {
+		return ctrl.Result{}, utils.HandleVGSCErrorMessage(ctx, logger, r.Client, vgsc, err, volumegroupv1.ConditionSnapshotCreated, createVGSnapshot)
+	}
+	if err = utils.CreateSuccessVGSCEvent(ctx, logger, r.Client, vgsc); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{Requeue: !utils.IsVGSCReady(vgsc)}, nil
+}
+
+func (r *VolumeGroupSnapshotContentReconciler) handleCreateVGSnapshot(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger,
+	vgsc *volumegroupv1.VolumeGroupSnapshotContent, secret map[string]string) error {
+	if !driver.Capabilities().VolumeGroupSnapshot {
+		return status.Error(codes.Unimplemented, fmt.Sprintf(messages.VGSnapshotIsNotSupported, driver.Name))
+	}
+	vgc, err := utils.GetVGC(ctx, r.Client, logger, utils.GetStringField(vgsc.Spec.Source, "VolumeGroupContentName"), vgsc.Namespace)
+	if err != nil {
+		return err
+	}
+	volumeIds := utils.GetVolumeIdsFromVGC(vgc)
+	if len(volumeIds) == 0 {
+		return fmt.Errorf(messages.VGCHasNoVolumes, vgc.Namespace, vgc.Name)
+	}
+	parameters, err := r.getVGSnapshotParameters(ctx, logger, vgsc)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf(messages.CreateVGSnapshot, vgsc.Name, volumeIds))
+	resp := volumegroup.NewVolumeGroupRequest(volumegroup.CommonRequestParameters{
+		Name:                vgsc.Name,
+		VolumeIds:           volumeIds,
+		Parameters:          parameters,
+		Secrets:             secret,
+		VolumeGroupSnapshot: driver.VolumeGroupSnapshot,
+	}).CreateSnapshot(ctx)
+	if resp.Error != nil {
+		logger.Error(resp.Error, "failed to create volume group snapshot")
+		return resp.Error
+	}
+	groupSnapshot := resp.Response.(*csi.CreateVolumeGroupSnapshotResponse).GroupSnapshot
+	if err = utils.UpdateVGSCSource(ctx, r.Client, vgsc, groupSnapshot, logger); err != nil {
+		return err
+	}
+	return utils.UpdateVGSCStatus(ctx, r.Client, vgsc, groupSnapshot, vgc.Status.Members, logger)
+}
+
+func (r *VolumeGroupSnapshotContentReconciler) refreshVGSnapshot(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger,
+	vgsc *volumegroupv1.VolumeGroupSnapshotContent, secret map[string]string) error {
+	resp := volumegroup.NewVolumeGroupRequest(volumegroup.CommonRequestParameters{
+		VolumeGroupSnapshotID: vgsc.Spec.Source.VolumeGroupSnapshotHandle,
+		SnapshotIds:           utils.GetSnapshotIdsFromVGSC(vgsc),
+		Secrets:               secret,
+		VolumeGroupSnapshot:   driver.VolumeGroupSnapshot,
+	}).GetSnapshot(ctx)
+	if resp.Error != nil {
+		logger.Error(resp.Error, "failed to get volume group snapshot")
+		return resp.Error
+	}
+	groupSnapshot := resp.Response.(*csi.GetVolumeGroupSnapshotResponse).GroupSnapshot
+	members, err := r.getSnapshottedMembers(ctx, logger, vgsc)
+	if err != nil {
+		return err
+	}
+	return utils.UpdateVGSCStatus(ctx, r.Client, vgsc, groupSnapshot, members, logger)
+}
+
+func (r *VolumeGroupSnapshotContentReconciler) getSnapshottedMembers(ctx context.Context, logger logr.Logger,
+	vgsc *volumegroupv1.VolumeGroupSnapshotContent) ([]volumegroupv1.VolumeGroupMemberReference, error) {
+	vgcName := utils.GetStringField(vgsc.Spec.Source, "VolumeGroupContentName")
+	if vgcName == "" {
+		return nil, nil
+	}
+	vgc, err := utils.GetVGC(ctx, r.Client, logger, vgcName, vgsc.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return vgc.Status.Members, nil
+}
+
+func (r *VolumeGroupSnapshotContentReconciler) getVGSnapshotParameters(ctx context.Context, logger logr.Logger,
+	vgsc *volumegroupv1.VolumeGroupSnapshotContent) (map[string]string, error) {
+	vgsClassName := utils.GetStringField(vgsc.Spec, "VolumeGroupSnapshotClassName")
+	if vgsClassName == "" {
+		return map[string]string{}, nil
+	}
+	vgsClass, err := utils.GetVGSClass(ctx, r.Client, logger, vgsClassName)
+	if err != nil {
+		return nil, err
+	}
+	return utils.FilterPrefixedParameters(utils.VGAsPrefix, vgsClass.Parameters), nil
+}
+
+func (r *VolumeGroupSnapshotContentReconciler) handleVGSCWithDeletionTimestamp(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger,
+	vgsc *volumegroupv1.VolumeGroupSnapshotContent, secret map[string]string) error {
+	if isVgsExist, err := utils.IsVgsExist(ctx, r.Client, logger, vgsc); err != nil {
+		return err
+	} else if isVgsExist {
+		return fmt.Errorf(messages.VgsIsStillExist, vgsc.Namespace, vgsc.Name)
+	}
+	if commonUtils.Contains(vgsc.GetFinalizers(), utils.VgscFinalizer) && !utils.IsContainOtherFinalizers(vgsc, logger) {
+		if err := r.removeVGSC(ctx, driver, logger, vgsc, secret); err != nil {
+			return err
+		}
+		logger.Info("VolumeGroupSnapshotContent object is terminated, skipping reconciliation")
+	}
+	return nil
+}
+
+func (r *VolumeGroupSnapshotContentReconciler) removeVGSC(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger,
+	vgsc *volumegroupv1.VolumeGroupSnapshotContent, secret map[string]string) error {
+	isDeletePolicy := utils.GetStringField(vgsc.Spec, "DeletionPolicy") == string(volumegroupv1.VolumeGroupSnapshotContentDelete)
+	if isDeletePolicy && vgsc.Spec.Source.VolumeGroupSnapshotHandle != "" {
+		if err := r.deleteVGSnapshot(ctx, driver, logger, vgsc, secret); err != nil {
+			return err
+		}
+	}
+	return utils.RemoveFinalizerFromVGSC(ctx, r.Client, logger, vgsc)
+}
+
+func (r *VolumeGroupSnapshotContentReconciler) deleteVGSnapshot(ctx context.Context, driver *grpcClient.Driver, logger logr.Logger,
+	vgsc *volumegroupv1.VolumeGroupSnapshotContent, secret map[string]string) error {
+	snapshotIds := utils.GetSnapshotIdsFromVGSC(vgsc)
+	logger.Info(fmt.Sprintf(messages.DeleteVGSnapshot, vgsc.Spec.Source.VolumeGroupSnapshotHandle, snapshotIds))
+	resp := volumegroup.NewVolumeGroupRequest(volumegroup.CommonRequestParameters{
+		VolumeGroupSnapshotID: vgsc.Spec.Source.VolumeGroupSnapshotHandle,
+		SnapshotIds:           snapshotIds,
+		Secrets:               secret,
+		VolumeGroupSnapshot:   driver.VolumeGroupSnapshot,
+	}).DeleteSnapshot(ctx)
+	if resp.Error != nil {
+		logger.Error(resp.Error, "failed to delete volume group snapshot")
+		return resp.Error
+	}
+	return nil
+}
+
+func (r *VolumeGroupSnapshotContentReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.DriverConfig) error {
+	generationPred := predicate.GenerationChangedPredicate{}
+	pred := predicate.Or(generationPred, utils.FinalizerPredicate)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&volumegroupv1.VolumeGroupSnapshotContent{}, builder.WithPredicates(pred)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: cfg.VGSCMaxConcurrentReconciles}).
+		Complete(r)
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"time"
 
@@ -27,6 +30,7 @@ import (
 	"github.com/go-logr/logr"
 
 	uberzap "go.uber.org/zap"
+	"google.golang.org/grpc/keepalive"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -35,24 +39,49 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
 	"github.com/IBM/csi-volume-group-operator/controllers"
+	"github.com/IBM/csi-volume-group-operator/controllers/garbagecollector"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroupclass"
 	"github.com/IBM/csi-volume-group-operator/controllers/volumegroupcontent"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroupsnapshot"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroupsnapshotcontent"
 	//+kubebuilder:scaffold:imports
 )
 
 const (
 	// defaultTimeout is default timeout for RPC call.
 	defaultTimeout = time.Minute
+	// defaultDriftCheckInterval is default interval of membership drift checks.
+	defaultDriftCheckInterval = 5 * time.Minute
+	// defaultDiscoveryInterval is default interval of volume group discovery.
+	defaultDiscoveryInterval = 10 * time.Minute
+	// defaultCapabilityDiscoveryInterval is default interval of driver capability discovery.
+	defaultCapabilityDiscoveryInterval = 10 * time.Minute
+	// defaultOrphanGCInterval is default interval of orphaned volume group garbage collection.
+	defaultOrphanGCInterval = time.Hour
+	// defaultOrphanGCGracePeriod is default time an orphaned volume group is kept before it is deleted.
+	defaultOrphanGCGracePeriod = 24 * time.Hour
+	// defaultRetryMaxDelay is default maximum delay between retries of a failed reconcile.
+	defaultRetryMaxDelay = 5 * time.Minute
+	// defaultKeepaliveTimeout is default time to wait for a keepalive ping to be acknowledged.
+	defaultKeepaliveTimeout = 20 * time.Second
+	// defaultMembershipBatchMaxSize is default maximum number of membership changes in one membership modification.
+	defaultMembershipBatchMaxSize = 100
 )
 
 var (
-	scheme        = runtime.NewScheme()
-	setupLog      = ctrl.Log.WithName("setup")
-	vgcController = "VolumeGroupContentController"
+	scheme              = runtime.NewScheme()
+	setupLog            = ctrl.Log.WithName("setup")
+	vgcController       = "VolumeGroupContentController"
+	vgClassController   = "VolumeGroupClassController"
+	vgsController       = "VolumeGroupSnapshotController"
+	vgscController      = "VolumeGroupSnapshotContentController"
+	orphanedVGCollector = "OrphanedVolumeGroupCollector"
 )
 
 func init() {
@@ -71,6 +100,8 @@ func main() {
 
 	cfg := config.NewDriverConfig()
 
+	var probeAddr string
+	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	defineFlags(cfg)
 
 	opts.BindFlags(flag.CommandLine)
@@ -82,12 +113,13 @@ func main() {
 	exitWithError(err, "error in driver configuration")
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: scheme,
+		Scheme:                 scheme,
+		HealthProbeBindAddress: probeAddr,
 	})
 	exitWithError(err, "unable to start manager")
 
 	log := ctrl.Log.WithName("controllers").WithName("VolumeGroup")
-	grpcClientInstance, err := getControllerGrpcClient(cfg, log)
+	drivers, err := getDrivers(cfg, mgr.GetAPIReader(), log)
 	exitWithError(err, "failed to get controller GRPC client")
 
 	err = (&controllers.VolumeGroupReconciler{
@@ -95,7 +127,7 @@ func main() {
 		Log:          log,
 		Scheme:       mgr.GetScheme(),
 		DriverConfig: cfg,
-		GRPCClient:   grpcClientInstance,
+		Drivers:      drivers,
 	}).SetupWithManager(mgr, cfg)
 	exitWithError(err, messages.UnableToCreateVGController)
 
@@ -104,16 +136,50 @@ func main() {
 		Log:          ctrl.Log.WithName(vgcController),
 		Scheme:       mgr.GetScheme(),
 		DriverConfig: cfg,
-		GRPCClient:   grpcClientInstance,
+		Drivers:      drivers,
 	}).SetupWithManager(mgr, cfg)
 	exitWithError(err, messages.UnableToCreateVGCController)
 
+	err = (&volumegroupclass.VolumeGroupClassReconciler{
+		Client:       mgr.GetClient(),
+		Log:          ctrl.Log.WithName(vgClassController),
+		Scheme:       mgr.GetScheme(),
+		DriverConfig: cfg,
+		Drivers:      drivers,
+	}).SetupWithManager(mgr, cfg)
+	exitWithError(err, messages.UnableToCreateVGClassController)
+
+	err = (&volumegroupsnapshot.VolumeGroupSnapshotReconciler{
+		Client:       mgr.GetClient(),
+		Log:          ctrl.Log.WithName(vgsController),
+		Scheme:       mgr.GetScheme(),
+		DriverConfig: cfg,
+	}).SetupWithManager(mgr)
+	exitWithError(err, messages.UnableToCreateVGSController)
+
+	err = (&volumegroupsnapshotcontent.VolumeGroupSnapshotContentReconciler{
+		Client:       mgr.GetClient(),
+		Log:          ctrl.Log.WithName(vgscController),
+		Scheme:       mgr.GetScheme(),
+		DriverConfig: cfg,
+		Drivers:      drivers,
+	}).SetupWithManager(mgr, cfg)
+	exitWithError(err, messages.UnableToCreateVGSCController)
+
+	err = (&garbagecollector.OrphanedVolumeGroupCollector{
+		Client:       mgr.GetClient(),
+		Log:          ctrl.Log.WithName(orphanedVGCollector),
+		DriverConfig: cfg,
+		Drivers:      drivers,
+	}).SetupWithManager(mgr, cfg)
+	exitWithError(err, messages.UnableToCreateOrphanedVGCollector)
+
 	//+kubebuilder:scaffold:builder
 
 	err = mgr.AddHealthzCheck("healthz", healthz.Ping)
 	exitWithError(err, "unable to set up health check")
 
-	err = mgr.AddReadyzCheck("readyz", healthz.Ping)
+	err = mgr.AddReadyzCheck("readyz", drivers.Check)
 	exitWithError(err, "unable to set up ready check")
 
 	setupLog.Info("starting manager")
@@ -124,22 +190,122 @@ func main() {
 
 func defineFlags(cfg *config.DriverConfig) {
 	flag.StringVar(&cfg.DriverName, "driver-name", "", "The CSI driver name.")
-	flag.StringVar(&cfg.DriverEndpoint, "csi-address", "/run/csi/socket", "Address of the CSI driver socket.")
+	flag.StringVar(&cfg.DriverEndpoint, "csi-address", "/run/csi/socket", "Address of the CSI driver, a unix socket or a tcp:// or dns: endpoint.")
 	flag.DurationVar(&cfg.RPCTimeout, "rpc-timeout", defaultTimeout, "The timeout for RPCs to the CSI driver.")
+	flag.Var(&cfg.Drivers, "driver", "An additional CSI driver given as <name>=<endpoint>[,<rpcTimeout>], can be repeated.")
 	flag.StringVar(&cfg.MultipleVGsToPVC, "multiple-vgs-to-pvc", "true", "Can PVC be assigned to multiple VolumeGroups.")
 	flag.StringVar(&cfg.DisableDeletePvcs, "disable-delete-pvcs", "false", "Does volumeGroup deletion delete all its PVCs.")
+	flag.DurationVar(&cfg.DriftCheckInterval, "drift-check-interval", defaultDriftCheckInterval, "The interval of membership drift checks of volumeGroupContents, 0 disables them.")
+	flag.DurationVar(&cfg.DiscoveryInterval, "discovery-interval", defaultDiscoveryInterval, "The interval of volume group discovery of volumeGroupClasses that enable it.")
+	flag.DurationVar(&cfg.CapabilityDiscoveryInterval, "capability-discovery-interval", defaultCapabilityDiscoveryInterval, "The interval of identity and capability discovery of the CSI drivers, 0 disables it after the startup discovery.")
+	flag.DurationVar(&cfg.OrphanGCInterval, "orphan-gc-interval", defaultOrphanGCInterval, "The interval of orphaned volumeGroup garbage collection, 0 disables it.")
+	flag.DurationVar(&cfg.OrphanGCGracePeriod, "orphan-gc-grace-period", defaultOrphanGCGracePeriod, "The time an orphaned volumeGroup is kept before it is deleted.")
+	flag.DurationVar(&cfg.RetryMaxDelay, "retry-max-delay", defaultRetryMaxDelay, "The maximum delay between retries of a failed volumeGroup or volumeGroupContent reconcile.")
+	flag.DurationVar(&cfg.MembershipBatchWindow, "membership-batch-window", 0, "The time membership changes of a volumeGroup are coalesced for before they are sent to the storage, 0 disables it.")
+	flag.IntVar(&cfg.MembershipBatchMaxSize, "membership-batch-max-size", defaultMembershipBatchMaxSize, "The maximum number of membership changes of a volumeGroup in one membership modification, 0 means no limit.")
+	flag.IntVar(&cfg.VGMaxConcurrentReconciles, "vg-max-concurrent-reconciles", 1, "The maximum number of concurrent reconciles of the VolumeGroup controller.")
+	flag.IntVar(&cfg.VGCMaxConcurrentReconciles, "vgc-max-concurrent-reconciles", 1, "The maximum number of concurrent reconciles of the VolumeGroupContent controller.")
+	flag.IntVar(&cfg.VGClassMaxConcurrentReconciles, "vgclass-max-concurrent-reconciles", 1, "The maximum number of concurrent reconciles of the VolumeGroupClass controller.")
+	flag.IntVar(&cfg.VGSMaxConcurrentReconciles, "vgs-max-concurrent-reconciles", 1, "The maximum number of concurrent reconciles of the VolumeGroupSnapshot controller.")
+	flag.IntVar(&cfg.VGSCMaxConcurrentReconciles, "vgsc-max-concurrent-reconciles", 1, "The maximum number of concurrent reconciles of the VolumeGroupSnapshotContent controller.")
+	flag.Float64Var(&cfg.RPCQPS, "rpc-qps", 0, "The maximum rate of RPCs to all the CSI drivers per second, 0 means no limit.")
+	flag.IntVar(&cfg.RPCBurst, "rpc-burst", 0, "The maximum burst of RPCs to all the CSI drivers above the rpc-qps rate, 0 means the rpc-qps rate.")
+	flag.IntVar(&cfg.RPCMaxInFlight, "rpc-max-in-flight", 0, "The maximum number of RPCs in flight to all the CSI drivers, 0 means no limit.")
+	flag.StringVar(&cfg.RPCOperationLimits, "rpc-operation-limits", "", "The limits of RPCs to each CSI driver per operation, as a comma separated list of <operation>=<qps>:<burst>:<maxInFlight>.")
+	flag.StringVar(&cfg.UnsupportedOperations, "unsupported-operations", "", "The optional operations the CSI drivers do not support, as a comma separated list of ModifyVolumeGroupMembership, ControllerGetVolumeGroup and ListVolumeGroups.")
+	flag.BoolVar(&cfg.OrphanGCDryRun, "orphan-gc-dry-run", false, "Only report orphaned volumeGroups without deleting them.")
+	flag.StringVar(&cfg.TLSCAFile, "tls-ca-file", "", "The CA certificates that verify remote CSI driver endpoints, the system roots when not set.")
+	flag.StringVar(&cfg.TLSCertFile, "tls-cert-file", "", "The client certificate of mutual TLS with remote CSI driver endpoints.")
+	flag.StringVar(&cfg.TLSKeyFile, "tls-key-file", "", "The client key of mutual TLS with remote CSI driver endpoints.")
+	flag.StringVar(&cfg.TLSSecret, "tls-secret", "", "A secret given as <namespace>/<name> with the ca.crt, tls.crt and tls.key of remote CSI driver endpoints, instead of the TLS files.")
+	flag.StringVar(&cfg.TLSServerName, "tls-server-name", "", "The name that the certificates of remote CSI driver endpoints are verified against, the endpoint host when not set.")
+	flag.DurationVar(&cfg.KeepaliveTime, "keepalive-time", 0, "The time without activity after which remote CSI driver endpoints are pinged, 0 disables keepalive.")
+	flag.DurationVar(&cfg.KeepaliveTimeout, "keepalive-timeout", defaultKeepaliveTimeout, "The time to wait for a keepalive ping to be acknowledged before the connection is closed.")
+	flag.BoolVar(&cfg.KeepalivePermitWithoutCalls, "keepalive-permit-without-calls", false, "Ping remote CSI driver endpoints even when there are no RPCs in flight.")
+}
+
+func getDrivers(cfg *config.DriverConfig, reader client.Reader, log logr.Logger) (grpcClient.Drivers, error) {
+	operationLimits, err := grpcClient.ParseRateLimits(cfg.RPCOperationLimits)
+	if err != nil {
+		log.Error(err, "failed to parse RPC operation limits", "Limits", cfg.RPCOperationLimits)
+
+		return nil, err
+	}
+	unsupportedOperations, err := grpcClient.ParseUnsupportedOperations(cfg.UnsupportedOperations)
+	if err != nil {
+		log.Error(err, "failed to parse unsupported operations", "Operations", cfg.UnsupportedOperations)
+
+		return nil, err
+	}
+	connectionConfig := getConnectionConfig(cfg, reader)
+	driverEndpoints := cfg.GetDriverEndpoints()
+	driverNames := make([]string, 0, len(driverEndpoints))
+	for _, driverEndpoint := range driverEndpoints {
+		driverNames = append(driverNames, driverEndpoint.Name)
+	}
+	limiters := grpcClient.NewRPCLimiters(driverNames, grpcClient.RateLimit{
+		QPS:         cfg.RPCQPS,
+		Burst:       cfg.RPCBurst,
+		MaxInFlight: cfg.RPCMaxInFlight,
+	}, operationLimits)
+	drivers := grpcClient.Drivers{}
+	for _, driverEndpoint := range driverEndpoints {
+		grpcClientInstance, err := getControllerGrpcClient(driverEndpoint, connectionConfig, log)
+		if err != nil {
+			return nil, err
+		}
+		driver := grpcClient.NewDriver(driverEndpoint.Name, grpcClientInstance, limiters[driverEndpoint.Name])
+		driver.UnsupportedOperations = unsupportedOperations
+		drivers[driverEndpoint.Name] = driver
+		if _, err = driver.Discover(context.Background()); err != nil {
+			log.Error(err, "failed to discover driver", "Driver", driverEndpoint.Name, "Endpoint", driverEndpoint.Endpoint)
+			if errors.Is(err, grpcClient.ErrUnexpectedDriver) {
+				return nil, err
+			}
+			// The driver is discovered again periodically and when its connection comes back.
+			continue
+		}
+		log.Info("discovered driver", "Driver", driverEndpoint.Name, "Capabilities", fmt.Sprintf("%+v", driver.Capabilities()))
+	}
+	return drivers, nil
+}
+
+func getConnectionConfig(cfg *config.DriverConfig, reader client.Reader) grpcClient.ConnectionConfig {
+	connectionConfig := grpcClient.ConnectionConfig{
+		TLS: grpcClient.TLSConfig{
+			CAFile:     cfg.TLSCAFile,
+			CertFile:   cfg.TLSCertFile,
+			KeyFile:    cfg.TLSKeyFile,
+			ServerName: cfg.TLSServerName,
+			Reader:     reader,
+		},
+		Keepalive: keepalive.ClientParameters{
+			Time:                cfg.KeepaliveTime,
+			Timeout:             cfg.KeepaliveTimeout,
+			PermitWithoutStream: cfg.KeepalivePermitWithoutCalls,
+		},
+	}
+	if cfg.TLSSecret != "" {
+		// The secret is validated with the rest of the configuration.
+		connectionConfig.TLS.SecretNamespace, connectionConfig.TLS.SecretName, _ = cfg.GetTLSSecret()
+	}
+	return connectionConfig
 }
 
-func getControllerGrpcClient(cfg *config.DriverConfig, log logr.Logger) (*grpcClient.Client, error) {
-	grpcClientInstance, err := grpcClient.New(cfg.DriverEndpoint, cfg.RPCTimeout)
+func getControllerGrpcClient(driverEndpoint config.DriverEndpoint, connectionConfig grpcClient.ConnectionConfig,
+	log logr.Logger) (*grpcClient.Client, error) {
+	grpcClientInstance, err := grpcClient.New(driverEndpoint.Endpoint, driverEndpoint.Name, driverEndpoint.RPCTimeout,
+		connectionConfig)
 	if err != nil {
-		log.Error(err, "failed to create GRPC Client", "Endpoint", cfg.DriverEndpoint, "GRPC Timeout", cfg.RPCTimeout)
+		log.Error(err, "failed to create GRPC Client", "Driver", driverEndpoint.Name, "Endpoint", driverEndpoint.Endpoint,
+			"GRPC Timeout", driverEndpoint.RPCTimeout)
 
 		return nil, err
 	}
 	err = grpcClientInstance.Probe()
 	if err != nil {
-		log.Error(err, "failed to connect to driver", "Endpoint", cfg.DriverEndpoint, "GRPC Timeout", cfg.RPCTimeout)
+		log.Error(err, "failed to connect to driver", "Driver", driverEndpoint.Name, "Endpoint", driverEndpoint.Endpoint,
+			"GRPC Timeout", driverEndpoint.RPCTimeout)
 
 		return nil, err
 	}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	volumegroup "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/kubernetes-csi/csi-lib-utils/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Capabilities are the optional volume group operations a driver supports.
+type Capabilities struct {
+	ModifyVolumeGroupMembership bool
+	ControllerGetVolumeGroup    bool
+	ListVolumeGroups            bool
+	VolumeGroupSnapshot         bool
+}
+
+// DriverStatus is the result of the last discovery of a driver.
+type DriverStatus struct {
+	Discovered        bool
+	Capabilities      Capabilities
+	LastDiscoveryTime time.Time
+}
+
+// ErrUnexpectedDriver is returned by the discovery of a driver whose endpoint serves another driver.
+var ErrUnexpectedDriver = errors.New("unexpected driver")
+
+// optionalOperations are the operations of the capabilities that can be configured as unsupported.
+var optionalOperations = []string{
+	modifyVolumeGroupMembershipOperation, controllerGetVolumeGroupOperation, listVolumeGroupsOperation,
+}
+
+var allCapabilities = Capabilities{
+	ModifyVolumeGroupMembership: true,
+	ControllerGetVolumeGroup:    true,
+	ListVolumeGroups:            true,
+	VolumeGroupSnapshot:         true,
+}
+
+// Status returns the result of the last discovery of the driver.
+func (d *Driver) Status() DriverStatus {
+	d.statusLock.RLock()
+	defer d.statusLock.RUnlock()
+	return d.status
+}
+
+// Capabilities returns the discovered capabilities of the driver. All the capabilities are assumed until
+// the driver is discovered, so no feature is disabled before the discovery.
+func (d *Driver) Capabilities() Capabilities {
+	driverStatus := d.Status()
+	if !driverStatus.Discovered {
+		return allCapabilities
+	}
+	return driverStatus.Capabilities
+}
+
+// Discover confirms that the endpoint serves the driver, discovers its capabilities and returns whether they changed.
+// The volume group API has no capabilities RPC, so the read-only operations are supported unless the driver answers
+// a call without the required volume group id with Unimplemented. ModifyVolumeGroupMembership changes the storage,
+// so it is never probed and is supported unless it is one of the unsupported operations of the driver.
+func (d *Driver) Discover(ctx context.Context) (bool, error) {
+	var name string
+	err := d.probe(ctx, getPluginInfoOperation, func(probeCtx context.Context) error {
+		var err error
+		name, err = d.Client.GetDriverName(probeCtx)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	if name != d.Name {
+		return false, fmt.Errorf("%w: the endpoint of %s driver serves %s driver", ErrUnexpectedDriver, d.Name, name)
+	}
+	capabilities, err := d.discoverCapabilities(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	d.statusLock.Lock()
+	defer d.statusLock.Unlock()
+	isChanged := !d.status.Discovered || d.status.Capabilities != capabilities
+	d.status = DriverStatus{Discovered: true, Capabilities: capabilities, LastDiscoveryTime: time.Now()}
+	return isChanged, nil
+}
+
+func (d *Driver) discoverCapabilities(ctx context.Context) (Capabilities, error) {
+	var err error
+	capabilities := Capabilities{ModifyVolumeGroupMembership: !d.isUnsupported(modifyVolumeGroupMembershipOperation)}
+	controllerClient := volumegroup.NewControllerClient(d.Client.Client)
+
+	if capabilities.ControllerGetVolumeGroup, err = d.probeOperation(ctx, controllerGetVolumeGroupOperation, func(probeCtx context.Context) error {
+		_, err := controllerClient.ControllerGetVolumeGroup(probeCtx, &volumegroup.ControllerGetVolumeGroupRequest{})
+		return err
+	}); err != nil {
+		return capabilities, err
+	}
+	if capabilities.ListVolumeGroups, err = d.probeOperation(ctx, listVolumeGroupsOperation, func(probeCtx context.Context) error {
+		_, err := controllerClient.ListVolumeGroups(probeCtx, &volumegroup.ListVolumeGroupsRequest{MaxEntries: 1})
+		return err
+	}); err != nil {
+		return capabilities, err
+	}
+
+	var groupControllerCapabilities rpc.GroupControllerCapabilitySet
+	err = d.probe(ctx, groupControllerGetCapabilitiesOperation, func(probeCtx context.Context) error {
+		var err error
+		groupControllerCapabilities, err = rpc.GetGroupControllerCapabilities(probeCtx, d.Client.Client)
+		return err
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return capabilities, nil
+	}
+	if err != nil {
+		return capabilities, err
+	}
+	capabilities.VolumeGroupSnapshot = groupControllerCapabilities[csi.GroupControllerServiceCapability_RPC_CREATE_DELETE_GET_VOLUME_GROUP_SNAPSHOT]
+	return capabilities, nil
+}
+
+// probeOperation returns whether the read-only operation is supported, an unsupported operation of the driver is not probed.
+func (d *Driver) probeOperation(ctx context.Context, operation string, call func(context.Context) error) (bool, error) {
+	if d.isUnsupported(operation) {
+		return false, nil
+	}
+	return isImplemented(d.probe(ctx, operation, call))
+}
+
+// probe makes a discovery call through the limiter of the driver, the RPC timeout starts once the limiter lets it run.
+func (d *Driver) probe(ctx context.Context, operation string, call func(context.Context) error) error {
+	release, err := d.limiter.Acquire(ctx, operation)
+	if err != nil {
+		return err
+	}
+	defer release()
+	probeCtx, cancel := context.WithTimeout(ctx, d.Client.Timeout)
+	defer cancel()
+	return call(probeCtx)
+}
+
+func (d *Driver) isUnsupported(operation string) bool {
+	for _, unsupportedOperation := range d.UnsupportedOperations {
+		if operation == unsupportedOperation {
+			return true
+		}
+	}
+	return false
+}
+
+// isImplemented returns whether the RPC that returned the error is implemented. An implemented RPC succeeds or rejects
+// the call without a volume group id, any other failure, for example a denied permission or an unreachable driver,
+// is returned since it does not tell.
+func isImplemented(err error) (bool, error) {
+	switch status.Code(err) {
+	case codes.OK, codes.InvalidArgument, codes.NotFound, codes.OutOfRange:
+		return true, nil
+	case codes.Unimplemented:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// ParseUnsupportedOperations parses the optional operations that the drivers do not support given as a comma
+// separated list.
+func ParseUnsupportedOperations(value string) ([]string, error) {
+	var unsupportedOperations []string
+	if value == "" {
+		return unsupportedOperations, nil
+	}
+	for _, operation := range strings.Split(value, ",") {
+		operation = strings.TrimSpace(operation)
+		if !isOptionalOperation(operation) {
+			return nil, fmt.Errorf("invalid unsupported operation %q, expected one of %s", operation,
+				strings.Join(optionalOperations, ", "))
+		}
+		unsupportedOperations = append(unsupportedOperations, operation)
+	}
+	return unsupportedOperations, nil
+}
+
+func isOptionalOperation(operation string) bool {
+	for _, optionalOperation := range optionalOperations {
+		if operation == optionalOperation {
+			return true
+		}
+	}
+	return false
+}
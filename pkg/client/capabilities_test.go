@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsImplemented(t *testing.T) {
+	tests := []struct {
+		code          codes.Code
+		isImplemented bool
+		wantErr       bool
+	}{
+		{code: codes.OK, isImplemented: true},
+		{code: codes.InvalidArgument, isImplemented: true},
+		{code: codes.NotFound, isImplemented: true},
+		{code: codes.OutOfRange, isImplemented: true},
+		{code: codes.Unimplemented, isImplemented: false},
+		{code: codes.PermissionDenied, wantErr: true},
+		{code: codes.Unauthenticated, wantErr: true},
+		{code: codes.Internal, wantErr: true},
+		{code: codes.Unavailable, wantErr: true},
+		{code: codes.DeadlineExceeded, wantErr: true},
+		{code: codes.Canceled, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			isImplemented, err := isImplemented(status.Error(tt.code, "fake error"))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected an error %v, got %v", tt.wantErr, err)
+			}
+			if isImplemented != tt.isImplemented {
+				t.Fatalf("expected implemented %v, got %v", tt.isImplemented, isImplemented)
+			}
+		})
+	}
+}
+
+func TestParseUnsupportedOperations(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		operations []string
+		wantErr    bool
+	}{
+		{
+			name:  "empty",
+			value: "",
+		},
+		{
+			name:       "single operation",
+			value:      "ModifyVolumeGroupMembership",
+			operations: []string{modifyVolumeGroupMembershipOperation},
+		},
+		{
+			name:       "several operations with spaces",
+			value:      "ControllerGetVolumeGroup, ListVolumeGroups",
+			operations: []string{controllerGetVolumeGroupOperation, listVolumeGroupsOperation},
+		},
+		{
+			name:    "required operation",
+			value:   "CreateVolumeGroup",
+			wantErr: true,
+		},
+		{
+			name:    "unknown operation",
+			value:   "ListVolumes",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operations, err := ParseUnsupportedOperations(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", operations)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(operations, tt.operations) {
+				t.Fatalf("expected %v, got %v", tt.operations, operations)
+			}
+		})
+	}
+}
@@ -21,9 +21,9 @@ import (
 	"time"
 
 	"github.com/kubernetes-csi/csi-lib-utils/connection"
-	"github.com/kubernetes-csi/csi-lib-utils/metrics"
 	"github.com/kubernetes-csi/csi-lib-utils/rpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 )
 
 type Client struct {
@@ -31,16 +31,22 @@ type Client struct {
 	Timeout time.Duration
 }
 
-func connect(address string, timeout time.Duration) (*grpc.ClientConn, error) {
+// connect dials the driver, a lost connection is reestablished in the background so the operator
+// keeps running while the driver restarts. Remote endpoints are dialed over TLS.
+func connect(address, driver string, timeout time.Duration, connectionConfig ConnectionConfig) (*grpc.ClientConn, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	return connection.Connect(ctx, address, metrics.NewCSIMetricsManager(""), connection.OnConnectionLoss(connection.ExitOnConnectionLoss()))
+	metricsManager := NewMetricsManager(driver)
+	if IsRemoteEndpoint(address) {
+		return connectRemote(ctx, address, metricsManager, connectionConfig)
+	}
+	return connection.Connect(ctx, address, metricsManager)
 }
 
-func New(address string, timeout time.Duration) (*Client, error) {
+func New(address, driver string, timeout time.Duration, connectionConfig ConnectionConfig) (*Client, error) {
 	c := &Client{}
-	cc, err := connect(address, timeout)
+	cc, err := connect(address, driver, timeout, connectionConfig)
 	if err != nil {
 		return c, err
 	}
@@ -57,9 +63,43 @@ func (c *Client) Probe() error {
 	return rpc.ProbeForever(ctx, c.Client, c.Timeout)
 }
 
-func (c *Client) GetDriverName() (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+func (c *Client) GetDriverName(ctx context.Context) (string, error) {
+	return rpc.GetDriverName(ctx, c.Client)
+}
+
+// IsReachable returns whether the connection to the driver is up. A connection that was ready turns idle when the
+// driver goes down, so an idle connection is connected first and the driver is reachable once the connection is ready.
+func (c *Client) IsReachable(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
 	defer cancel()
+	return c.waitForConnection(ctx) == connectivity.Ready
+}
 
-	return rpc.GetDriverName(ctx, c.Client)
+// WatchConnection calls onChange every time the driver becomes reachable or unreachable, until the context is done.
+func (c *Client) WatchConnection(ctx context.Context, onChange func(reachable bool)) {
+	state := c.waitForConnection(ctx)
+	reachable := state == connectivity.Ready
+	for c.Client.WaitForStateChange(ctx, state) {
+		state = c.waitForConnection(ctx)
+		if (state == connectivity.Ready) != reachable {
+			reachable = !reachable
+			onChange(reachable)
+		}
+	}
+}
+
+// waitForConnection connects an idle connection and waits until the connection is ready or failing, and returns its
+// state. It returns an idle or connecting state when the context is done first.
+func (c *Client) waitForConnection(ctx context.Context) connectivity.State {
+	state := c.Client.GetState()
+	for state == connectivity.Idle || state == connectivity.Connecting {
+		if state == connectivity.Idle {
+			c.Client.Connect()
+		}
+		if !c.Client.WaitForStateChange(ctx, state) {
+			return state
+		}
+		state = c.Client.GetState()
+	}
+	return state
 }
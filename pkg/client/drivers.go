@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+)
+
+// Driver is a CSI driver served by the operator with the clients of its connection.
+type Driver struct {
+	Name                string
+	Client              *Client
+	VolumeGroup         VolumeGroup
+	VolumeGroupSnapshot VolumeGroupSnapshot
+	// UnsupportedOperations are the optional operations the driver does not support, they are never probed.
+	UnsupportedOperations []string
+
+	limiter    *RPCLimiter
+	statusLock sync.RWMutex
+	status     DriverStatus
+}
+
+// Drivers maps the names of the CSI drivers served by the operator to their clients.
+type Drivers map[string]*Driver
+
+func NewDriver(name string, client *Client, limiter *RPCLimiter) *Driver {
+	return &Driver{
+		Name:                name,
+		Client:              client,
+		VolumeGroup:         NewVolumeGroupClient(name, client.Client, client.Timeout, limiter),
+		VolumeGroupSnapshot: NewVolumeGroupSnapshotClient(name, client.Client, client.Timeout, limiter),
+		limiter:             limiter,
+	}
+}
+
+// Get returns the driver of the given name, and false when the operator does not serve it.
+func (d Drivers) Get(name string) (*Driver, bool) {
+	driver, ok := d[name]
+	return driver, ok
+}
+
+// Names returns the sorted names of the drivers.
+func (d Drivers) Names() []string {
+	names := make([]string, 0, len(d))
+	for name := range d {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check is a readiness check that fails only while the connections to all the drivers are down, a single driver that is
+// down does not stop the operator from serving the others. The state of every driver is reported in the driver reachable gauge.
+func (d Drivers) Check(req *http.Request) error {
+	var unreachable []string
+	for _, name := range d.Names() {
+		reachable := d[name].Client.IsReachable(req.Context())
+		value := 0.0
+		if reachable {
+			value = 1
+		}
+		metrics.DriverReachable.WithLabelValues(name).Set(value)
+		if !reachable {
+			unreachable = append(unreachable, name)
+		}
+	}
+	if len(d) > 0 && len(unreachable) == len(d) {
+		return fmt.Errorf("the connection to all the drivers is down: %s", strings.Join(unreachable, ", "))
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package fake
 
 import (
+	"context"
 	"time"
 
 	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
@@ -25,19 +26,29 @@ import (
 
 type VolumeGroup struct {
 	NewVolumeGroupClient            func(cc *grpc.ClientConn, timeout time.Duration) VolumeGroup
-	CreateVolumeGroupMock           func(name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error)
-	DeleteVolumeGroupMock           func(volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error)
-	ModifyVolumeGroupMembershipMock func(volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error)
+	CreateVolumeGroupMock           func(ctx context.Context, name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error)
+	DeleteVolumeGroupMock           func(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error)
+	ModifyVolumeGroupMembershipMock func(ctx context.Context, volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error)
+	ControllerGetVolumeGroupMock    func(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.ControllerGetVolumeGroupResponse, error)
+	ListVolumeGroupsMock            func(ctx context.Context, maxEntries int32, startingToken string, secrets map[string]string) (*csi.ListVolumeGroupsResponse, error)
 }
 
-func (v VolumeGroup) CreateVolumeGroup(name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error) {
-	return v.CreateVolumeGroupMock(name, secrets, parameters)
+func (v VolumeGroup) CreateVolumeGroup(ctx context.Context, name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error) {
+	return v.CreateVolumeGroupMock(ctx, name, secrets, parameters)
 }
 
-func (v VolumeGroup) DeleteVolumeGroup(volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error) {
-	return v.DeleteVolumeGroupMock(volumeGroupId, secrets)
+func (v VolumeGroup) DeleteVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error) {
+	return v.DeleteVolumeGroupMock(ctx, volumeGroupId, secrets)
 }
 
-func (v VolumeGroup) ModifyVolumeGroupMembership(volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error) {
-	return v.ModifyVolumeGroupMembershipMock(volumeGroupId, volumeIds, secrets)
+func (v VolumeGroup) ModifyVolumeGroupMembership(ctx context.Context, volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error) {
+	return v.ModifyVolumeGroupMembershipMock(ctx, volumeGroupId, volumeIds, secrets)
+}
+
+func (v VolumeGroup) ControllerGetVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.ControllerGetVolumeGroupResponse, error) {
+	return v.ControllerGetVolumeGroupMock(ctx, volumeGroupId, secrets)
+}
+
+func (v VolumeGroup) ListVolumeGroups(ctx context.Context, maxEntries int32, startingToken string, secrets map[string]string) (*csi.ListVolumeGroupsResponse, error) {
+	return v.ListVolumeGroupsMock(ctx, maxEntries, startingToken, secrets)
 }
@@ -0,0 +1,41 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+)
+
+type VolumeGroupSnapshot struct {
+	CreateVolumeGroupSnapshotMock func(ctx context.Context, name string, sourceVolumeIds []string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupSnapshotResponse, error)
+	DeleteVolumeGroupSnapshotMock func(ctx context.Context, groupSnapshotId string, snapshotIds []string, secrets map[string]string) (*csi.DeleteVolumeGroupSnapshotResponse, error)
+	GetVolumeGroupSnapshotMock    func(ctx context.Context, groupSnapshotId string, snapshotIds []string, secrets map[string]string) (*csi.GetVolumeGroupSnapshotResponse, error)
+}
+
+func (v VolumeGroupSnapshot) CreateVolumeGroupSnapshot(ctx context.Context, name string, sourceVolumeIds []string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupSnapshotResponse, error) {
+	return v.CreateVolumeGroupSnapshotMock(ctx, name, sourceVolumeIds, secrets, parameters)
+}
+
+func (v VolumeGroupSnapshot) DeleteVolumeGroupSnapshot(ctx context.Context, groupSnapshotId string, snapshotIds []string, secrets map[string]string) (*csi.DeleteVolumeGroupSnapshotResponse, error) {
+	return v.DeleteVolumeGroupSnapshotMock(ctx, groupSnapshotId, snapshotIds, secrets)
+}
+
+func (v VolumeGroupSnapshot) GetVolumeGroupSnapshot(ctx context.Context, groupSnapshotId string, snapshotIds []string, secrets map[string]string) (*csi.GetVolumeGroupSnapshotResponse, error) {
+	return v.GetVolumeGroupSnapshotMock(ctx, groupSnapshotId, snapshotIds, secrets)
+}
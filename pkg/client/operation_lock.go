@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	"golang.org/x/sync/semaphore"
+	"google.golang.org/grpc/status"
+)
+
+// operationLocks serializes the driver operations on the same volume group across all the controllers.
+// A lock is kept only while an operation holds or waits for it.
+type operationLocks struct {
+	mutex sync.Mutex
+	locks map[string]*operationLock
+}
+
+// operationLock is held while its channel is full, so waiting for it can be abandoned when the context is done.
+type operationLock struct {
+	held chan struct{}
+	refs int
+}
+
+var volumeGroupLocks = &operationLocks{locks: map[string]*operationLock{}}
+
+// lock waits until the lock of the key is held or the context is done, and returns the function that releases it.
+func (l *operationLocks) lock(ctx context.Context, key string) (func(), error) {
+	l.mutex.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &operationLock{held: make(chan struct{}, 1)}
+		l.locks[key] = lock
+	}
+	lock.refs++
+	l.mutex.Unlock()
+
+	select {
+	case lock.held <- struct{}{}:
+		return func() {
+			<-lock.held
+			l.release(key, lock)
+		}, nil
+	case <-ctx.Done():
+		l.release(key, lock)
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (l *operationLocks) release(key string, lock *operationLock) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, key)
+	}
+}
+
+// listLockWeight is the weight of the list lock of a driver that a listing acquires, the creations and the deletions
+// of the driver acquire a weight of one each so they run side by side but never while a listing runs.
+const listLockWeight = math.MaxInt32
+
+// listLocks keeps a listing of the volume groups of a driver from observing a volume group while it is created or deleted.
+var listLocks = struct {
+	sync.Mutex
+	locks map[string]*semaphore.Weighted
+}{locks: map[string]*semaphore.Weighted{}}
+
+func getListLock(driver string) *semaphore.Weighted {
+	listLocks.Lock()
+	defer listLocks.Unlock()
+	lock, ok := listLocks.locks[driver]
+	if !ok {
+		lock = semaphore.NewWeighted(listLockWeight)
+		listLocks.locks[driver] = lock
+	}
+	return lock
+}
+
+func acquireListLock(ctx context.Context, driver string, weight int64) (func(), error) {
+	lock := getListLock(driver)
+	if err := lock.Acquire(ctx, weight); err != nil {
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	return func() { lock.Release(weight) }, nil
+}
+
+// observeLockWait exports an operation as waiting for its lock until the returned function is called.
+func observeLockWait(driver, operation string) func() {
+	start := time.Now()
+	metrics.OperationLockWaiting.WithLabelValues(driver, operation).Inc()
+	return func() {
+		metrics.OperationLockWaiting.WithLabelValues(driver, operation).Dec()
+		metrics.OperationLockWaitSeconds.WithLabelValues(driver, operation).Observe(time.Since(start).Seconds())
+	}
+}
+
+// lockVolumeGroup waits until no other operation runs on the volume group of the driver and returns the function
+// that releases it. The volume group is identified by its id.
+func lockVolumeGroup(ctx context.Context, driver, operation, key string) (func(), error) {
+	defer observeLockWait(driver, operation)()
+	return volumeGroupLocks.lock(ctx, driver+"/"+key)
+}
+
+// lockVolumeGroupExistence locks the volume group like lockVolumeGroup and also keeps the volume groups of the driver
+// from being listed, for the operations that create or delete a volume group. A volume group that is created is
+// identified by the name it is created with, since its id is not known before CreateVolumeGroup returns.
+func lockVolumeGroupExistence(ctx context.Context, driver, operation, key string) (func(), error) {
+	defer observeLockWait(driver, operation)()
+	unlock, err := volumeGroupLocks.lock(ctx, driver+"/"+key)
+	if err != nil {
+		return nil, err
+	}
+	unlockList, err := acquireListLock(ctx, driver, 1)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	return func() {
+		unlockList()
+		unlock()
+	}, nil
+}
+
+// lockVolumeGroupList waits until no volume group of the driver is being created or deleted and returns the function
+// that releases it, the creations and the deletions of the driver wait until it is released.
+func lockVolumeGroupList(ctx context.Context, driver string) (func(), error) {
+	defer observeLockWait(driver, listVolumeGroupsOperation)()
+	return acquireListLock(ctx, driver, listLockWeight)
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const lockWaitTimeout = 100 * time.Millisecond
+
+func newOperationLocks() *operationLocks {
+	return &operationLocks{locks: map[string]*operationLock{}}
+}
+
+func lockInBackground(ctx context.Context, locks *operationLocks, key string) chan error {
+	locked := make(chan error, 1)
+	go func() {
+		unlock, err := locks.lock(ctx, key)
+		if err == nil {
+			defer unlock()
+		}
+		locked <- err
+	}()
+	return locked
+}
+
+func TestOperationLocksSerializeTheSameKey(t *testing.T) {
+	locks := newOperationLocks()
+	unlock, err := locks.lock(context.Background(), "driver/vg")
+	if err != nil {
+		t.Fatalf("lock failed: %v", err)
+	}
+
+	locked := lockInBackground(context.Background(), locks, "driver/vg")
+	select {
+	case <-locked:
+		t.Fatal("the lock of the same key was held twice")
+	case <-time.After(lockWaitTimeout):
+	}
+
+	unlock()
+	select {
+	case err := <-locked:
+		if err != nil {
+			t.Fatalf("lock failed after the release: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the lock was not held after the release")
+	}
+}
+
+func TestOperationLocksDoNotSerializeDifferentKeys(t *testing.T) {
+	locks := newOperationLocks()
+	unlock, err := locks.lock(context.Background(), "driver/vg")
+	if err != nil {
+		t.Fatalf("lock failed: %v", err)
+	}
+	defer unlock()
+
+	select {
+	case err := <-lockInBackground(context.Background(), locks, "driver/other-vg"):
+		if err != nil {
+			t.Fatalf("lock failed: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the lock of a different key was blocked")
+	}
+}
+
+func TestOperationLocksStopWaitingWhenTheContextIsDone(t *testing.T) {
+	tests := []struct {
+		name       string
+		newContext func() (context.Context, context.CancelFunc)
+		code       codes.Code
+	}{
+		{
+			name: "canceled",
+			newContext: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				go func() {
+					time.Sleep(lockWaitTimeout)
+					cancel()
+				}()
+				return ctx, cancel
+			},
+			code: codes.Canceled,
+		},
+		{
+			name: "deadline exceeded",
+			newContext: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), lockWaitTimeout)
+			},
+			code: codes.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locks := newOperationLocks()
+			unlock, err := locks.lock(context.Background(), "driver/vg")
+			if err != nil {
+				t.Fatalf("lock failed: %v", err)
+			}
+
+			ctx, cancel := tt.newContext()
+			defer cancel()
+			select {
+			case err := <-lockInBackground(ctx, locks, "driver/vg"):
+				if status.Code(err) != tt.code {
+					t.Fatalf("expected %v, got %v", tt.code, err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("the lock was still awaited after the context was done")
+			}
+
+			unlock()
+			if len(locks.locks) != 0 {
+				t.Fatalf("expected no locks to be kept, got %d", len(locks.locks))
+			}
+		})
+	}
+}
+
+func TestListLockExcludesCreationsAndDeletions(t *testing.T) {
+	tests := []struct {
+		name      string
+		lockFirst func(ctx context.Context, driver string) (func(), error)
+		lockNext  func(ctx context.Context, driver string) (func(), error)
+	}{
+		{
+			name: "listing waits for a creation",
+			lockFirst: func(ctx context.Context, driver string) (func(), error) {
+				return lockVolumeGroupExistence(ctx, driver, createVolumeGroupOperation, "vg")
+			},
+			lockNext: lockVolumeGroupList,
+		},
+		{
+			name:      "deletion waits for a listing",
+			lockFirst: lockVolumeGroupList,
+			lockNext: func(ctx context.Context, driver string) (func(), error) {
+				return lockVolumeGroupExistence(ctx, driver, deleteVolumeGroupOperation, "vg-id")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver := t.Name()
+			unlock, err := tt.lockFirst(context.Background(), driver)
+			if err != nil {
+				t.Fatalf("lock failed: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), lockWaitTimeout)
+			defer cancel()
+			if _, err = tt.lockNext(ctx, driver); status.Code(err) != codes.DeadlineExceeded {
+				t.Fatalf("expected the lock to be awaited until the deadline, got %v", err)
+			}
+
+			unlock()
+			ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			unlock, err = tt.lockNext(ctx, driver)
+			if err != nil {
+				t.Fatalf("lock failed after the release: %v", err)
+			}
+			unlock()
+		})
+	}
+}
+
+func TestListLockDoesNotSerializeOtherOperations(t *testing.T) {
+	driver := t.Name()
+	unlockList, err := lockVolumeGroupList(context.Background(), driver)
+	if err != nil {
+		t.Fatalf("lock failed: %v", err)
+	}
+	defer unlockList()
+	unlockCreation, err := lockVolumeGroupExistence(context.Background(), "other-"+driver, createVolumeGroupOperation, "vg")
+	if err != nil {
+		t.Fatalf("expected the creations of another driver to run during a listing, got %v", err)
+	}
+	defer unlockCreation()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	unlock, err := lockVolumeGroup(ctx, driver, modifyVolumeGroupMembershipOperation, "vg-id")
+	if err != nil {
+		t.Fatalf("expected a membership modification to run during a listing, got %v", err)
+	}
+	unlock()
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	createVolumeGroupOperation           = "CreateVolumeGroup"
+	deleteVolumeGroupOperation           = "DeleteVolumeGroup"
+	modifyVolumeGroupMembershipOperation = "ModifyVolumeGroupMembership"
+	controllerGetVolumeGroupOperation    = "ControllerGetVolumeGroup"
+	listVolumeGroupsOperation            = "ListVolumeGroups"
+	createVolumeGroupSnapshotOperation   = "CreateVolumeGroupSnapshot"
+	deleteVolumeGroupSnapshotOperation   = "DeleteVolumeGroupSnapshot"
+	getVolumeGroupSnapshotOperation      = "GetVolumeGroupSnapshot"
+	// The discovery operations are limited by the global limit only.
+	getPluginInfoOperation                  = "GetPluginInfo"
+	groupControllerGetCapabilitiesOperation = "GroupControllerGetCapabilities"
+)
+
+var operations = []string{
+	createVolumeGroupOperation, deleteVolumeGroupOperation, modifyVolumeGroupMembershipOperation,
+	controllerGetVolumeGroupOperation, listVolumeGroupsOperation, createVolumeGroupSnapshotOperation,
+	deleteVolumeGroupSnapshotOperation, getVolumeGroupSnapshotOperation,
+}
+
+// RateLimit limits the driver RPCs, a zero QPS or MaxInFlight means no limit.
+type RateLimit struct {
+	QPS         float64
+	Burst       int
+	MaxInFlight int
+}
+
+// RPCLimiter limits the rate and the number of in-flight RPCs of a driver. The global limit is shared by the RPCs of all
+// the drivers, the limit of an operation applies to the RPCs of the driver only.
+type RPCLimiter struct {
+	driver     string
+	global     *operationLimiter
+	operations map[string]*operationLimiter
+}
+
+type operationLimiter struct {
+	limiter  *rate.Limiter
+	inFlight chan struct{}
+}
+
+func NewRPCLimiter(driver string, global RateLimit, operationLimits map[string]RateLimit) *RPCLimiter {
+	return NewRPCLimiters([]string{driver}, global, operationLimits)[driver]
+}
+
+// NewRPCLimiters returns the limiters of the drivers, which share one global limiter.
+func NewRPCLimiters(drivers []string, global RateLimit, operationLimits map[string]RateLimit) map[string]*RPCLimiter {
+	globalLimiter := newOperationLimiter(global)
+	limiters := map[string]*RPCLimiter{}
+	for _, driver := range drivers {
+		limiter := &RPCLimiter{
+			driver:     driver,
+			global:     globalLimiter,
+			operations: map[string]*operationLimiter{},
+		}
+		for operation, limit := range operationLimits {
+			limiter.operations[operation] = newOperationLimiter(limit)
+		}
+		limiters[driver] = limiter
+	}
+	return limiters
+}
+
+func newOperationLimiter(limit RateLimit) *operationLimiter {
+	limiter := &operationLimiter{}
+	if limit.QPS > 0 {
+		burst := limit.Burst
+		if burst < 1 {
+			burst = int(math.Ceil(limit.QPS))
+		}
+		limiter.limiter = rate.NewLimiter(rate.Limit(limit.QPS), burst)
+	}
+	if limit.MaxInFlight > 0 {
+		limiter.inFlight = make(chan struct{}, limit.MaxInFlight)
+	}
+	return limiter
+}
+
+// Acquire waits until the global and the operation limits allow the operation to run and returns the function
+// that ends it. The wait is canceled with the context and returns its error as a gRPC status, so the context
+// should not carry the RPC timeout, which starts once the operation is allowed to run.
+func (l *RPCLimiter) Acquire(ctx context.Context, operation string) (func(), error) {
+	start := time.Now()
+	metrics.RPCLimiterWaiting.WithLabelValues(l.driver, operation).Inc()
+	defer metrics.RPCLimiterWaiting.WithLabelValues(l.driver, operation).Dec()
+
+	// The operation limit is acquired first, so an operation throttled by its own limit does not hold the global
+	// limit shared by all the operations of all the drivers.
+	var limiters []*operationLimiter
+	if limiter, ok := l.operations[operation]; ok {
+		limiters = append(limiters, limiter)
+	}
+	limiters = append(limiters, l.global)
+	var releases []func()
+	release := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+	for _, limiter := range limiters {
+		limiterRelease, err := limiter.acquire(ctx)
+		if err != nil {
+			release()
+			return nil, status.FromContextError(err).Err()
+		}
+		releases = append(releases, limiterRelease)
+	}
+	metrics.RPCLimiterWaitSeconds.WithLabelValues(l.driver, operation).Observe(time.Since(start).Seconds())
+	metrics.RPCInFlight.WithLabelValues(l.driver, operation).Inc()
+	return func() {
+		metrics.RPCInFlight.WithLabelValues(l.driver, operation).Dec()
+		release()
+	}, nil
+}
+
+// acquire waits for the rate first and for an in-flight slot after it, so no slot is held while the rate is awaited.
+func (l *operationLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.limiter != nil {
+		if err := l.limiter.Wait(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// The wait would exceed the deadline of the context.
+			return nil, context.DeadlineExceeded
+		}
+	}
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return func() {
+		<-l.inFlight
+	}, nil
+}
+
+// ParseRateLimits parses the limits of operations given as a comma separated list of
+// <operation>=<qps>:<burst>:<maxInFlight> entries, an empty value is no limit.
+func ParseRateLimits(value string) (map[string]RateLimit, error) {
+	limits := map[string]RateLimit{}
+	if value == "" {
+		return limits, nil
+	}
+	for _, entry := range strings.Split(value, ",") {
+		operation, limitValue, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || !isOperation(operation) {
+			return nil, fmt.Errorf("invalid operation rate limit %q, expected <operation>=<qps>:<burst>:<maxInFlight>", entry)
+		}
+		limit, err := parseRateLimit(limitValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operation rate limit %q: %w", entry, err)
+		}
+		limits[operation] = limit
+	}
+	return limits, nil
+}
+
+func parseRateLimit(value string) (RateLimit, error) {
+	fields := strings.Split(value, ":")
+	if len(fields) != 3 {
+		return RateLimit{}, fmt.Errorf("expected <qps>:<burst>:<maxInFlight>")
+	}
+	qps, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return RateLimit{}, err
+	}
+	burst, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return RateLimit{}, err
+	}
+	maxInFlight, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return RateLimit{}, err
+	}
+	return RateLimit{QPS: qps, Burst: burst, MaxInFlight: maxInFlight}, nil
+}
+
+func isOperation(operation string) bool {
+	for _, knownOperation := range operations {
+		if operation == knownOperation {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		limits  map[string]RateLimit
+		wantErr bool
+	}{
+		{
+			name:   "empty",
+			value:  "",
+			limits: map[string]RateLimit{},
+		},
+		{
+			name:  "single operation",
+			value: "DeleteVolumeGroup=1:1:1",
+			limits: map[string]RateLimit{
+				deleteVolumeGroupOperation: {QPS: 1, Burst: 1, MaxInFlight: 1},
+			},
+		},
+		{
+			name:  "several operations with spaces and fractional qps",
+			value: "ModifyVolumeGroupMembership=5:10:2, ControllerGetVolumeGroup=0.5:0:0",
+			limits: map[string]RateLimit{
+				modifyVolumeGroupMembershipOperation: {QPS: 5, Burst: 10, MaxInFlight: 2},
+				controllerGetVolumeGroupOperation:    {QPS: 0.5},
+			},
+		},
+		{
+			name:    "unknown operation",
+			value:   "CreateVolume=1:1:1",
+			wantErr: true,
+		},
+		{
+			name:    "missing limit",
+			value:   "DeleteVolumeGroup",
+			wantErr: true,
+		},
+		{
+			name:    "missing field",
+			value:   "DeleteVolumeGroup=1:1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid qps",
+			value:   "DeleteVolumeGroup=fast:1:1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid burst",
+			value:   "DeleteVolumeGroup=1:1.5:1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid max in flight",
+			value:   "DeleteVolumeGroup=1:1:many",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := ParseRateLimits(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", limits)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(limits, tt.limits) {
+				t.Fatalf("expected %+v, got %+v", tt.limits, limits)
+			}
+		})
+	}
+}
+
+func TestRPCLimiterStopsWaitingWhenTheContextIsDone(t *testing.T) {
+	tests := []struct {
+		name            string
+		global          RateLimit
+		operationLimits map[string]RateLimit
+		cancel          bool
+		code            codes.Code
+	}{
+		{
+			name:   "canceled waiting for the global in-flight limit",
+			global: RateLimit{MaxInFlight: 1},
+			cancel: true,
+			code:   codes.Canceled,
+		},
+		{
+			name:   "deadline exceeded waiting for the global rate",
+			global: RateLimit{QPS: 0.1, Burst: 1},
+			code:   codes.DeadlineExceeded,
+		},
+		{
+			name:            "canceled waiting for the operation rate",
+			operationLimits: map[string]RateLimit{deleteVolumeGroupOperation: {QPS: 0.1, Burst: 1}},
+			cancel:          true,
+			code:            codes.Canceled,
+		},
+		{
+			name:            "deadline exceeded waiting for the operation in-flight limit",
+			operationLimits: map[string]RateLimit{deleteVolumeGroupOperation: {MaxInFlight: 1}},
+			code:            codes.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewRPCLimiter("driver", tt.global, tt.operationLimits)
+			release, err := limiter.Acquire(context.Background(), deleteVolumeGroupOperation)
+			if err != nil {
+				t.Fatalf("acquire failed: %v", err)
+			}
+			defer release()
+
+			ctx, cancel := context.WithTimeout(context.Background(), lockWaitTimeout)
+			defer cancel()
+			if tt.cancel {
+				ctx, cancel = context.WithCancel(context.Background())
+				go func() {
+					time.Sleep(lockWaitTimeout)
+					cancel()
+				}()
+			}
+			acquired := make(chan error, 1)
+			go func() {
+				release, err := limiter.Acquire(ctx, deleteVolumeGroupOperation)
+				if err == nil {
+					release()
+				}
+				acquired <- err
+			}()
+			select {
+			case err := <-acquired:
+				if status.Code(err) != tt.code {
+					t.Fatalf("expected %v, got %v", tt.code, err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("the limiter was still awaited after the context was done")
+			}
+		})
+	}
+}
+
+func TestRPCLimitersShareTheGlobalLimit(t *testing.T) {
+	limiters := NewRPCLimiters([]string{"driver", "other-driver"}, RateLimit{MaxInFlight: 1},
+		map[string]RateLimit{deleteVolumeGroupOperation: {MaxInFlight: 1}})
+	release, err := limiters["driver"].Acquire(context.Background(), listVolumeGroupsOperation)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lockWaitTimeout)
+	defer cancel()
+	if _, err = limiters["other-driver"].Acquire(ctx, listVolumeGroupsOperation); status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected the global limit to be shared by the drivers, got %v", err)
+	}
+
+	release()
+	if limiters["driver"].operations[deleteVolumeGroupOperation] == limiters["other-driver"].operations[deleteVolumeGroupOperation] {
+		t.Fatal("expected every driver to have its own operation limits")
+	}
+}
+
+func TestRPCLimiterThrottledOperationDoesNotBlockOtherOperations(t *testing.T) {
+	tests := []struct {
+		name            string
+		global          RateLimit
+		operationLimits map[string]RateLimit
+		holdFirst       bool
+	}{
+		{
+			name:            "operation waiting for its rate",
+			global:          RateLimit{MaxInFlight: 1},
+			operationLimits: map[string]RateLimit{modifyVolumeGroupMembershipOperation: {QPS: 0.1, Burst: 1}},
+		},
+		{
+			name:            "operation waiting for its in-flight slot",
+			global:          RateLimit{MaxInFlight: 2},
+			operationLimits: map[string]RateLimit{modifyVolumeGroupMembershipOperation: {MaxInFlight: 1}},
+			holdFirst:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewRPCLimiter("driver", tt.global, tt.operationLimits)
+			release, err := limiter.Acquire(context.Background(), modifyVolumeGroupMembershipOperation)
+			if err != nil {
+				t.Fatalf("acquire failed: %v", err)
+			}
+			if tt.holdFirst {
+				defer release()
+			} else {
+				release()
+			}
+
+			acquireThrottled := func(ctx context.Context) {
+				if release, err := limiter.Acquire(ctx, modifyVolumeGroupMembershipOperation); err == nil {
+					release()
+				}
+			}
+			throttledCtx, cancelThrottled := context.WithCancel(context.Background())
+			defer cancelThrottled()
+			go acquireThrottled(throttledCtx)
+			time.Sleep(lockWaitTimeout)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			otherRelease, err := limiter.Acquire(ctx, controllerGetVolumeGroupOperation)
+			if err != nil {
+				t.Fatalf("expected another operation to run while the throttled operation waits, got %v", err)
+			}
+			otherRelease()
+		})
+	}
+}
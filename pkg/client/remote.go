@@ -0,0 +1,271 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubernetes-csi/csi-lib-utils/connection"
+	"github.com/kubernetes-csi/csi-lib-utils/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	tcpScheme = "tcp://"
+	dnsScheme = "dns:"
+
+	secretCAKey   = "ca.crt"
+	secretCertKey = "tls.crt"
+	secretKeyKey  = "tls.key"
+
+	secretReadTimeout = 10 * time.Second
+)
+
+// ConnectionConfig configures the connections to remote driver endpoints.
+type ConnectionConfig struct {
+	TLS       TLSConfig
+	Keepalive keepalive.ClientParameters
+}
+
+// TLSConfig holds the TLS credentials of remote driver endpoints, from files or from a secret with the ca.crt,
+// tls.crt and tls.key keys. A client certificate enables mutual TLS and the system roots are used without a CA.
+// The credentials are loaded again for every new connection, so rotated credentials are used once the connection
+// is reestablished.
+type TLSConfig struct {
+	CAFile          string
+	CertFile        string
+	KeyFile         string
+	SecretNamespace string
+	SecretName      string
+	ServerName      string
+	// Reader reads the secret of the credentials.
+	Reader runtimeclient.Reader
+}
+
+// IsRemoteEndpoint returns whether the driver endpoint is a tcp:// or a dns: endpoint rather than a unix socket.
+func IsRemoteEndpoint(address string) bool {
+	return strings.HasPrefix(address, tcpScheme) || strings.HasPrefix(address, dnsScheme)
+}
+
+func connectRemote(ctx context.Context, address string, metricsManager metrics.CSIMetricsManager,
+	connectionConfig ConnectionConfig) (*grpc.ClientConn, error) {
+	target, host, err := getRemoteTarget(address)
+	if err != nil {
+		return nil, err
+	}
+	serverName := connectionConfig.TLS.ServerName
+	if serverName == "" {
+		serverName = host
+	}
+	backoffConfig := backoff.DefaultConfig
+	backoffConfig.MaxDelay = time.Second
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(credentials.NewTLS(newTLSClientConfig(connectionConfig.TLS, serverName))),
+		grpc.WithConnectParams(grpc.ConnectParams{Backoff: backoffConfig}),
+		grpc.WithIdleTimeout(0),
+		grpc.WithChainUnaryInterceptor(connection.LogGRPC,
+			connection.ExtendedCSIMetricsManager{CSIMetricsManager: metricsManager}.RecordMetricsClientInterceptor),
+	}
+	if connectionConfig.Keepalive.Time > 0 {
+		dialOptions = append(dialOptions, grpc.WithKeepaliveParams(connectionConfig.Keepalive))
+	}
+
+	cc, err := grpc.NewClient(target, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if err = waitForReady(ctx, cc); err != nil {
+		cc.Close()
+		return nil, err
+	}
+	return cc, nil
+}
+
+// getRemoteTarget returns the gRPC target of a tcp://<host>:<port> or a dns:[//<authority>/]<host>:<port> endpoint and its host.
+func getRemoteTarget(address string) (string, string, error) {
+	target := address
+	hostPort := strings.TrimPrefix(address, tcpScheme)
+	if strings.HasPrefix(address, tcpScheme) {
+		target = "passthrough:///" + hostPort
+	} else {
+		hostPort = strings.TrimPrefix(address, dnsScheme)
+		if strings.HasPrefix(hostPort, "//") {
+			_, hostPort, _ = strings.Cut(strings.TrimPrefix(hostPort, "//"), "/")
+		}
+	}
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid driver endpoint %s: %w", address, err)
+	}
+	return target, host, nil
+}
+
+func waitForReady(ctx context.Context, cc *grpc.ClientConn) error {
+	cc.Connect()
+	for state := cc.GetState(); state != connectivity.Ready; state = cc.GetState() {
+		if !cc.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("failed to connect to %s: %w", cc.Target(), ctx.Err())
+		}
+	}
+	return nil
+}
+
+// newTLSClientConfig verifies the server against the CA that is loaded for the connection, since the
+// roots of a tls.Config cannot change after the connection is created.
+func newTLSClientConfig(tlsConfig TLSConfig, serverName string) *tls.Config {
+	loader := &tlsCredentialsLoader{config: tlsConfig}
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         serverName,
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			return loader.verifyServer(state, serverName)
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return loader.getClientCertificate()
+		},
+	}
+}
+
+// tlsCredentialsLoader loads the TLS credentials and keeps the last ones that loaded, so a failure to read
+// rotated credentials does not break new connections.
+type tlsCredentialsLoader struct {
+	config TLSConfig
+
+	lock        sync.Mutex
+	caPool      *x509.CertPool
+	certificate *tls.Certificate
+}
+
+func (l *tlsCredentialsLoader) verifyServer(state tls.ConnectionState, serverName string) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("the driver did not send a server certificate")
+	}
+	caPool, _, err := l.load()
+	if err != nil {
+		return err
+	}
+	intermediates := x509.NewCertPool()
+	for _, certificate := range state.PeerCertificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	_, err = state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Roots:         caPool,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+func (l *tlsCredentialsLoader) getClientCertificate() (*tls.Certificate, error) {
+	_, certificate, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+	if certificate == nil {
+		// No client certificate is sent without mutual TLS.
+		return &tls.Certificate{}, nil
+	}
+	return certificate, nil
+}
+
+func (l *tlsCredentialsLoader) load() (*x509.CertPool, *tls.Certificate, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	caPool, certificate, err := l.read()
+	if err != nil {
+		if l.caPool == nil {
+			return nil, nil, err
+		}
+		return l.caPool, l.certificate, nil
+	}
+	l.caPool, l.certificate = caPool, certificate
+	return caPool, certificate, nil
+}
+
+func (l *tlsCredentialsLoader) read() (*x509.CertPool, *tls.Certificate, error) {
+	caPEM, certPEM, keyPEM, err := l.readPEM()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var caPool *x509.CertPool
+	if len(caPEM) == 0 {
+		if caPool, err = x509.SystemCertPool(); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		caPool = x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(caPEM) {
+			return nil, nil, errors.New("failed to parse the CA certificates of the driver endpoint")
+		}
+	}
+	if len(certPEM) == 0 && len(keyPEM) == 0 {
+		return caPool, nil, nil
+	}
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	return caPool, &certificate, nil
+}
+
+func (l *tlsCredentialsLoader) readPEM() ([]byte, []byte, []byte, error) {
+	if l.config.SecretName != "" {
+		return l.readSecretPEM()
+	}
+	var pems [3][]byte
+	for i, file := range []string{l.config.CAFile, l.config.CertFile, l.config.KeyFile} {
+		if file == "" {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		pems[i] = data
+	}
+	return pems[0], pems[1], pems[2], nil
+}
+
+func (l *tlsCredentialsLoader) readSecretPEM() ([]byte, []byte, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), secretReadTimeout)
+	defer cancel()
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: l.config.SecretNamespace, Name: l.config.SecretName}
+	if err := l.config.Reader.Get(ctx, key, secret); err != nil {
+		return nil, nil, nil, err
+	}
+	return secret.Data[secretCAKey], secret.Data[secretCertKey], secret.Data[secretKeyKey], nil
+}
@@ -0,0 +1,370 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testServerName = "driver.example.com"
+
+type testCredentials struct {
+	caPEM   []byte
+	certPEM []byte
+	keyPEM  []byte
+	// certificate is signed by the CA for testServerName, and is used as both the server and the client certificate.
+	certificate *x509.Certificate
+}
+
+func newTestCredentials(t *testing.T) testCredentials {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate the CA key: %v", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("failed to create the CA certificate: %v", err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatalf("failed to parse the CA certificate: %v", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate the key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: testServerName},
+		DNSNames:     []string{testServerName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("failed to create the certificate: %v", err)
+	}
+	certificate, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		t.Fatalf("failed to parse the certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal the key: %v", err)
+	}
+	return testCredentials{
+		caPEM:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		certPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		keyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		certificate: certificate,
+	}
+}
+
+func writeTestFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestGetRemoteTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		target  string
+		host    string
+		wantErr bool
+	}{
+		{
+			name:    "tcp",
+			address: "tcp://driver:10000",
+			target:  "passthrough:///driver:10000",
+			host:    "driver",
+		},
+		{
+			name:    "tcp without a port",
+			address: "tcp://driver",
+			wantErr: true,
+		},
+		{
+			name:    "tcp with an IPv6 host",
+			address: "tcp://[::1]:10000",
+			target:  "passthrough:///[::1]:10000",
+			host:    "::1",
+		},
+		{
+			name:    "tcp with an IPv6 host without brackets",
+			address: "tcp://::1:10000",
+			wantErr: true,
+		},
+		{
+			name:    "dns without an authority",
+			address: "dns:driver.example.com:10000",
+			target:  "dns:driver.example.com:10000",
+			host:    "driver.example.com",
+		},
+		{
+			name:    "dns with an empty authority",
+			address: "dns:///driver.example.com:10000",
+			target:  "dns:///driver.example.com:10000",
+			host:    "driver.example.com",
+		},
+		{
+			name:    "dns with an authority",
+			address: "dns://8.8.8.8:53/driver.example.com:10000",
+			target:  "dns://8.8.8.8:53/driver.example.com:10000",
+			host:    "driver.example.com",
+		},
+		{
+			name:    "dns with an authority and no host",
+			address: "dns://8.8.8.8:53",
+			wantErr: true,
+		},
+		{
+			name:    "dns with an IPv6 host",
+			address: "dns:[::1]:10000",
+			target:  "dns:[::1]:10000",
+			host:    "::1",
+		},
+		{
+			name:    "dns without a port",
+			address: "dns:///driver.example.com",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, host, err := getRemoteTarget(tt.address)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got target %s and host %s", target, host)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if target != tt.target || host != tt.host {
+				t.Fatalf("expected target %s and host %s, got target %s and host %s", tt.target, tt.host, target, host)
+			}
+		})
+	}
+}
+
+func TestTLSCredentialsLoaderRotatesAndFallsBackToTheLastGoodCredentials(t *testing.T) {
+	const secretNamespace, secretName = "default", "driver-tls"
+	tests := []struct {
+		name      string
+		newLoader func(t *testing.T) (*tlsCredentialsLoader, func(testCredentials), func())
+	}{
+		{
+			name: "files",
+			newLoader: func(t *testing.T) (*tlsCredentialsLoader, func(testCredentials), func()) {
+				dir := t.TempDir()
+				config := TLSConfig{
+					CAFile:   filepath.Join(dir, secretCAKey),
+					CertFile: filepath.Join(dir, secretCertKey),
+					KeyFile:  filepath.Join(dir, secretKeyKey),
+				}
+				write := func(credentials testCredentials) {
+					writeTestFile(t, config.CAFile, credentials.caPEM)
+					writeTestFile(t, config.CertFile, credentials.certPEM)
+					writeTestFile(t, config.KeyFile, credentials.keyPEM)
+				}
+				corrupt := func() {
+					writeTestFile(t, config.CAFile, []byte("not a certificate"))
+				}
+				return &tlsCredentialsLoader{config: config}, write, corrupt
+			},
+		},
+		{
+			name: "secret",
+			newLoader: func(t *testing.T) (*tlsCredentialsLoader, func(testCredentials), func()) {
+				reader := fake.NewClientBuilder().Build()
+				secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: secretNamespace, Name: secretName}}
+				write := func(credentials testCredentials) {
+					_ = reader.Delete(context.Background(), secret)
+					secret.ResourceVersion = ""
+					secret.Data = map[string][]byte{
+						secretCAKey:   credentials.caPEM,
+						secretCertKey: credentials.certPEM,
+						secretKeyKey:  credentials.keyPEM,
+					}
+					if err := reader.Create(context.Background(), secret); err != nil {
+						t.Fatalf("failed to create the secret: %v", err)
+					}
+				}
+				corrupt := func() {
+					if err := reader.Delete(context.Background(), secret); err != nil {
+						t.Fatalf("failed to delete the secret: %v", err)
+					}
+				}
+				config := TLSConfig{SecretNamespace: secretNamespace, SecretName: secretName, Reader: reader}
+				return &tlsCredentialsLoader{config: config}, write, corrupt
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader, write, corrupt := tt.newLoader(t)
+			original, rotated := newTestCredentials(t), newTestCredentials(t)
+			expectCredentials := func(step string, credentials, other testCredentials) {
+				t.Helper()
+				state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{credentials.certificate}}
+				if err := loader.verifyServer(state, testServerName); err != nil {
+					t.Fatalf("%s: expected the server to be verified, got %v", step, err)
+				}
+				state = tls.ConnectionState{PeerCertificates: []*x509.Certificate{other.certificate}}
+				if err := loader.verifyServer(state, testServerName); err == nil {
+					t.Fatalf("%s: expected the server of other credentials not to be verified", step)
+				}
+				certificate, err := loader.getClientCertificate()
+				if err != nil {
+					t.Fatalf("%s: unexpected error: %v", step, err)
+				}
+				if len(certificate.Certificate) == 0 || !bytes.Equal(certificate.Certificate[0], credentials.certificate.Raw) {
+					t.Fatalf("%s: expected the client certificate of the credentials", step)
+				}
+			}
+
+			write(original)
+			expectCredentials("initial", original, rotated)
+			write(rotated)
+			expectCredentials("rotated", rotated, original)
+			corrupt()
+			expectCredentials("unreadable", rotated, original)
+		})
+	}
+}
+
+func TestTLSCredentialsLoaderLoad(t *testing.T) {
+	credentials := newTestCredentials(t)
+	tests := []struct {
+		name string
+		// files maps the configured files to their content, nil for a file that does not exist.
+		files       map[string][]byte
+		secretName  string
+		certificate bool
+		wantErr     bool
+	}{
+		{
+			name:        "mutual TLS",
+			files:       map[string][]byte{secretCAKey: credentials.caPEM, secretCertKey: credentials.certPEM, secretKeyKey: credentials.keyPEM},
+			certificate: true,
+		},
+		{
+			name:  "without a client certificate",
+			files: map[string][]byte{secretCAKey: credentials.caPEM},
+		},
+		{
+			name:        "without a CA",
+			files:       map[string][]byte{secretCertKey: credentials.certPEM, secretKeyKey: credentials.keyPEM},
+			certificate: true,
+		},
+		{
+			name:    "missing file",
+			files:   map[string][]byte{secretCAKey: nil},
+			wantErr: true,
+		},
+		{
+			name:    "invalid CA",
+			files:   map[string][]byte{secretCAKey: []byte("not a certificate")},
+			wantErr: true,
+		},
+		{
+			name:    "certificate without a key",
+			files:   map[string][]byte{secretCAKey: credentials.caPEM, secretCertKey: credentials.certPEM},
+			wantErr: true,
+		},
+		{
+			name:       "missing secret",
+			secretName: "driver-tls",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			config := TLSConfig{SecretNamespace: "default", SecretName: tt.secretName, Reader: fake.NewClientBuilder().Build()}
+			paths := map[string]*string{secretCAKey: &config.CAFile, secretCertKey: &config.CertFile, secretKeyKey: &config.KeyFile}
+			for name, data := range tt.files {
+				*paths[name] = filepath.Join(dir, name)
+				if data != nil {
+					writeTestFile(t, *paths[name], data)
+				}
+			}
+
+			loader := &tlsCredentialsLoader{config: config}
+			caPool, certificate, err := loader.load()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if _, err = loader.getClientCertificate(); err == nil {
+					t.Fatal("expected an error for the client certificate")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if caPool == nil {
+				t.Fatal("expected a CA pool")
+			}
+			if (certificate != nil) != tt.certificate {
+				t.Fatalf("expected a client certificate %v, got %v", tt.certificate, certificate != nil)
+			}
+			clientCertificate, err := loader.getClientCertificate()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.certificate && len(clientCertificate.Certificate) != 0 {
+				t.Fatal("expected an empty client certificate without mutual TLS")
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"time"
+
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	csimetrics "github.com/kubernetes-csi/csi-lib-utils/metrics"
+	"google.golang.org/grpc/status"
+)
+
+// rpcMetricsManager records the latency of every driver RPC in the operator metrics, besides the CSI metrics.
+type rpcMetricsManager struct {
+	csimetrics.CSIMetricsManager
+	driver string
+}
+
+// NewMetricsManager returns the metrics manager of the connection to the driver.
+func NewMetricsManager(driver string) csimetrics.CSIMetricsManager {
+	return &rpcMetricsManager{CSIMetricsManager: csimetrics.NewCSIMetricsManager(driver), driver: driver}
+}
+
+func (m *rpcMetricsManager) RecordMetrics(operationName string, operationErr error, operationDuration time.Duration) {
+	m.CSIMetricsManager.RecordMetrics(operationName, operationErr, operationDuration)
+	metrics.RPCDurationSeconds.WithLabelValues(m.driver, operationName, status.Code(operationErr).String()).
+		Observe(operationDuration.Seconds())
+}
@@ -25,57 +25,147 @@ import (
 )
 
 type volumeGroupClient struct {
+	driver  string
 	client  csi.ControllerClient
 	timeout time.Duration
+	limiter *RPCLimiter
 }
 
+// VolumeGroup is the client of the volume group operations of the driver. The operations on the same volume group
+// are serialized across all the controllers by its id, and its creation by the name it is created with since its id
+// is not known yet. A listing never overlaps a creation or a deletion of the driver. Every RPC ends when the given
+// context is done or when the RPC timeout expires, whichever is first.
 type VolumeGroup interface {
-	CreateVolumeGroup(name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error)
-	DeleteVolumeGroup(volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error)
-	ModifyVolumeGroupMembership(volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error)
+	CreateVolumeGroup(ctx context.Context, name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error)
+	DeleteVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error)
+	ModifyVolumeGroupMembership(ctx context.Context, volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error)
+	ControllerGetVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.ControllerGetVolumeGroupResponse, error)
+	ListVolumeGroups(ctx context.Context, maxEntries int32, startingToken string, secrets map[string]string) (*csi.ListVolumeGroupsResponse, error)
 }
 
-func NewVolumeGroupClient(cc *grpc.ClientConn, timeout time.Duration) VolumeGroup {
-	return &volumeGroupClient{client: csi.NewControllerClient(cc), timeout: timeout}
+func NewVolumeGroupClient(driver string, cc *grpc.ClientConn, timeout time.Duration, limiter *RPCLimiter) VolumeGroup {
+	return &volumeGroupClient{driver: driver, client: csi.NewControllerClient(cc), timeout: timeout, limiter: limiter}
 }
 
-func (rc *volumeGroupClient) CreateVolumeGroup(name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error) {
+func (rc *volumeGroupClient) CreateVolumeGroup(ctx context.Context, name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error) {
 	req := &csi.CreateVolumeGroupRequest{
 		Name:       name,
 		Parameters: parameters,
 		Secrets:    secrets,
 	}
 
-	createCtx, cancel := context.WithTimeout(context.Background(), rc.timeout)
+	unlock, err := lockVolumeGroupExistence(ctx, rc.driver, createVolumeGroupOperation, name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	release, err := rc.limiter.Acquire(ctx, createVolumeGroupOperation)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	createCtx, cancel := context.WithTimeout(ctx, rc.timeout)
 	defer cancel()
 	resp, err := rc.client.CreateVolumeGroup(createCtx, req)
 
 	return resp, err
 }
 
-func (rc *volumeGroupClient) DeleteVolumeGroup(volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error) {
+func (rc *volumeGroupClient) DeleteVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error) {
 	req := &csi.DeleteVolumeGroupRequest{
 		VolumeGroupId: volumeGroupId,
 		Secrets:       secrets,
 	}
 
-	createCtx, cancel := context.WithTimeout(context.Background(), rc.timeout)
+	unlock, err := lockVolumeGroupExistence(ctx, rc.driver, deleteVolumeGroupOperation, volumeGroupId)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	release, err := rc.limiter.Acquire(ctx, deleteVolumeGroupOperation)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	createCtx, cancel := context.WithTimeout(ctx, rc.timeout)
 	defer cancel()
 	resp, err := rc.client.DeleteVolumeGroup(createCtx, req)
 
 	return resp, err
 }
 
-func (rc *volumeGroupClient) ModifyVolumeGroupMembership(volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error) {
+func (rc *volumeGroupClient) ModifyVolumeGroupMembership(ctx context.Context, volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error) {
 	req := &csi.ModifyVolumeGroupMembershipRequest{
 		VolumeGroupId: volumeGroupId,
 		VolumeIds:     volumeIds,
 		Secrets:       secrets,
 	}
 
-	createCtx, cancel := context.WithTimeout(context.Background(), rc.timeout)
+	unlock, err := lockVolumeGroup(ctx, rc.driver, modifyVolumeGroupMembershipOperation, volumeGroupId)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	release, err := rc.limiter.Acquire(ctx, modifyVolumeGroupMembershipOperation)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	createCtx, cancel := context.WithTimeout(ctx, rc.timeout)
 	defer cancel()
 	resp, err := rc.client.ModifyVolumeGroupMembership(createCtx, req)
 
 	return resp, err
 }
+
+func (rc *volumeGroupClient) ControllerGetVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.ControllerGetVolumeGroupResponse, error) {
+	req := &csi.ControllerGetVolumeGroupRequest{
+		VolumeGroupId: volumeGroupId,
+		Secrets:       secrets,
+	}
+
+	unlock, err := lockVolumeGroup(ctx, rc.driver, controllerGetVolumeGroupOperation, volumeGroupId)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	release, err := rc.limiter.Acquire(ctx, controllerGetVolumeGroupOperation)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	createCtx, cancel := context.WithTimeout(ctx, rc.timeout)
+	defer cancel()
+	resp, err := rc.client.ControllerGetVolumeGroup(createCtx, req)
+
+	return resp, err
+}
+
+func (rc *volumeGroupClient) ListVolumeGroups(ctx context.Context, maxEntries int32, startingToken string, secrets map[string]string) (*csi.ListVolumeGroupsResponse, error) {
+	req := &csi.ListVolumeGroupsRequest{
+		MaxEntries:    maxEntries,
+		StartingToken: startingToken,
+		Secrets:       secrets,
+	}
+
+	unlock, err := lockVolumeGroupList(ctx, rc.driver)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	release, err := rc.limiter.Acquire(ctx, listVolumeGroupsOperation)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	createCtx, cancel := context.WithTimeout(ctx, rc.timeout)
+	defer cancel()
+	resp, err := rc.client.ListVolumeGroups(createCtx, req)
+
+	return resp, err
+}
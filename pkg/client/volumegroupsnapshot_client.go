@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/grpc"
+)
+
+type volumeGroupSnapshotClient struct {
+	driver  string
+	client  csi.GroupControllerClient
+	timeout time.Duration
+	limiter *RPCLimiter
+}
+
+type VolumeGroupSnapshot interface {
+	CreateVolumeGroupSnapshot(ctx context.Context, name string, sourceVolumeIds []string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupSnapshotResponse, error)
+	DeleteVolumeGroupSnapshot(ctx context.Context, groupSnapshotId string, snapshotIds []string, secrets map[string]string) (*csi.DeleteVolumeGroupSnapshotResponse, error)
+	GetVolumeGroupSnapshot(ctx context.Context, groupSnapshotId string, snapshotIds []string, secrets map[string]string) (*csi.GetVolumeGroupSnapshotResponse, error)
+}
+
+func NewVolumeGroupSnapshotClient(driver string, cc *grpc.ClientConn, timeout time.Duration, limiter *RPCLimiter) VolumeGroupSnapshot {
+	return &volumeGroupSnapshotClient{driver: driver, client: csi.NewGroupControllerClient(cc), timeout: timeout, limiter: limiter}
+}
+
+func (rc *volumeGroupSnapshotClient) CreateVolumeGroupSnapshot(ctx context.Context, name string, sourceVolumeIds []string, secrets,
+	parameters map[string]string) (*csi.CreateVolumeGroupSnapshotResponse, error) {
+	req := &csi.CreateVolumeGroupSnapshotRequest{
+		Name:            name,
+		SourceVolumeIds: sourceVolumeIds,
+		Parameters:      parameters,
+		Secrets:         secrets,
+	}
+
+	release, err := rc.limiter.Acquire(ctx, createVolumeGroupSnapshotOperation)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	createCtx, cancel := context.WithTimeout(ctx, rc.timeout)
+	defer cancel()
+	resp, err := rc.client.CreateVolumeGroupSnapshot(createCtx, req)
+
+	return resp, err
+}
+
+func (rc *volumeGroupSnapshotClient) DeleteVolumeGroupSnapshot(ctx context.Context, groupSnapshotId string, snapshotIds []string,
+	secrets map[string]string) (*csi.DeleteVolumeGroupSnapshotResponse, error) {
+	req := &csi.DeleteVolumeGroupSnapshotRequest{
+		GroupSnapshotId: groupSnapshotId,
+		SnapshotIds:     snapshotIds,
+		Secrets:         secrets,
+	}
+
+	release, err := rc.limiter.Acquire(ctx, deleteVolumeGroupSnapshotOperation)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	deleteCtx, cancel := context.WithTimeout(ctx, rc.timeout)
+	defer cancel()
+	resp, err := rc.client.DeleteVolumeGroupSnapshot(deleteCtx, req)
+
+	return resp, err
+}
+
+func (rc *volumeGroupSnapshotClient) GetVolumeGroupSnapshot(ctx context.Context, groupSnapshotId string, snapshotIds []string,
+	secrets map[string]string) (*csi.GetVolumeGroupSnapshotResponse, error) {
+	req := &csi.GetVolumeGroupSnapshotRequest{
+		GroupSnapshotId: groupSnapshotId,
+		SnapshotIds:     snapshotIds,
+		Secrets:         secrets,
+	}
+
+	release, err := rc.limiter.Acquire(ctx, getVolumeGroupSnapshotOperation)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	getCtx, cancel := context.WithTimeout(ctx, rc.timeout)
+	defer cancel()
+	resp, err := rc.client.GetVolumeGroupSnapshot(getCtx, req)
+
+	return resp, err
+}
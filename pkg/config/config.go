@@ -18,15 +18,45 @@ package config
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
 type DriverConfig struct {
-	DriverEndpoint    string
-	DriverName        string
-	RPCTimeout        time.Duration
-	MultipleVGsToPVC  string
-	DisableDeletePvcs string
+	DriverEndpoint                 string
+	DriverName                     string
+	RPCTimeout                     time.Duration
+	Drivers                        DriverEndpoints
+	MultipleVGsToPVC               string
+	DisableDeletePvcs              string
+	DriftCheckInterval             time.Duration
+	DiscoveryInterval              time.Duration
+	CapabilityDiscoveryInterval    time.Duration
+	OrphanGCInterval               time.Duration
+	OrphanGCGracePeriod            time.Duration
+	OrphanGCDryRun                 bool
+	RetryMaxDelay                  time.Duration
+	MembershipBatchWindow          time.Duration
+	MembershipBatchMaxSize         int
+	VGMaxConcurrentReconciles      int
+	VGCMaxConcurrentReconciles     int
+	VGClassMaxConcurrentReconciles int
+	VGSMaxConcurrentReconciles     int
+	VGSCMaxConcurrentReconciles    int
+	RPCQPS                         float64
+	RPCBurst                       int
+	RPCMaxInFlight                 int
+	RPCOperationLimits             string
+	UnsupportedOperations          string
+	TLSCAFile                      string
+	TLSCertFile                    string
+	TLSKeyFile                     string
+	TLSSecret                      string
+	TLSServerName                  string
+	KeepaliveTime                  time.Duration
+	KeepaliveTimeout               time.Duration
+	KeepalivePermitWithoutCalls    bool
 }
 
 func NewDriverConfig() *DriverConfig {
@@ -35,9 +65,96 @@ func NewDriverConfig() *DriverConfig {
 
 func (cfg *DriverConfig) Validate() error {
 
-	if cfg.DriverName == "" {
+	driverEndpoints := cfg.GetDriverEndpoints()
+	if len(driverEndpoints) == 0 {
 		return errors.New("driverName is empty")
 	}
+	driverNames := map[string]bool{}
+	for _, driverEndpoint := range driverEndpoints {
+		if driverNames[driverEndpoint.Name] {
+			return fmt.Errorf("driver %s is defined more than once", driverEndpoint.Name)
+		}
+		driverNames[driverEndpoint.Name] = true
+	}
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return errors.New("tlsCertFile and tlsKeyFile must be set together")
+	}
+	if cfg.TLSSecret != "" {
+		if cfg.TLSCAFile != "" || cfg.TLSCertFile != "" {
+			return errors.New("tlsSecret cannot be set together with TLS files")
+		}
+		if _, _, err := cfg.GetTLSSecret(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
+// GetTLSSecret returns the namespace and the name of the secret given as <namespace>/<name>.
+func (cfg *DriverConfig) GetTLSSecret() (string, string, error) {
+	namespace, name, ok := strings.Cut(cfg.TLSSecret, "/")
+	if !ok || namespace == "" || name == "" {
+		return "", "", fmt.Errorf("invalid tlsSecret %q, expected <namespace>/<name>", cfg.TLSSecret)
+	}
+	return namespace, name, nil
+}
+
+// GetDriverEndpoints returns the CSI drivers served by the operator, the driver of driverName first.
+func (cfg *DriverConfig) GetDriverEndpoints() []DriverEndpoint {
+	var driverEndpoints []DriverEndpoint
+	if cfg.DriverName != "" {
+		driverEndpoints = append(driverEndpoints, DriverEndpoint{
+			Name:       cfg.DriverName,
+			Endpoint:   cfg.DriverEndpoint,
+			RPCTimeout: cfg.RPCTimeout,
+		})
+	}
+	for _, driverEndpoint := range cfg.Drivers {
+		if driverEndpoint.RPCTimeout == 0 {
+			driverEndpoint.RPCTimeout = cfg.RPCTimeout
+		}
+		driverEndpoints = append(driverEndpoints, driverEndpoint)
+	}
+	return driverEndpoints
+}
+
+// DriverEndpoint is the endpoint of a CSI driver and the timeout of its RPCs, 0 means the default RPC timeout.
+type DriverEndpoint struct {
+	Name       string
+	Endpoint   string
+	RPCTimeout time.Duration
+}
+
+// DriverEndpoints is a flag of CSI drivers given as <name>=<endpoint>[,<rpcTimeout>], it can be repeated.
+type DriverEndpoints []DriverEndpoint
+
+func (d *DriverEndpoints) String() string {
+	var values []string
+	for _, driverEndpoint := range *d {
+		value := driverEndpoint.Name + "=" + driverEndpoint.Endpoint
+		if driverEndpoint.RPCTimeout != 0 {
+			value += "," + driverEndpoint.RPCTimeout.String()
+		}
+		values = append(values, value)
+	}
+	return strings.Join(values, " ")
+}
+
+func (d *DriverEndpoints) Set(value string) error {
+	name, endpoint, ok := strings.Cut(value, "=")
+	if !ok || name == "" || endpoint == "" {
+		return fmt.Errorf("invalid driver %q, expected <name>=<endpoint>[,<rpcTimeout>]", value)
+	}
+	driverEndpoint := DriverEndpoint{Name: name, Endpoint: endpoint}
+	if endpoint, timeout, ok := strings.Cut(endpoint, ","); ok {
+		rpcTimeout, err := time.ParseDuration(timeout)
+		if err != nil {
+			return fmt.Errorf("invalid RPC timeout of driver %s: %w", name, err)
+		}
+		driverEndpoint.Endpoint = endpoint
+		driverEndpoint.RPCTimeout = rpcTimeout
+	}
+	*d = append(*d, driverEndpoint)
 	return nil
 }
@@ -17,44 +17,118 @@ limitations under the License.
 package messages
 
 var (
-	ReconcileVG                    = "Reconciling VolumeGroup"
-	UnableToCreateVGController     = "Unable to create VolumeGroup controller"
-	UnableToCreateVGCController    = "Unable to create VolumeGroupContent controller"
-	PVCNotFound                    = "%s/%s persistentVolumeClaim not found"
-	ListVGs                        = "Listing volumeGroups"
-	CheckIfPVCMatchesVG            = "Checking if %s/%s persistentVolumeClaim is matches %s/%s volumeGroup"
-	PVCMatchedToVG                 = "%s/%s persistentVolumeClaim is matched with %s/%s volumeGroup"
-	PVCNotMatchedToVG              = "%s/%s persistentVolumeClaim is not matched with %s/%s volumeGroup"
-	RemovePVCFromVG                = "Removing %s/%s persistentVolumeClaim from %s/%s volumeGroup"
-	RemovedPVCFromVG               = "Successfully removed %s/%s persistentVolumeClaim from %s/%s volumeGroup"
-	PVCDoesNotHavePV               = "PersistentVolumeClaim does not Have persistentVolume"
-	GetPVOfPVC                     = "Get matching persistentVolume from %s/%s persistentVolumeClaim"
-	GetVGC                         = "Get %s/%s volumeGroupContent"
-	GetVG                          = "Get %s/%s volumeGroup"
-	RemovePVFromVGC                = "Removing %s/%s persistentVolume from %s/%s volumeGroupContent"
-	RemovedPVFromVGC               = "Successfully removed %s persistentVolume from %s/%s volumeGroupContent"
-	FailedToModifyVG               = "Failed to modify %s/%s volumeGroup"
-	AddPVCToVG                     = "Adding %s/%s persistentVolumeClaim to %s/%s volumeGroup"
-	AddedPVCToVG                   = "Successfully added %s/%s persistentVolumeClaim to %s/%s volumeGroup"
-	AddPVToVG                      = "Adding %s persistentVolume to %s/%s volumeGroup"
-	AddedPVToVGC                   = "Successfully added %s persistentVolume to %s/%s volumeGroupContent"
-	ModifyVG                       = "Modifying %s volumeGroupID with %v volumeIDs"
-	ModifiedVG                     = "Successfully modified %s volumeGroupID"
-	CreateEventForNamespacedObject = "Creating event for %s/%s %s, with [%s] message"
-	EventCreated                   = "Successfully Created  %s/%s event"
-	UpdateVGStatus                 = "Updating status of %s/%s volumeGroup"
-	GetPVC                         = "Getting %s/%s persistentVolumeClaim"
-	GetPV                          = "Getting %s persistentVolume"
-	PVCIsNotInBoundPhase           = "PersistentVolumeClaim is not in bound phase, stopping the reconcile, when it will be in bound phase, reconcile will continue"
-	StorageClassHasVGParameter     = "StorageClass %s contain parameter volume_group for claim %s/%s. volumegroup feature is not supported"
-	ListPVCs                       = "Listing PersistentVolumeClaims"
-	VGCreated                      = "Successfully Created  %s/%s volumeGroup"
-	VGCCreated                     = "Successfully Created  %s/%s volumeGroupContent"
-	RetryUpdateVGStatus            = "Retry update %s/%s volumeGroup status due to conflict error"
-	RetryUpdateVGCtStatus          = "Retry update %s/%s volumeGroupContent status due to conflict error"
-	RetryUpdateFinalizer           = "Retry update finalizer due to conflict error"
-	NonVolumeGroupFinalizers       = "%s/%s have a non-volumegroup finalizers"
-	VgIsStillExist                 = "Cant delete %s/%s volumeGroupContent because volumeGroup is still exist"
-	DeletePVCsUnderVGC             = "Deleting persistentVolumeClaims under %s/%s volumeGroupContent"
-	DeletePVC                      = "Deleting %s/%s persistentVolumeClaim"
+	ReconcileVG                       = "Reconciling VolumeGroup"
+	UnableToCreateVGController        = "Unable to create VolumeGroup controller"
+	UnableToCreateVGCController       = "Unable to create VolumeGroupContent controller"
+	PVCNotFound                       = "%s/%s persistentVolumeClaim not found"
+	ListVGs                           = "Listing volumeGroups"
+	CheckIfPVCMatchesVG               = "Checking if %s/%s persistentVolumeClaim is matches %s/%s volumeGroup"
+	PVCMatchedToVG                    = "%s/%s persistentVolumeClaim is matched with %s/%s volumeGroup"
+	PVCNotMatchedToVG                 = "%s/%s persistentVolumeClaim is not matched with %s/%s volumeGroup"
+	RemovePVCFromVG                   = "Removing %s/%s persistentVolumeClaim from %s/%s volumeGroup"
+	RemovedPVCFromVG                  = "Successfully removed %s/%s persistentVolumeClaim from %s/%s volumeGroup"
+	PVCDoesNotHavePV                  = "PersistentVolumeClaim does not Have persistentVolume"
+	GetPVOfPVC                        = "Get matching persistentVolume from %s/%s persistentVolumeClaim"
+	GetVGC                            = "Get %s/%s volumeGroupContent"
+	GetVG                             = "Get %s/%s volumeGroup"
+	RemovePVFromVGC                   = "Removing %s persistentVolume from %s/%s volumeGroupContent"
+	RemovedPVFromVGC                  = "Successfully removed %s persistentVolume from %s/%s volumeGroupContent"
+	FailedToModifyVG                  = "Failed to modify %s/%s volumeGroup"
+	AddPVCToVG                        = "Adding %s/%s persistentVolumeClaim to %s/%s volumeGroup"
+	AddedPVCToVG                      = "Successfully added %s/%s persistentVolumeClaim to %s/%s volumeGroup"
+	AddPVToVG                         = "Adding %s persistentVolume to %s/%s volumeGroup"
+	AddedPVToVGC                      = "Successfully added %s persistentVolume to %s/%s volumeGroupContent"
+	ModifyVG                          = "Modifying %s volumeGroupID with %v volumeIDs"
+	ModifiedVG                        = "Successfully modified %s volumeGroupID"
+	CreateEventForNamespacedObject    = "Creating event for %s/%s %s, with [%s] message"
+	EventCreated                      = "Successfully Created  %s/%s event"
+	UpdateVGStatus                    = "Updating status of %s/%s volumeGroup"
+	GetPVC                            = "Getting %s/%s persistentVolumeClaim"
+	GetPV                             = "Getting %s persistentVolume"
+	PVCIsNotInBoundPhase              = "PersistentVolumeClaim is not in bound phase, stopping the reconcile, when it will be in bound phase, reconcile will continue"
+	StorageClassHasVGParameter        = "StorageClass %s contain parameter volume_group for claim %s/%s. volumegroup feature is not supported"
+	ListPVCs                          = "Listing PersistentVolumeClaims"
+	PVCIsBeingDeleted                 = "%s/%s persistentVolumeClaim is being deleted, it is removed from its volumeGroups"
+	ListNamespacePVCs                 = "Listing PersistentVolumeClaims in %s namespace"
+	VGCreated                         = "Successfully Created  %s/%s volumeGroup"
+	VGCCreated                        = "Successfully Created  %s/%s volumeGroupContent"
+	RetryUpdateVGStatus               = "Retry update %s/%s volumeGroup status due to conflict error"
+	RetryUpdateVGCtStatus             = "Retry update %s/%s volumeGroupContent status due to conflict error"
+	RetryUpdateVGClassStatus          = "Retry update %s volumeGroupClass status due to conflict error"
+	RetryUpdateFinalizer              = "Retry update finalizer due to conflict error"
+	NonVolumeGroupFinalizers          = "%s/%s have a non-volumegroup finalizers"
+	VgIsStillExist                    = "Cant delete %s/%s volumeGroupContent because volumeGroup is still exist"
+	VgsIsStillExist                   = "Cant delete %s/%s volumeGroupSnapshotContent because volumeGroupSnapshot is still exist"
+	DeletePVCsUnderVGC                = "Deleting persistentVolumeClaims under %s/%s volumeGroupContent"
+	DeletePVC                         = "Deleting %s/%s persistentVolumeClaim"
+	GetNamespace                      = "Getting %s namespace"
+	VGBoundToVGC                      = "%s/%s volumeGroup is bound to %s volumeGroupContent"
+	VGCIsNotReady                     = "Waiting for %s/%s volumeGroupContent to be ready"
+	VGCWithoutVGClass                 = "%s/%s volumeGroupContent does not have a volumeGroupClass"
+	VGDeletionRequested               = "%s/%s volumeGroup is being deleted"
+	VGCDeletionRequested              = "%s/%s volumeGroupContent is being deleted"
+	ReconcileVGS                      = "Reconciling VolumeGroupSnapshot"
+	ReconcileVGSC                     = "Reconciling VolumeGroupSnapshotContent"
+	UnableToCreateVGSController       = "Unable to create VolumeGroupSnapshot controller"
+	UnableToCreateVGSCController      = "Unable to create VolumeGroupSnapshotContent controller"
+	GetVGS                            = "Get %s/%s volumeGroupSnapshot"
+	GetVGSC                           = "Get %s/%s volumeGroupSnapshotContent"
+	VGSBoundToVGSC                    = "%s/%s volumeGroupSnapshot is bound to %s volumeGroupSnapshotContent"
+	VGSCIsNotReady                    = "Waiting for %s/%s volumeGroupSnapshotContent to be ready to use"
+	VGSCIsNotReadyToUse               = "%s/%s volumeGroupSnapshotContent is not ready to use yet"
+	VGIsNotBound                      = "Waiting for %s/%s volumeGroup to be bound to a volumeGroupContent"
+	VGSCreated                        = "Successfully Created  %s/%s volumeGroupSnapshot"
+	VGSCCreated                       = "Successfully Created  %s/%s volumeGroupSnapshotContent"
+	VGSDeletionRequested              = "%s/%s volumeGroupSnapshot is being deleted"
+	VGSCDeletionRequested             = "%s/%s volumeGroupSnapshotContent is being deleted"
+	RetryUpdateVGSStatus              = "Retry update %s/%s volumeGroupSnapshot status due to conflict error"
+	RetryUpdateVGSCStatus             = "Retry update %s/%s volumeGroupSnapshotContent status due to conflict error"
+	CreateVGSnapshot                  = "Creating %s volumeGroupSnapshot of %v volumeIDs"
+	DeleteVGSnapshot                  = "Deleting %s volumeGroupSnapshotID with %v snapshotIDs"
+	ReconcileVGClass                  = "Reconciling VolumeGroupClass"
+	UnableToCreateVGClassController   = "Unable to create VolumeGroupClass controller"
+	CreateRestoredPVC                 = "Creating %s/%s persistentVolumeClaim from %s snapshot"
+	CreateRestoreSnapshotObject       = "Creating %s %s/%s to restore a volumeGroup member"
+	DeleteRestoreSnapshotObject       = "Deleting %s %s/%s of a restored volumeGroup member"
+	VGIsRestored                      = "%s/%s volumeGroup is restored from %s volumeGroupSnapshot"
+	VGIsNotRestored                   = "Waiting for %s/%s volumeGroup to be restored from %s volumeGroupSnapshot"
+	GetVGOnStorage                    = "Getting %s volumeGroup from the storage to check %s/%s volumeGroupContent membership"
+	GetVGIsNotSupported               = "The driver does not support getting a volumeGroup, skipping membership drift check"
+	VGCMembershipDrift                = "Membership of %s/%s volumeGroupContent drifted from the storage, missing volumes %v, unexpected volumes %v"
+	VGCMembershipDriftRemediated      = "Membership drift of %s/%s volumeGroupContent is remediated"
+	DiscoverVGs                       = "Discovering volumeGroups on the storage for %s volumeGroupClass"
+	CreateDiscoveredVGC               = "Creating %s/%s volumeGroupContent for discovered %s volumeGroup"
+	PostponeVGDiscovery               = "A volumeGroup of %s volumeGroupClass driver is being created, postponing the discovery"
+	RecordCreatedVG                   = "Recording %s volumeGroup created for %s/%s volumeGroupContent in %s volumeGroupClass status"
+	CollectOrphanedVGs                = "Collecting orphaned volumeGroups on the storage for %s volumeGroupClass"
+	ListVGsIsNotSupported             = "The driver does not support listing volumeGroups, skipping orphaned volumeGroup collection"
+	OrphanedVGFound                   = "Found orphaned %s volumeGroup on the storage that is not referenced by any volumeGroupContent"
+	OrphanedVGDeleted                 = "Deleted orphaned %s volumeGroup from the storage"
+	OrphanedVGDryRun                  = "Orphaned %s volumeGroup passed its grace period, skipping deletion in dry-run mode"
+	UnableToCreateOrphanedVGCollector = "Unable to create orphaned volumeGroup collector"
+	MarkVGCreationInProgress          = "Marking the volumeGroup creation of %s/%s volumeGroupContent as in progress"
+	ResumeVGCreation                  = "Resuming the in progress volumeGroup creation of %s/%s volumeGroupContent"
+	VGCreationInProgress              = "The volumeGroup creation of %s/%s volumeGroupContent is still in progress on the storage: %s"
+	RetryReconcile                    = "Retrying reconcile %d in %s: %s"
+	StopRetryReconcile                = "Not retrying reconcile %d until the spec changes: %s"
+	WaitForReconcile                  = "Reconcile %d is still waiting, retrying in %s"
+	RefreshVGCMembers                 = "Refreshing the persistentVolume members of %s/%s volumeGroupContent"
+	MigrateVGMembers                  = "Migrating the pvcList of %s/%s volumeGroup status to members"
+	MigrateVGCMembers                 = "Migrating the pvList of %s/%s volumeGroupContent status to members"
+	VGCPVsNotBound                    = "%s/%s volumeGroupContent has persistentVolumes that are not bound to their claims, deleted %v, rebound %v, released %v"
+	CreateVGM                         = "Creating %s/%s volumeGroupMember of %s/%s persistentVolumeClaim"
+	DeleteVGM                         = "Deleting %s/%s volumeGroupMember of %s/%s persistentVolumeClaim"
+	MoveVGMembersToObjects            = "Moving the members of %s/%s volumeGroup status to volumeGroupMembers"
+	MoveVGMembersToStatus             = "Moving the volumeGroupMembers of %s/%s volumeGroup to its status"
+	SetVGMembersIntent                = "Setting the desired members of %s/%s volumeGroup as pending and leaving"
+	RollbackVGMembers                 = "Rolling back the pending and leaving members of %s/%s volumeGroup"
+	VGModificationInProgress          = "The membership modification of %s/%s volumeGroup may still be in progress on the storage, keeping its pending and leaving members: %s"
+	ScheduleVGMembershipBatch         = "Coalescing %d membership changes of %s/%s volumeGroup until %s"
+	VGMembershipBatchIsPending        = "%d membership changes of %s/%s volumeGroup are sent to the storage at %s"
+	VGMembershipBatchIsTruncated      = "The membership batch is limited to %d changes, the other changes are sent in the next batch"
+	DriverIsUnreachable               = "The connection to %s driver is down, waiting for it to be reestablished"
+	DriverIsReachable                 = "The connection to %s driver is reestablished"
+	DriverCapabilitiesDiscovered      = "Discovered the capabilities of %s driver: %+v"
+	GetVGIsNotSupportedByDriver       = "%s driver does not support getting volumeGroups, skipping the membership drift check"
+	ListVGsIsNotSupportedByDriver     = "%s driver does not support listing volumeGroups, skipping orphaned volumeGroup collection"
 )
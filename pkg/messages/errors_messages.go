@@ -30,7 +30,37 @@ var (
 	PVCMatchedWithMultipleNewGroups      = "Failed to add %s/%s persistentVolumeClaim to VolumeGroups %v Because it matched more than one new VolumeGroups"
 	FailedToGetStorageClass              = "Failed to get %s storageClass"
 	FailedToListPVC                      = "Failed to list persistentVolumeClaim"
+	FailedToListNamespaces               = "Failed to list namespaces"
+	FailedToListStorageClasses           = "Failed to list storageClasses"
 	FailedToGetStorageClassName          = "Failed to get storageClass name from persistentVolumeClaim %s"
 	CannotFindMatchingPVCForPV           = "Cannot find matching persistentVolumeClaim for %s persistentVolume"
 	FailToRemovePVCObject                = "Fail To remove %s/%s persistentVolumeClaim object"
+	FailedToGetNamespace                 = "Failed to get %s namespace"
+	NamespaceSelectorIsNotAllowed        = "NamespaceSelector of %s/%s volumeGroup is not allowed by %s volumeGroupClass"
+	VGCDoesNotSupportSnapshot            = "%s/%s volumeGroupContent does not support volume group snapshots"
+	VGCHasNoVolumes                      = "%s/%s volumeGroupContent does not have any volumes to snapshot"
+	VGSCIsBoundToOtherVGS                = "%s/%s volumeGroupSnapshotContent is bound to %s/%s volumeGroupSnapshot"
+	VGSourceIsMissing                    = "%s/%s volumeGroupSnapshot does not have a volumeGroupName or a volumeGroupSnapshotContentName source"
+	UnsupportedVGDataSource              = "DataSource of %s/%s volumeGroup has unsupported kind %s, only VolumeGroupSnapshot is supported"
+	VGDataSourceWithVGC                  = "%s/%s volumeGroup cannot have both a dataSource and a volumeGroupContentName"
+	VGDataSourceWithoutMatchLabels       = "%s/%s volumeGroup with a dataSource must have a selector with matchLabels"
+	RestoreSizeIsMissing                 = "Cannot find the restore size of %s snapshot"
+	FailedToCreateRestoredPVC            = "Failed to create %s/%s persistentVolumeClaim from snapshot"
+	FailedToCreateRestoreSnapshotObject  = "Failed to create %s %s/%s to restore a volumeGroup member"
+	FailedToDeleteRestoreSnapshotObject  = "Failed to delete %s %s/%s of a restored volumeGroup member"
+	FailedToListVGsOnStorage             = "Failed to list volumeGroups on the storage"
+	FailedToListVGC                      = "Failed to list volumeGroupContents"
+	FailedToListVGClass                  = "Failed to list volumeGroupClasses"
+	FailedToDeleteOrphanedVG             = "Failed to delete orphaned %s volumeGroup"
+	FailedToListPV                       = "Failed to list persistentVolumes"
+	FailedToCreateDiscoveredVGC          = "Failed to create %s/%s volumeGroupContent for a discovered volumeGroup"
+	VGRestoreFailed                      = "Failed to restore %v persistentVolumeClaims of %s/%s volumeGroup"
+	FailedToCreateVGM                    = "Failed to create %s/%s volumeGroupMember"
+	FailedToDeleteVGM                    = "Failed to delete %s/%s volumeGroupMember"
+	FailedToUpdateVGMStatus              = "Failed to update status of %s/%s volumeGroupMember"
+	FailedToListVGMs                     = "Failed to list volumeGroupMembers of %s/%s volumeGroup"
+	VGClassSettingsAreNotSupported       = "%s driver does not support %s of %s volumeGroupClass"
+	VGSnapshotIsNotSupported             = "%s driver does not support volumeGroup snapshots"
+	VGModificationIsNotSupported         = "%s driver does not support modifying the membership of volumeGroups"
+	FailedToDiscoverDriver               = "Failed to discover the identity and the capabilities of %s driver"
 )
@@ -0,0 +1,213 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespaceLabel          = "namespace"
+	volumeGroupContentLabel = "volume_group_content"
+	volumeGroupClassLabel   = "volume_group_class"
+	operationLabel          = "operation"
+	driverLabel             = "driver"
+	volumeGroupLabel        = "volume_group"
+	stateLabel              = "state"
+	resultLabel             = "result"
+	methodLabel             = "method"
+	codeLabel               = "code"
+	reasonLabel             = "reason"
+	kindLabel               = "kind"
+)
+
+// States of the volume group state metric.
+const (
+	VGStatePending  = "Pending"
+	VGStateReady    = "Ready"
+	VGStateFailed   = "Failed"
+	VGStateDeleting = "Deleting"
+)
+
+var vgStates = []string{VGStatePending, VGStateReady, VGStateFailed, VGStateDeleting}
+
+// Results of the membership operations metric.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Reasons of the rejected persistentVolumeClaims metric.
+const (
+	// RejectedPVCInOtherVG means the claim already belongs to another volume group.
+	RejectedPVCInOtherVG = "InOtherVolumeGroup"
+	// RejectedPVCMatchesMultipleVGs means the claim matches more than one new volume group.
+	RejectedPVCMatchesMultipleVGs = "MatchesMultipleVolumeGroups"
+	// RejectedPVCInStaticVG means the storage class of the claim puts its volumes in a volume group.
+	RejectedPVCInStaticVG = "StorageClassHasVolumeGroupParameter"
+)
+
+var (
+	MembershipDriftVolumes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "volume_group_membership_drift_volumes",
+			Help: "Number of volumes that differ between a volumeGroupContent and its volume group on the storage system",
+		},
+		[]string{namespaceLabel, volumeGroupContentLabel},
+	)
+	MembershipDriftRemediationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "volume_group_membership_drift_remediations_total",
+			Help: "Number of membership drift remediations of a volumeGroupContent",
+		},
+		[]string{namespaceLabel, volumeGroupContentLabel},
+	)
+	OrphanedVolumeGroups = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "volume_group_orphaned_volume_groups",
+			Help: "Number of volume groups on the storage system that were created by the operator and are not referenced by a volumeGroupContent",
+		},
+		[]string{volumeGroupClassLabel},
+	)
+	OrphanedVolumeGroupsDeletedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "volume_group_orphaned_volume_groups_deleted_total",
+			Help: "Number of orphaned volume groups deleted from the storage system",
+		},
+		[]string{volumeGroupClassLabel},
+	)
+	OperationLockWaitSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "volume_group_operation_lock_wait_seconds",
+			Help:    "Time a driver operation waited for the other operations on the same volume group to finish",
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+		},
+		[]string{driverLabel, operationLabel},
+	)
+	OperationLockWaiting = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "volume_group_operation_lock_waiting",
+			Help: "Number of driver operations that wait for another operation on the same volume group",
+		},
+		[]string{driverLabel, operationLabel},
+	)
+	RPCLimiterWaitSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "volume_group_rpc_limiter_wait_seconds",
+			Help:    "Time a driver RPC waited for the rate limit and the in-flight cap",
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+		},
+		[]string{driverLabel, operationLabel},
+	)
+	RPCLimiterWaiting = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "volume_group_rpc_limiter_waiting",
+			Help: "Number of driver RPCs that wait for the rate limit or the in-flight cap",
+		},
+		[]string{driverLabel, operationLabel},
+	)
+	RPCInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "volume_group_rpc_in_flight",
+			Help: "Number of driver RPCs in flight",
+		},
+		[]string{driverLabel, operationLabel},
+	)
+	DriverReachable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "volume_group_driver_reachable",
+			Help: "Whether the connection to the driver is up, 1 when it is and 0 when it is down",
+		},
+		[]string{driverLabel},
+	)
+	VolumeGroupState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "volume_group_state",
+			Help: "The state of a volumeGroup, 1 for its current state and 0 for the other states",
+		},
+		[]string{namespaceLabel, volumeGroupLabel, stateLabel},
+	)
+	VolumeGroupMembers = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "volume_group_members",
+			Help: "Number of members of a volumeGroup",
+		},
+		[]string{namespaceLabel, volumeGroupLabel},
+	)
+	MembershipOperationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "volume_group_membership_operations_total",
+			Help: "Number of membership modifications of a volumeGroup on the storage system by result",
+		},
+		[]string{namespaceLabel, volumeGroupLabel, resultLabel},
+	)
+	RPCDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "volume_group_rpc_duration_seconds",
+			Help:    "Latency of driver RPCs by method and gRPC status code",
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+		},
+		[]string{driverLabel, methodLabel, codeLabel},
+	)
+	RejectedPVCsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "volume_group_rejected_pvcs_total",
+			Help: "Number of times a persistentVolumeClaim that matches a volumeGroup was not added to it",
+		},
+		[]string{namespaceLabel, reasonLabel},
+	)
+	FinalizerBlockedDeletionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "volume_group_finalizer_blocked_deletions_total",
+			Help: "Number of reconciles of a deleted object that waited for the finalizers of other controllers",
+		},
+		[]string{kindLabel},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(MembershipDriftVolumes, MembershipDriftRemediationsTotal,
+		OrphanedVolumeGroups, OrphanedVolumeGroupsDeletedTotal, OperationLockWaitSeconds, OperationLockWaiting,
+		RPCLimiterWaitSeconds, RPCLimiterWaiting, RPCInFlight, DriverReachable, VolumeGroupState, VolumeGroupMembers,
+		MembershipOperationsTotal, RPCDurationSeconds, RejectedPVCsTotal, FinalizerBlockedDeletionsTotal)
+}
+
+func DeleteVGCMetrics(namespace, name string) {
+	MembershipDriftVolumes.DeleteLabelValues(namespace, name)
+	MembershipDriftRemediationsTotal.DeleteLabelValues(namespace, name)
+}
+
+// SetVGMetrics sets the state and the number of members of a volumeGroup.
+func SetVGMetrics(namespace, name, state string, members int) {
+	for _, vgState := range vgStates {
+		value := 0.0
+		if vgState == state {
+			value = 1
+		}
+		VolumeGroupState.WithLabelValues(namespace, name, vgState).Set(value)
+	}
+	VolumeGroupMembers.WithLabelValues(namespace, name).Set(float64(members))
+}
+
+func DeleteVGMetrics(namespace, name string) {
+	for _, vgState := range vgStates {
+		VolumeGroupState.DeleteLabelValues(namespace, name, vgState)
+	}
+	VolumeGroupMembers.DeleteLabelValues(namespace, name)
+	MembershipOperationsTotal.DeletePartialMatch(prometheus.Labels{namespaceLabel: namespace, volumeGroupLabel: name})
+}
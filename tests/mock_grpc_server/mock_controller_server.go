@@ -18,15 +18,116 @@ package mock_grpc_server
 
 import (
 	"context"
+	"sort"
+	"strconv"
+	"sync"
 
 	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type MockControllerServer struct {
 	csi.UnimplementedControllerServer
 }
 
+var (
+	volumeGroupMembersLock sync.Mutex
+	volumeGroupMembers     = map[string][]string{}
+	createVolumeGroupError error
+	modifyVolumeGroupError error
+	listVolumeGroupsError  error
+)
+
+// SetCreateVolumeGroupError sets the error that CreateVolumeGroup returns, nil restores the success response.
+func SetCreateVolumeGroupError(err error) {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	createVolumeGroupError = err
+}
+
+// SetModifyVolumeGroupError sets the error that ModifyVolumeGroupMembership returns, nil restores the success response.
+func SetModifyVolumeGroupError(err error) {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	modifyVolumeGroupError = err
+}
+
+// SetListVolumeGroupsError sets the error that ListVolumeGroups returns, nil restores the success response.
+func SetListVolumeGroupsError(err error) {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	listVolumeGroupsError = err
+}
+
+// SetVolumeGroupMembers sets the volumes of a volume group on the mock storage.
+func SetVolumeGroupMembers(volumeGroupId string, volumeIds []string) {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	volumeGroupMembers[volumeGroupId] = volumeIds
+}
+
+// RemoveVolumeGroup removes a volume group from the mock storage.
+func RemoveVolumeGroup(volumeGroupId string) {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	delete(volumeGroupMembers, volumeGroupId)
+}
+
+// IsVolumeGroupExist returns whether a volume group exists on the mock storage.
+func IsVolumeGroupExist(volumeGroupId string) bool {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	_, ok := volumeGroupMembers[volumeGroupId]
+	return ok
+}
+
+// GetVolumeGroupVolumeIds returns the volumes of a volume group on the mock storage.
+func GetVolumeGroupVolumeIds(volumeGroupId string) []string {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	return append([]string{}, volumeGroupMembers[volumeGroupId]...)
+}
+
+func getModifyVolumeGroupError() error {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	return modifyVolumeGroupError
+}
+
+func getListVolumeGroupsError() error {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	return listVolumeGroupsError
+}
+
+func getVolumeGroupIds() []string {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	volumeGroupIds := []string{}
+	for volumeGroupId := range volumeGroupMembers {
+		volumeGroupIds = append(volumeGroupIds, volumeGroupId)
+	}
+	sort.Strings(volumeGroupIds)
+	return volumeGroupIds
+}
+
+func getVolumeGroupMembers(volumeGroupId string) []*csi.VgVolume {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	volumes := []*csi.VgVolume{}
+	for _, volumeId := range volumeGroupMembers[volumeGroupId] {
+		volumes = append(volumes, &csi.VgVolume{VolumeId: volumeId})
+	}
+	return volumes
+}
+
 func (MockControllerServer) CreateVolumeGroup(context.Context, *csi.CreateVolumeGroupRequest) (*csi.CreateVolumeGroupResponse, error) {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	if createVolumeGroupError != nil {
+		return nil, createVolumeGroupError
+	}
 	return &csi.CreateVolumeGroupResponse{
 		VolumeGroup: &csi.VolumeGroup{
 			VolumeGroupId: "test",
@@ -37,19 +138,69 @@ func (MockControllerServer) CreateVolumeGroup(context.Context, *csi.CreateVolume
 	}, nil
 }
 
-func (MockControllerServer) DeleteVolumeGroup(context.Context, *csi.DeleteVolumeGroupRequest) (*csi.DeleteVolumeGroupResponse, error) {
+func (MockControllerServer) DeleteVolumeGroup(_ context.Context, req *csi.DeleteVolumeGroupRequest) (*csi.DeleteVolumeGroupResponse, error) {
+	volumeGroupMembersLock.Lock()
+	defer volumeGroupMembersLock.Unlock()
+	delete(volumeGroupMembers, req.VolumeGroupId)
 	return &csi.DeleteVolumeGroupResponse{}, nil
 }
 
-func (MockControllerServer) ModifyVolumeGroupMembership(context.Context, *csi.ModifyVolumeGroupMembershipRequest) (*csi.ModifyVolumeGroupMembershipResponse, error) {
-	return &csi.ModifyVolumeGroupMembershipResponse{}, nil
+func (MockControllerServer) ModifyVolumeGroupMembership(_ context.Context, req *csi.ModifyVolumeGroupMembershipRequest) (*csi.ModifyVolumeGroupMembershipResponse, error) {
+	if req.VolumeGroupId == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume group id is missing")
+	}
+	if err := getModifyVolumeGroupError(); err != nil {
+		return nil, err
+	}
+	SetVolumeGroupMembers(req.VolumeGroupId, req.VolumeIds)
+	return &csi.ModifyVolumeGroupMembershipResponse{
+		VolumeGroup: &csi.VolumeGroup{
+			VolumeGroupId: req.VolumeGroupId,
+			Volumes:       getVolumeGroupMembers(req.VolumeGroupId),
+		},
+	}, nil
 }
 
-func (MockControllerServer) ListVolumeGroups(context.Context, *csi.ListVolumeGroupsRequest) (*csi.ListVolumeGroupsResponse, error) {
-	return &csi.ListVolumeGroupsResponse{}, nil
+func (MockControllerServer) ListVolumeGroups(_ context.Context, req *csi.ListVolumeGroupsRequest) (*csi.ListVolumeGroupsResponse, error) {
+	if err := getListVolumeGroupsError(); err != nil {
+		return nil, err
+	}
+	volumeGroupIds := getVolumeGroupIds()
+	start := 0
+	if req.StartingToken != "" {
+		var err error
+		if start, err = strconv.Atoi(req.StartingToken); err != nil || start > len(volumeGroupIds) {
+			return nil, status.Errorf(codes.Aborted, "invalid starting token %s", req.StartingToken)
+		}
+	}
+	end := len(volumeGroupIds)
+	if req.MaxEntries > 0 && start+int(req.MaxEntries) < end {
+		end = start + int(req.MaxEntries)
+	}
+	resp := &csi.ListVolumeGroupsResponse{}
+	for _, volumeGroupId := range volumeGroupIds[start:end] {
+		resp.Entries = append(resp.Entries, &csi.ListVolumeGroupsResponse_Entry{
+			VolumeGroup: &csi.VolumeGroup{
+				VolumeGroupId: volumeGroupId,
+				Volumes:       getVolumeGroupMembers(volumeGroupId),
+			},
+		})
+	}
+	if end < len(volumeGroupIds) {
+		resp.NextToken = strconv.Itoa(end)
+	}
+	return resp, nil
 }
-func (MockControllerServer) ControllerGetVolumeGroup(context.Context, *csi.ControllerGetVolumeGroupRequest) (*csi.ControllerGetVolumeGroupResponse, error) {
-	return &csi.ControllerGetVolumeGroupResponse{}, nil
+func (MockControllerServer) ControllerGetVolumeGroup(_ context.Context, req *csi.ControllerGetVolumeGroupRequest) (*csi.ControllerGetVolumeGroupResponse, error) {
+	if req.VolumeGroupId == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume group id is missing")
+	}
+	return &csi.ControllerGetVolumeGroupResponse{
+		VolumeGroup: &csi.VolumeGroup{
+			VolumeGroupId: req.VolumeGroupId,
+			Volumes:       getVolumeGroupMembers(req.VolumeGroupId),
+		},
+	}, nil
 }
 func (MockControllerServer) testEmbeddedByValue() {
 }
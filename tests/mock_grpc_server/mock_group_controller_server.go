@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock_grpc_server
+
+import (
+	"context"
+	"fmt"
+
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type MockGroupControllerServer struct {
+	csi.UnimplementedGroupControllerServer
+}
+
+func (MockGroupControllerServer) GroupControllerGetCapabilities(context.Context, *csi.GroupControllerGetCapabilitiesRequest) (*csi.GroupControllerGetCapabilitiesResponse, error) {
+	return &csi.GroupControllerGetCapabilitiesResponse{
+		Capabilities: []*csi.GroupControllerServiceCapability{{
+			Type: &csi.GroupControllerServiceCapability_Rpc{
+				Rpc: &csi.GroupControllerServiceCapability_RPC{
+					Type: csi.GroupControllerServiceCapability_RPC_CREATE_DELETE_GET_VOLUME_GROUP_SNAPSHOT,
+				},
+			},
+		}},
+	}, nil
+}
+
+func (MockGroupControllerServer) CreateVolumeGroupSnapshot(_ context.Context, req *csi.CreateVolumeGroupSnapshotRequest) (*csi.CreateVolumeGroupSnapshotResponse, error) {
+	return &csi.CreateVolumeGroupSnapshotResponse{
+		GroupSnapshot: generateVolumeGroupSnapshot("test-snapshot", req.SourceVolumeIds),
+	}, nil
+}
+
+func (MockGroupControllerServer) DeleteVolumeGroupSnapshot(context.Context, *csi.DeleteVolumeGroupSnapshotRequest) (*csi.DeleteVolumeGroupSnapshotResponse, error) {
+	return &csi.DeleteVolumeGroupSnapshotResponse{}, nil
+}
+
+func (MockGroupControllerServer) GetVolumeGroupSnapshot(_ context.Context, req *csi.GetVolumeGroupSnapshotRequest) (*csi.GetVolumeGroupSnapshotResponse, error) {
+	return &csi.GetVolumeGroupSnapshotResponse{
+		GroupSnapshot: &csi.VolumeGroupSnapshot{
+			GroupSnapshotId: req.GroupSnapshotId,
+			CreationTime:    timestamppb.Now(),
+			ReadyToUse:      true,
+		},
+	}, nil
+}
+
+func generateVolumeGroupSnapshot(groupSnapshotId string, volumeIds []string) *csi.VolumeGroupSnapshot {
+	snapshots := []*csi.Snapshot{}
+	for _, volumeId := range volumeIds {
+		snapshots = append(snapshots, &csi.Snapshot{
+			SizeBytes:       1024,
+			SnapshotId:      fmt.Sprintf("%s-%s", groupSnapshotId, volumeId),
+			SourceVolumeId:  volumeId,
+			CreationTime:    timestamppb.Now(),
+			ReadyToUse:      true,
+			GroupSnapshotId: groupSnapshotId,
+		})
+	}
+	return &csi.VolumeGroupSnapshot{
+		GroupSnapshotId: groupSnapshotId,
+		Snapshots:       snapshots,
+		CreationTime:    timestamppb.Now(),
+		ReadyToUse:      true,
+	}
+}
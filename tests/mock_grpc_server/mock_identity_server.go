@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock_grpc_server
+
+import (
+	"context"
+	"sync"
+
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type MockIdentityServer struct {
+	csi.UnimplementedIdentityServer
+}
+
+var (
+	driverNameLock sync.Mutex
+	driverName     string
+)
+
+// SetDriverName sets the driver name that GetPluginInfo returns.
+func SetDriverName(name string) {
+	driverNameLock.Lock()
+	defer driverNameLock.Unlock()
+	driverName = name
+}
+
+func (MockIdentityServer) GetPluginInfo(context.Context, *csi.GetPluginInfoRequest) (*csi.GetPluginInfoResponse, error) {
+	driverNameLock.Lock()
+	defer driverNameLock.Unlock()
+	return &csi.GetPluginInfoResponse{Name: driverName, VendorVersion: "test"}, nil
+}
+
+func (MockIdentityServer) GetPluginCapabilities(context.Context, *csi.GetPluginCapabilitiesRequest) (*csi.GetPluginCapabilitiesResponse, error) {
+	return &csi.GetPluginCapabilitiesResponse{
+		Capabilities: []*csi.PluginCapability{{
+			Type: &csi.PluginCapability_Service_{
+				Service: &csi.PluginCapability_Service{Type: csi.PluginCapability_Service_GROUP_CONTROLLER_SERVICE},
+			},
+		}},
+	}, nil
+}
+
+func (MockIdentityServer) Probe(context.Context, *csi.ProbeRequest) (*csi.ProbeResponse, error) {
+	return &csi.ProbeResponse{Ready: wrapperspb.Bool(true)}, nil
+}
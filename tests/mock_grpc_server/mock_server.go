@@ -17,6 +17,7 @@ limitations under the License.
 package mock_grpc_server
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -34,7 +35,8 @@ func CreateMockServer() (*MockServer, error) {
 		return nil, err
 	}
 	controllerServer := MockControllerServer{}
-	server := newMockServer(controllerServer)
+	groupControllerServer := MockGroupControllerServer{}
+	server := newMockServer(controllerServer, groupControllerServer)
 	err = server.startOnAddress("unix", filepath.Join(tmpdir, "csi.sock"))
 	if err != nil {
 		return nil, err
@@ -43,6 +45,18 @@ func CreateMockServer() (*MockServer, error) {
 	return server, nil
 }
 
+// CreateMockTLSServer starts the mock server on a local TCP port with the TLS configuration.
+func CreateMockTLSServer(tlsConfig *tls.Config) (*MockServer, error) {
+	server := newMockServer(MockControllerServer{}, MockGroupControllerServer{})
+	server.TLSConfig = tlsConfig
+	err := server.startOnAddress("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
 func tempDir() (string, error) {
 	dir, err := ioutil.TempDir("", "volume-group-operator-test-")
 	if err != nil {
@@ -51,10 +65,11 @@ func tempDir() (string, error) {
 	return dir, nil
 }
 
-func newMockServer(vg MockControllerServer) *MockServer {
+func newMockServer(vg MockControllerServer, gc MockGroupControllerServer) *MockServer {
 	return &MockServer{
 		VolumeGroupServer: VolumeGroupServer{
-			VolumeGroup: vg,
+			VolumeGroup:     vg,
+			GroupController: gc,
 		},
 	}
 }
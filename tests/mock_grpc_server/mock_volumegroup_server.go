@@ -18,23 +18,29 @@ package mock_grpc_server
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"sync"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+	csispec "github.com/container-storage-interface/spec/lib/go/csi"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
 type VolumeGroupServer struct {
-	listener    net.Listener
-	server      *grpc.Server
-	VolumeGroup MockControllerServer
-	wg          sync.WaitGroup
-	running     bool
-	lock        sync.Mutex
+	listener        net.Listener
+	server          *grpc.Server
+	VolumeGroup     MockControllerServer
+	GroupController MockGroupControllerServer
+	Identity        MockIdentityServer
+	TLSConfig       *tls.Config
+	wg              sync.WaitGroup
+	running         bool
+	lock            sync.Mutex
 }
 
 func (c *VolumeGroupServer) Address() string {
@@ -53,6 +59,8 @@ func (c *VolumeGroupServer) Start(listener net.Listener) error {
 	c.server = server
 
 	csi.RegisterControllerServer(c.server, c.VolumeGroup)
+	csispec.RegisterGroupControllerServer(c.server, c.GroupController)
+	csispec.RegisterIdentityServer(c.server, c.Identity)
 	reflection.Register(c.server)
 
 	waitForServer := make(chan bool)
@@ -74,6 +82,9 @@ func (c *VolumeGroupServer) createNewGRPCServer() (*grpc.Server, error) {
 	opts := []grpc.ServerOption{
 		grpc.UnaryInterceptor(logErr),
 	}
+	if c.TLSConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(c.TLSConfig)))
+	}
 	return grpc.NewServer(opts...), nil
 }
 